@@ -0,0 +1,361 @@
+package main
+
+import (
+	"coffe/config"
+	"coffe/internal/auth"
+	"coffe/internal/common"
+	"coffe/internal/database/postgres"
+	"coffe/internal/database/postgres/repositories"
+	redisdb "coffe/internal/database/redis"
+	eventshttp "coffe/internal/events/delivery/http"
+	eventsusecase "coffe/internal/events/usecase"
+	fiscalhttp "coffe/internal/fiscal/delivery/http"
+	fiscalentity "coffe/internal/fiscal/entity"
+	fiscalregistrar "coffe/internal/fiscal/registrar"
+	fiscalusecase "coffe/internal/fiscal/usecase"
+	inventoryhttp "coffe/internal/inventory/delivery/http"
+	inventoryentity "coffe/internal/inventory/entity"
+	inventoryusecase "coffe/internal/inventory/usecase"
+	kitchenhttp "coffe/internal/kitchen/delivery/http"
+	kitchenentity "coffe/internal/kitchen/entity"
+	kitchenusecase "coffe/internal/kitchen/usecase"
+	loyaltyhttp "coffe/internal/loyalty/delivery/http"
+	loyaltyentity "coffe/internal/loyalty/entity"
+	loyaltyusecase "coffe/internal/loyalty/usecase"
+	menuhttp "coffe/internal/menu/delivery/http/menu"
+	menuentity "coffe/internal/menu/entity"
+	menuusecase "coffe/internal/menu/usecase"
+	"coffe/internal/middleware"
+	orderhttp "coffe/internal/order/delivery/http"
+	orderentity "coffe/internal/order/entity"
+	orderusecase "coffe/internal/order/usecase"
+	paymenthttp "coffe/internal/payment/delivery/http"
+	paymententity "coffe/internal/payment/entity"
+	paymentprovider "coffe/internal/payment/provider"
+	paymentusecase "coffe/internal/payment/usecase"
+	promotionhttp "coffe/internal/promotion/delivery/http"
+	promotionentity "coffe/internal/promotion/entity"
+	promotionusecase "coffe/internal/promotion/usecase"
+	"coffe/internal/receipt"
+	receipthttp "coffe/internal/receipt/delivery/http"
+	staffhttp "coffe/internal/staff/delivery/http"
+	staffentity "coffe/internal/staff/entity"
+	staffusecase "coffe/internal/staff/usecase"
+	userhttp "coffe/internal/user/delivery/http"
+	userentity "coffe/internal/user/entity"
+	userrepository "coffe/internal/user/repository"
+	userusecase "coffe/internal/user/usecase"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func main() {
+	if err := run(); err != nil {
+		log.Fatalf("ошибка запуска сервера: %v", err)
+	}
+}
+
+// run собирает зависимости, запускает HTTP сервер и дожидается сигнала завершения.
+func run() error {
+	cfg := config.New()
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("ошибка конфигурации: %w", err)
+	}
+
+	db, err := postgres.Connect(cfg)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := postgres.Close(db); err != nil {
+			log.Printf("ошибка закрытия соединения с БД: %v", err)
+		}
+	}()
+
+	if err := migrate(db); err != nil {
+		return fmt.Errorf("ошибка миграции: %w", err)
+	}
+
+	redisClient := redisdb.NewRedisClient(redisdb.Config{
+		Addr:     cfg.RedisAddr,
+		Password: cfg.RedisPassword,
+		DB:       cfg.RedisDB,
+	})
+	defer func() {
+		if err := redisClient.Close(); err != nil {
+			log.Printf("ошибка закрытия соединения с Redis: %v", err)
+		}
+	}()
+
+	// Репозитории
+	userRepo := repositories.NewUserRepository(db)
+	permissionRepo := repositories.NewPermissionRepository(db)
+	menuRepo := repositories.NewMenuRepository(db)
+	productRepo := repositories.NewProductRepository(db)
+	orderRepo := repositories.NewOrderRepository(db)
+	stockRepo := repositories.NewStockRepository(db)
+	costRepo := repositories.NewCostRepository(db)
+	promotionRepo := repositories.NewPromotionRepository(db)
+	loyaltyRepo := repositories.NewLoyaltyRepository(db)
+	stampRepo := repositories.NewStampRepository(db)
+	paymentRepo := repositories.NewPaymentRepository(db)
+	shiftRepo := repositories.NewShiftRepository(db)
+	taxRepo := repositories.NewTaxRepository(db)
+	fiscalRepo := repositories.NewFiscalRepository(db)
+	kitchenRepo := repositories.NewKitchenRepository(db)
+	txManager := repositories.NewTransactionManager(db)
+	tokenRepo := redisdb.NewTokenRepository(redisClient)
+	orderNumberRepo := redisdb.NewOrderNumberRepository(redisClient)
+	eventBroker := redisdb.NewEventBroker(redisClient)
+
+	// Usecase слой
+	jwtService := auth.NewJWTService(cfg.JWTSecret, time.Duration(cfg.JWTTokenTTL)*time.Minute)
+	authService := userusecase.NewAuthService(userRepo, jwtService, tokenRepo)
+	userUseCase := userusecase.NewUserUseCase(*userrepository.NewUserRepository(db), authService)
+	permissionUC := userusecase.NewPermissionUsecase(permissionRepo)
+	menuUsecase := menuusecase.NewMenuUsecase(menuRepo)
+	productUsecase := menuusecase.NewProductUsecase(productRepo)
+	taxUsecase := menuusecase.NewTaxUsecase(taxRepo, menuRepo, cfg.TaxInclusive, float64(cfg.TaxDefaultRate))
+	stopListUsecase := inventoryusecase.NewStopListUsecase(stockRepo, menuRepo)
+	inventoryUsecase := inventoryusecase.NewInventoryUsecase(stockRepo, stopListUsecase)
+	costingUsecase := inventoryusecase.NewCostingUsecase(costRepo, stockRepo, productRepo, float64(cfg.MarginThreshold))
+	promotionUsecase := promotionusecase.NewPromotionUsecase(promotionRepo)
+	loyaltyTiers, err := loyaltyentity.ParseTiers(cfg.LoyaltyTiers)
+	if err != nil {
+		return fmt.Errorf("настройки программы лояльности: %w", err)
+	}
+	loyaltyUsecase := loyaltyusecase.NewLoyaltyUsecase(loyaltyRepo, loyaltyentity.Program{
+		Tiers:            loyaltyTiers,
+		PointValue:       common.NewMoney(int64(cfg.LoyaltyPointValue)),
+		MaxRedeemPercent: float64(cfg.LoyaltyMaxRedeemPercent),
+	})
+	stampUsecase := loyaltyusecase.NewStampUsecase(stampRepo, loyaltyRepo)
+	paymentProvider, err := newPaymentProvider(cfg)
+	if err != nil {
+		return err
+	}
+	eventHub := eventsusecase.NewHub(eventBroker)
+	paymentUsecase := paymentusecase.NewPaymentUsecase(paymentRepo, orderRepo, txManager, paymentProvider, eventHub)
+	refundUsecase := paymentusecase.NewRefundUsecase(paymentRepo, orderRepo, txManager, paymentProvider, inventoryUsecase,
+		loyaltyUsecase, stampUsecase, common.NewMoney(int64(cfg.RefundApprovalThreshold)))
+	shiftUsecase := staffusecase.NewShiftUsecase(shiftRepo, paymentUsecase)
+	fiscalRegistrar, err := newFiscalRegistrar(cfg)
+	if err != nil {
+		return err
+	}
+	fiscalUsecase := fiscalusecase.NewFiscalUsecase(fiscalRepo, orderRepo, userRepo, paymentUsecase, txManager, fiscalRegistrar, fiscalentity.Company{
+		Email:          cfg.FiscalEmail,
+		SNO:            cfg.FiscalSNO,
+		INN:            cfg.ShopINN,
+		PaymentAddress: cfg.ShopAddress,
+	}, cfg.FiscalMaxAttempts)
+	pickupSchedule, err := newPickupSchedule(cfg)
+	if err != nil {
+		return fmt.Errorf("настройки предзаказов: %w", err)
+	}
+	kitchenUsecase := kitchenusecase.NewKitchenUsecase(kitchenRepo, orderRepo, productRepo, txManager, eventHub)
+	orderUsecase := orderusecase.NewOrderUsecase(orderRepo, productRepo, menuRepo, txManager, inventoryUsecase, promotionUsecase, loyaltyUsecase, stampUsecase, paymentUsecase, taxUsecase, fiscalUsecase, orderNumberRepo, eventHub, kitchenUsecase, pickupSchedule)
+
+	// Остатки могли измениться, пока сервер был остановлен
+	if err := stopListUsecase.RecomputeAll(context.Background()); err != nil {
+		log.Printf("ошибка пересчета стоп-листа: %v", err)
+	}
+
+	// Delivery слой
+	jwtMiddleware := middleware.NewJWTMiddleware(jwtService, userRepo)
+	userHandler := userhttp.NewUserHandler(userUseCase, jwtMiddleware, stampUsecase)
+	menuHandler := menuhttp.NewMenuHandler(jwtMiddleware, menuUsecase, productUsecase, taxUsecase)
+	orderHandler := orderhttp.NewOrderHandler(orderUsecase)
+	inventoryHandler := inventoryhttp.NewInventoryHandler(inventoryUsecase, costingUsecase)
+	promotionHandler := promotionhttp.NewPromotionHandler(promotionUsecase)
+	loyaltyHandler := loyaltyhttp.NewLoyaltyHandler(loyaltyUsecase, stampUsecase)
+	paymentHandler := paymenthttp.NewPaymentHandler(paymentUsecase, refundUsecase)
+	staffHandler := staffhttp.NewStaffHandler(shiftUsecase)
+	fiscalHandler := fiscalhttp.NewFiscalHandler(fiscalUsecase)
+	eventsHandler := eventshttp.NewEventsHandler(eventHub)
+	kitchenHandler := kitchenhttp.NewKitchenHandler(kitchenUsecase)
+	receiptHandler := receipthttp.NewReceiptHandler(orderUsecase, paymentUsecase, receipt.Shop{
+		Name:    cfg.ShopName,
+		Address: cfg.ShopAddress,
+		INN:     cfg.ShopINN,
+		Phone:   cfg.ShopPhone,
+		Footer:  cfg.ShopFooter,
+	})
+
+	router := gin.Default()
+	api := router.Group("/api/v1")
+	userhttp.SetupUserRoutes(api, userHandler, jwtMiddleware, permissionUC)
+	menuhttp.SetupMenuRoutes(api, menuHandler, jwtMiddleware)
+	orderhttp.SetupOrderRoutes(api, orderHandler, jwtMiddleware, permissionUC)
+	inventoryhttp.SetupInventoryRoutes(api, inventoryHandler, jwtMiddleware, permissionUC)
+	promotionhttp.SetupPromotionRoutes(api, promotionHandler, jwtMiddleware, permissionUC)
+	loyaltyhttp.SetupLoyaltyRoutes(api, loyaltyHandler, jwtMiddleware, permissionUC)
+	paymenthttp.SetupPaymentRoutes(api, paymentHandler, jwtMiddleware, permissionUC)
+	staffhttp.SetupStaffRoutes(api, staffHandler, jwtMiddleware, permissionUC)
+	receipthttp.SetupReceiptRoutes(api, receiptHandler, jwtMiddleware)
+	fiscalhttp.SetupFiscalRoutes(api, fiscalHandler, jwtMiddleware, permissionUC)
+	eventshttp.SetupEventsRoutes(api, eventsHandler, jwtMiddleware)
+	kitchenhttp.SetupKitchenRoutes(api, kitchenHandler, jwtMiddleware, permissionUC)
+
+	server := &http.Server{
+		Addr:    ":" + cfg.HTTPPort,
+		Handler: router,
+	}
+	// Потоки событий открыты бессрочно, поэтому при остановке их нужно закрыть явно
+	server.RegisterOnShutdown(eventHub.Close)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Чеки выполненных заказов отправляются в регистратор в фоне
+	go fiscalUsecase.Run(ctx, time.Duration(cfg.FiscalInterval)*time.Second)
+
+	// Предзаказы передаются в работу незадолго до слота получения
+	go orderUsecase.RunScheduler(ctx, time.Duration(cfg.PreorderInterval)*time.Second)
+
+	// События заказов с других экземпляров приходят через Redis
+	go eventHub.Run(ctx)
+
+	serverErr := make(chan error, 1)
+	go func() {
+		log.Printf("сервер запущен на %s", server.Addr)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+		close(serverErr)
+	}()
+
+	select {
+	case err := <-serverErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Println("получен сигнал завершения, останавливаем сервер")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.ShutdownTimeout)*time.Second)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("ошибка остановки сервера: %w", err)
+	}
+
+	log.Println("сервер остановлен")
+	return nil
+}
+
+// newPaymentProvider создает платежный шлюз, выбранный в настройках.
+func newPaymentProvider(cfg *config.Config) (paymentprovider.Provider, error) {
+	switch cfg.PaymentProvider {
+	case paymentprovider.FakeName:
+		return paymentprovider.NewFakeProvider(cfg.PaymentWebhookSecret), nil
+	}
+	return nil, fmt.Errorf("неизвестный платежный шлюз %q", cfg.PaymentProvider)
+}
+
+// newFiscalRegistrar создает фискальный регистратор, выбранный в настройках.
+func newFiscalRegistrar(cfg *config.Config) (fiscalregistrar.Registrar, error) {
+	switch cfg.FiscalRegistrar {
+	case fiscalregistrar.FileName:
+		return fiscalregistrar.NewFileRegistrar(cfg.FiscalDir), nil
+	}
+	return nil, fmt.Errorf("неизвестный фискальный регистратор %q", cfg.FiscalRegistrar)
+}
+
+// newPickupSchedule собирает расписание слотов получения предзаказов из настроек.
+func newPickupSchedule(cfg *config.Config) (orderentity.PickupSchedule, error) {
+	if cfg.PickupSlotMinutes <= 0 || cfg.PickupSlotCapacity <= 0 {
+		return orderentity.PickupSchedule{}, errors.New("длина и вместимость слота должны быть больше нуля")
+	}
+	opens, err := orderentity.ParseClock(cfg.PickupOpens)
+	if err != nil {
+		return orderentity.PickupSchedule{}, err
+	}
+	closes, err := orderentity.ParseClock(cfg.PickupCloses)
+	if err != nil {
+		return orderentity.PickupSchedule{}, err
+	}
+	return orderentity.PickupSchedule{
+		SlotLength: time.Duration(cfg.PickupSlotMinutes) * time.Minute,
+		Capacity:   cfg.PickupSlotCapacity,
+		Opens:      opens,
+		Closes:     closes,
+		Lead:       time.Duration(cfg.PreorderLead) * time.Minute,
+		MaxDays:    cfg.PickupMaxDays,
+	}, nil
+}
+
+// migrate выполняет автоматическую миграцию всех моделей приложения.
+func migrate(db *gorm.DB) error {
+	// product_ingredients хранит количество и единицу измерения, поэтому
+	// связь продукт-ингредиент использует собственную модель вместо стандартной
+	if err := db.SetupJoinTable(&menuentity.Product{}, "Ingredients", &menuentity.ProductIngredient{}); err != nil {
+		return err
+	}
+
+	// Цены раньше хранились как float, переводим их в копейки до AutoMigrate
+	if err := postgres.ConvertMoneyColumns(db,
+		postgres.MoneyColumn{Table: "products", Column: "price"},
+		postgres.MoneyColumn{Table: "orders", Column: "total_price"},
+		postgres.MoneyColumn{Table: "items_orders", Column: "price"},
+	); err != nil {
+		return err
+	}
+
+	if err := postgres.AutoMigrate(db,
+		&common.Role{},
+		&common.User{},
+		&userentity.Permission{},
+		&userentity.RolePermission{},
+		&menuentity.Menu{},
+		&menuentity.MenuCategory{},
+		&menuentity.Product{},
+		&menuentity.Ingredient{},
+		&menuentity.ProductIngredient{},
+		&menuentity.ProductVariant{},
+		&menuentity.VariantIngredient{},
+		&menuentity.ModifierGroup{},
+		&menuentity.Modifier{},
+		&menuentity.TaxRate{},
+		&menuentity.MenuItem{},
+		&orderentity.Order{},
+		&orderentity.ItemsOrders{},
+		&orderentity.ItemModifier{},
+		&orderentity.OrderDiscount{},
+		&orderentity.OrderStatusHistory{},
+		&fiscalentity.OutboxEntry{},
+		&kitchenentity.Station{},
+		&kitchenentity.StationCategory{},
+		&kitchenentity.Ticket{},
+		&kitchenentity.TicketLine{},
+		&inventoryentity.StockMovement{},
+		&inventoryentity.IngredientCost{},
+		&promotionentity.Promotion{},
+		&promotionentity.Condition{},
+		&promotionentity.Usage{},
+		&loyaltyentity.Entry{},
+		&loyaltyentity.StampCampaign{},
+		&loyaltyentity.StampEntry{},
+		&loyaltyentity.StampReward{},
+		&paymententity.Payment{},
+		&paymententity.PaymentLine{},
+		&paymententity.Refund{},
+		&paymententity.RefundLine{},
+		&staffentity.Shift{},
+	); err != nil {
+		return err
+	}
+
+	// Остатки ингредиентов теперь вычисляются по складскому журналу
+	return postgres.MoveIngredientStockToLedger(db)
+}
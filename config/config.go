@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"strconv"
 )
@@ -19,6 +20,47 @@ type Config struct {
 	RedisAddr     string
 	RedisPassword string
 	RedisDB       int
+
+	HTTPPort        string
+	ShutdownTimeout int // секунды
+
+	JWTSecret   string
+	JWTTokenTTL int // минуты
+
+	MarginThreshold int // минимальная валовая маржа продукта в процентах
+
+	LoyaltyTiers            string // уровни программы лояльности: "название:порог:процент,..."
+	LoyaltyPointValue       int    // стоимость балла в копейках
+	LoyaltyMaxRedeemPercent int    // максимальная доля заказа, оплачиваемая баллами
+
+	PaymentProvider      string // платежный шлюз для онлайн-оплаты, "fake" - тестовый шлюз для разработки
+	PaymentWebhookSecret string // секрет подписи уведомлений шлюза
+
+	RefundApprovalThreshold int // сумма возвратов по заказу в копейках, выше которой их оформляет только менеджер
+
+	TaxInclusive   bool // цены в меню включают налог
+	TaxDefaultRate int  // ставка налога в процентах, если для категории ничего не задано
+
+	ShopName    string // название кофейни в чеке
+	ShopAddress string // адрес кофейни в чеке
+	ShopINN     string // ИНН продавца
+	ShopPhone   string // телефон кофейни
+	ShopFooter  string // строка в конце чека
+
+	FiscalRegistrar   string // фискальный регистратор
+	FiscalDir         string // каталог документов файлового регистратора
+	FiscalSNO         string // система налогообложения продавца
+	FiscalEmail       string // почта продавца в фискальном чеке
+	FiscalMaxAttempts int    // число попыток отправки чека
+	FiscalInterval    int    // период обработки очереди чеков, секунды
+
+	PickupSlotMinutes  int    // длина слота получения предзаказов, минуты
+	PickupSlotCapacity int    // число заказов в слоте
+	PickupOpens        string // начало приема предзаказов, "ЧЧ:ММ"
+	PickupCloses       string // конец приема предзаказов, "ЧЧ:ММ"
+	PickupMaxDays      int    // на сколько дней вперед принимаются предзаказы
+	PreorderLead       int    // за сколько минут до слота предзаказ передается в работу
+	PreorderInterval   int    // период проверки предзаказов, секунды
 }
 
 // New создает новый экземпляр Config, заполняя его из переменных окружения.
@@ -36,9 +78,64 @@ func New() *Config {
 		RedisAddr:     getEnv("REDIS_ADDR", "localhost:6379"),
 		RedisPassword: getEnv("REDIS_PASSWORD", ""),
 		RedisDB:       getEnvInt("REDIS_DB", 0),
+
+		HTTPPort:        getEnv("HTTP_PORT", "8080"),
+		ShutdownTimeout: getEnvInt("SHUTDOWN_TIMEOUT", 15),
+
+		JWTSecret:   getEnv("JWT_SECRET", ""),
+		JWTTokenTTL: getEnvInt("JWT_TOKEN_TTL", 15),
+
+		MarginThreshold: getEnvInt("MARGIN_THRESHOLD", 60),
+
+		LoyaltyTiers:            getEnv("LOYALTY_TIERS", "Базовый:0:3,Серебро:3000:5,Золото:10000:7"),
+		LoyaltyPointValue:       getEnvInt("LOYALTY_POINT_VALUE", 100),
+		LoyaltyMaxRedeemPercent: getEnvInt("LOYALTY_MAX_REDEEM_PERCENT", 50),
+
+		PaymentProvider:      getEnv("PAYMENT_PROVIDER", ""),
+		PaymentWebhookSecret: getEnv("PAYMENT_WEBHOOK_SECRET", ""),
+
+		RefundApprovalThreshold: getEnvInt("REFUND_APPROVAL_THRESHOLD", 100000),
+
+		TaxInclusive:   getEnvBool("TAX_INCLUSIVE", true),
+		TaxDefaultRate: getEnvInt("TAX_DEFAULT_RATE", 0),
+
+		ShopName:    getEnv("SHOP_NAME", "BeanQ"),
+		ShopAddress: getEnv("SHOP_ADDRESS", ""),
+		ShopINN:     getEnv("SHOP_INN", ""),
+		ShopPhone:   getEnv("SHOP_PHONE", ""),
+		ShopFooter:  getEnv("SHOP_FOOTER", "Спасибо за покупку!"),
+
+		FiscalRegistrar:   getEnv("FISCAL_REGISTRAR", "file"),
+		FiscalDir:         getEnv("FISCAL_DIR", "fiscal"),
+		FiscalSNO:         getEnv("FISCAL_SNO", "osn"),
+		FiscalEmail:       getEnv("FISCAL_EMAIL", ""),
+		FiscalMaxAttempts: getEnvInt("FISCAL_MAX_ATTEMPTS", 10),
+		FiscalInterval:    getEnvInt("FISCAL_INTERVAL", 30),
+
+		PickupSlotMinutes:  getEnvInt("PICKUP_SLOT_MINUTES", 10),
+		PickupSlotCapacity: getEnvInt("PICKUP_SLOT_CAPACITY", 5),
+		PickupOpens:        getEnv("PICKUP_OPENS", "08:00"),
+		PickupCloses:       getEnv("PICKUP_CLOSES", "21:00"),
+		PickupMaxDays:      getEnvInt("PICKUP_MAX_DAYS", 7),
+		PreorderLead:       getEnvInt("PREORDER_LEAD", 15),
+		PreorderInterval:   getEnvInt("PREORDER_INTERVAL", 30),
 	}
 }
 
+// Validate проверяет, что заданы обязательные параметры.
+func (c *Config) Validate() error {
+	if c.JWTSecret == "" {
+		return errors.New("не задан JWT_SECRET")
+	}
+	if c.PaymentProvider == "" {
+		return errors.New("не задан PAYMENT_PROVIDER")
+	}
+	if c.PaymentWebhookSecret == "" {
+		return errors.New("не задан PAYMENT_WEBHOOK_SECRET")
+	}
+	return nil
+}
+
 // getEnv возвращает значение переменной окружения или значение по умолчанию.
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
@@ -56,3 +153,13 @@ func getEnvInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+// getEnvBool возвращает значение переменной окружения как bool или значение по умолчанию.
+func getEnvBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return defaultValue
+}
@@ -0,0 +1,323 @@
+package common
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency - валюта, в которой работает кофейня.
+const DefaultCurrency = "RUB"
+
+// minorUnits - количество минимальных единиц (копеек) в одной единице валюты.
+const minorUnits = 100
+
+// ErrInvalidMoney возвращается при разборе некорректной денежной суммы.
+var ErrInvalidMoney = errors.New("некорректная денежная сумма")
+
+// ErrUnsupportedCurrency возвращается для сумм в валюте, отличной от DefaultCurrency.
+var ErrUnsupportedCurrency = errors.New("неподдерживаемая валюта")
+
+// Money представляет денежную сумму в минимальных единицах валюты.
+// В базе данных хранится как bigint (копейки), в JSON - как строка "350.50".
+// Кофейня работает в одной валюте: суммы в других валютах отклоняются
+// при разборе и сохранении, поэтому арифметика не проверяет валюту.
+type Money struct {
+	Amount   int64  // сумма в минимальных единицах (копейках)
+	Currency string // код валюты ISO 4217
+}
+
+// NewMoney создает сумму из минимальных единиц в валюте по умолчанию.
+func NewMoney(amount int64) Money {
+	return Money{Amount: amount, Currency: DefaultCurrency}
+}
+
+// ParseMoney разбирает строку вида "350", "350.5", "350,50" или "350.50 RUB".
+// Валюта, если указана, должна совпадать с DefaultCurrency.
+func ParseMoney(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	if parts := strings.Fields(s); len(parts) == 2 {
+		if currency := strings.ToUpper(parts[1]); currency != DefaultCurrency {
+			return Money{}, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, currency)
+		}
+		s = parts[0]
+	}
+	if s == "" {
+		return Money{}, ErrInvalidMoney
+	}
+
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	s = strings.Replace(s, ",", ".", 1)
+
+	whole, fraction, _ := strings.Cut(s, ".")
+	if !isDigits(whole) || len(fraction) > 2 || fraction != "" && !isDigits(fraction) {
+		return Money{}, ErrInvalidMoney
+	}
+	for len(fraction) < 2 {
+		fraction += "0"
+	}
+
+	major, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return Money{}, ErrInvalidMoney
+	}
+	minor, err := strconv.ParseInt(fraction, 10, 64)
+	if err != nil {
+		return Money{}, ErrInvalidMoney
+	}
+
+	amount := major*minorUnits + minor
+	if negative {
+		amount = -amount
+	}
+	return NewMoney(amount), nil
+}
+
+// isDigits сообщает, что строка непустая и состоит только из цифр ASCII.
+// strconv.ParseInt принимает знак, поэтому цифры проверяются до разбора.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// String возвращает сумму в виде десятичной строки с двумя знаками после точки.
+func (m Money) String() string {
+	sign := ""
+	amount := m.Amount
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, amount/minorUnits, amount%minorUnits)
+}
+
+// currencyWith возвращает валюту результата операции над двумя суммами.
+// Пустая валюта (нулевое значение Money) совместима с любой.
+func (m Money) currencyWith(o Money) string {
+	if m.Currency == "" {
+		return o.Currency
+	}
+	return m.Currency
+}
+
+// SameCurrency проверяет, что суммы можно складывать и сравнивать.
+func (m Money) SameCurrency(o Money) bool {
+	return m.Currency == "" || o.Currency == "" || m.Currency == o.Currency
+}
+
+// Add возвращает сумму m + o.
+func (m Money) Add(o Money) Money {
+	return Money{Amount: m.Amount + o.Amount, Currency: m.currencyWith(o)}
+}
+
+// Sub возвращает разность m - o.
+func (m Money) Sub(o Money) Money {
+	return Money{Amount: m.Amount - o.Amount, Currency: m.currencyWith(o)}
+}
+
+// Mul умножает сумму на целое количество.
+func (m Money) Mul(n int64) Money {
+	return Money{Amount: m.Amount * n, Currency: m.Currency}
+}
+
+// Percent возвращает указанный процент от суммы с математическим округлением до копейки.
+func (m Money) Percent(percent float64) Money {
+	return Money{Amount: int64(math.Round(float64(m.Amount) * percent / 100)), Currency: m.Currency}
+}
+
+// Allocate делит сумму на n частей без потери копеек: остаток
+// распределяется по одной копейке на первые части.
+func (m Money) Allocate(n int) []Money {
+	if n <= 0 {
+		return nil
+	}
+	parts := make([]Money, n)
+	share := m.Amount / int64(n)
+	remainder := m.Amount % int64(n)
+	for i := range parts {
+		parts[i] = Money{Amount: share, Currency: m.Currency}
+		if remainder > 0 {
+			parts[i].Amount++
+			remainder--
+		} else if remainder < 0 {
+			parts[i].Amount--
+			remainder++
+		}
+	}
+	return parts
+}
+
+// AllocateBy делит сумму пропорционально весам без потери копеек: остаток
+// распределяется по одной копейке на части с наибольшими весами. Части с нулевым
+// весом получают ноль; если все веса нулевые, возвращается nil.
+func (m Money) AllocateBy(weights []int64) []Money {
+	var total int64
+	for _, weight := range weights {
+		if weight < 0 {
+			return nil
+		}
+		total += weight
+	}
+	if total == 0 {
+		return nil
+	}
+
+	parts := make([]Money, len(weights))
+	allocated := int64(0)
+	for i, weight := range weights {
+		parts[i] = Money{Amount: m.Amount * weight / total, Currency: m.Currency}
+		allocated += parts[i].Amount
+	}
+
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return weights[order[a]] > weights[order[b]] })
+	// остаток меньше числа ненулевых весов, поэтому достается только им
+	remainder := m.Amount - allocated
+	for _, i := range order {
+		switch {
+		case remainder > 0:
+			parts[i].Amount++
+			remainder--
+		case remainder < 0:
+			parts[i].Amount--
+			remainder++
+		}
+	}
+	return parts
+}
+
+// Neg возвращает сумму с противоположным знаком.
+func (m Money) Neg() Money {
+	return Money{Amount: -m.Amount, Currency: m.Currency}
+}
+
+// Cmp сравнивает суммы: -1 если m < o, 0 если равны, 1 если m > o.
+func (m Money) Cmp(o Money) int {
+	switch {
+	case m.Amount < o.Amount:
+		return -1
+	case m.Amount > o.Amount:
+		return 1
+	}
+	return 0
+}
+
+// Min возвращает меньшую из двух сумм.
+func (m Money) Min(o Money) Money {
+	if m.Cmp(o) <= 0 {
+		return m
+	}
+	return o
+}
+
+// IsZero сообщает, что сумма равна нулю.
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// IsPositive сообщает, что сумма больше нуля.
+func (m Money) IsPositive() bool {
+	return m.Amount > 0
+}
+
+// IsNegative сообщает, что сумма меньше нуля.
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+// SumMoney складывает несколько сумм.
+func SumMoney(amounts ...Money) Money {
+	total := Money{}
+	for _, amount := range amounts {
+		total = total.Add(amount)
+	}
+	return total
+}
+
+// MarshalJSON кодирует сумму строкой, чтобы не терять точность на клиенте.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// UnmarshalJSON принимает сумму строкой ("350.50") или числом (350.5).
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*m = Money{}
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		var number json.Number
+		if err := json.Unmarshal(data, &number); err != nil {
+			return ErrInvalidMoney
+		}
+		s = number.String()
+	}
+
+	parsed, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// GormDataType задает тип колонки для денежных сумм.
+func (Money) GormDataType() string {
+	return "bigint"
+}
+
+// Value сохраняет сумму в базу данных в минимальных единицах.
+// Валюта не хранится, поэтому суммы в других валютах не сохраняются.
+func (m Money) Value() (driver.Value, error) {
+	if m.Currency != "" && m.Currency != DefaultCurrency {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, m.Currency)
+	}
+	return m.Amount, nil
+}
+
+// Scan читает сумму из базы данных. Value сохраняет только суммы
+// в DefaultCurrency, поэтому валюта восстанавливается по умолчанию.
+func (m *Money) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*m = Money{Currency: DefaultCurrency}
+	case int64:
+		*m = NewMoney(v)
+	case int32:
+		*m = NewMoney(int64(v))
+	case []byte:
+		return m.scanString(string(v))
+	case string:
+		return m.scanString(v)
+	default:
+		return fmt.Errorf("%w: неподдерживаемый тип %T", ErrInvalidMoney, value)
+	}
+	return nil
+}
+
+func (m *Money) scanString(s string) error {
+	amount, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: %q", ErrInvalidMoney, s)
+	}
+	*m = NewMoney(amount)
+	return nil
+}
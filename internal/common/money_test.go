@@ -0,0 +1,124 @@
+package common_test
+
+import (
+	"coffe/internal/common"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    int64
+		wantErr bool
+	}{
+		{"350", 35000, false},
+		{"350.5", 35050, false},
+		{"350,50", 35050, false},
+		{"0.01", 1, false},
+		{"-12.30", -1230, false},
+		{"99.99 RUB", 9999, false},
+		{"1.234", 0, true},
+		{"abc", 0, true},
+		{"--5", 0, true},
+		{"+5", 0, true},
+		{"1.-5", 0, true},
+		{"1.+5", 0, true},
+		{"1. 5", 0, true},
+		{"", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := common.ParseMoney(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseMoney(%q): ожидали ошибку", tt.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseMoney(%q): неожиданная ошибка %v", tt.input, err)
+			continue
+		}
+		if got.Amount != tt.want {
+			t.Errorf("ParseMoney(%q) = %d, ожидали %d", tt.input, got.Amount, tt.want)
+		}
+	}
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	price := common.NewMoney(25050)
+
+	if got := price.Mul(3).String(); got != "751.50" {
+		t.Errorf("Mul: получили %s", got)
+	}
+	if got := price.Add(common.NewMoney(50)).Sub(common.NewMoney(100)).String(); got != "250.00" {
+		t.Errorf("Add/Sub: получили %s", got)
+	}
+	if got := common.NewMoney(-5).String(); got != "-0.05" {
+		t.Errorf("String для отрицательной суммы: получили %s", got)
+	}
+	if got := common.NewMoney(999).Percent(10).Amount; got != 100 {
+		t.Errorf("Percent: получили %d, ожидали 100", got)
+	}
+
+	parts := common.NewMoney(1000).Allocate(3)
+	var total int64
+	for _, part := range parts {
+		total += part.Amount
+	}
+	if total != 1000 || parts[0].Amount != 334 || parts[2].Amount != 333 {
+		t.Errorf("Allocate: получили %v", parts)
+	}
+
+	// 100 ₽ на смены 3, 2 и 0 часов: копейка остатка достается самой длинной смене
+	shares := common.NewMoney(10001).AllocateBy([]int64{180, 120, 0})
+	if shares[0].Amount != 6001 || shares[1].Amount != 4000 || shares[2].Amount != 0 {
+		t.Errorf("AllocateBy: получили %v", shares)
+	}
+	if common.NewMoney(100).AllocateBy([]int64{0, 0}) != nil {
+		t.Error("AllocateBy: ожидали nil при нулевых весах")
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	data, err := json.Marshal(struct {
+		Price common.Money `json:"price"`
+	}{common.NewMoney(35050)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"price":"350.50"}` {
+		t.Errorf("Marshal: получили %s", data)
+	}
+
+	var decoded struct {
+		Price common.Money `json:"price"`
+	}
+	for _, input := range []string{`{"price":"350.50"}`, `{"price":350.5}`} {
+		if err := json.Unmarshal([]byte(input), &decoded); err != nil {
+			t.Fatalf("Unmarshal(%s): %v", input, err)
+		}
+		if decoded.Price.Amount != 35050 {
+			t.Errorf("Unmarshal(%s): получили %d", input, decoded.Price.Amount)
+		}
+	}
+}
+
+func TestMoneyRejectsForeignCurrency(t *testing.T) {
+	if _, err := common.ParseMoney("100 USD"); !errors.Is(err, common.ErrUnsupportedCurrency) {
+		t.Errorf("ParseMoney: ожидали ErrUnsupportedCurrency, получили %v", err)
+	}
+
+	var decoded struct {
+		Tip common.Money `json:"tip"`
+	}
+	if err := json.Unmarshal([]byte(`{"tip":"100 USD"}`), &decoded); !errors.Is(err, common.ErrUnsupportedCurrency) {
+		t.Errorf("Unmarshal: ожидали ErrUnsupportedCurrency, получили %v", err)
+	}
+
+	if _, err := (common.Money{Amount: 1, Currency: "USD"}).Value(); !errors.Is(err, common.ErrUnsupportedCurrency) {
+		t.Errorf("Value: ожидали ErrUnsupportedCurrency, получили %v", err)
+	}
+}
@@ -0,0 +1,11 @@
+// Package repositorytest содержит заглушки репозиториев для тестов usecase.
+package repositorytest
+
+import "context"
+
+// InlineTx выполняет функцию без транзакции.
+type InlineTx struct{}
+
+func (InlineTx) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
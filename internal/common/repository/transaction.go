@@ -0,0 +1,10 @@
+package repository
+
+import "context"
+
+// TransactionManager выполняет операции нескольких репозиториев в одной транзакции.
+type TransactionManager interface {
+	// WithinTransaction вызывает fn с контекстом, через который репозитории
+	// работают в общей транзакции. Ошибка из fn откатывает транзакцию.
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
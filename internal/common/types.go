@@ -11,6 +11,7 @@ type User struct {
 	Surname  string    `json:"surname" db:"surname"`
 	Email    string    `json:"email" db:"email"`
 	Password string    `json:"-" db:"password"`
+	RoleID   uuid.UUID `json:"-" db:"role_id"`
 	Role     *Role     `json:"role,omitempty" db:"role"`
 }
 
@@ -25,7 +26,7 @@ type Product struct {
 	ID          uuid.UUID `json:"id" db:"id"`
 	Name        string    `json:"name" db:"name"`
 	Category    string    `json:"category" db:"category"`
-	Price       float64   `json:"price" db:"price"`
+	Price       Money     `json:"price" db:"price"`
 	Description string    `json:"description" db:"description"`
 	IsActive    bool      `json:"is_active" db:"is_active"`
 	ImageURL    string    `json:"image_url" db:"image_url"`
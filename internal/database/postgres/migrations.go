@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// MoneyColumn описывает денежную колонку таблицы.
+type MoneyColumn struct {
+	Table  string
+	Column string
+}
+
+// ConvertMoneyColumns переводит денежные колонки, созданные как float или numeric,
+// в bigint с суммой в копейках. Уже сконвертированные и отсутствующие колонки пропускаются,
+// поэтому функцию безопасно вызывать при каждом запуске перед AutoMigrate.
+func ConvertMoneyColumns(db *gorm.DB, columns ...MoneyColumn) error {
+	for _, column := range columns {
+		var dataType string
+		err := db.Raw(
+			"SELECT data_type FROM information_schema.columns WHERE table_schema = CURRENT_SCHEMA() AND table_name = ? AND column_name = ?",
+			column.Table, column.Column,
+		).Scan(&dataType).Error
+		if err != nil {
+			return fmt.Errorf("ошибка чтения схемы %s.%s: %w", column.Table, column.Column, err)
+		}
+
+		switch dataType {
+		case "double precision", "real", "numeric":
+		default:
+			continue
+		}
+
+		sql := fmt.Sprintf(
+			"ALTER TABLE %q ALTER COLUMN %q TYPE bigint USING ROUND(%q * 100)::bigint",
+			column.Table, column.Column, column.Column,
+		)
+		if err := db.Exec(sql).Error; err != nil {
+			return fmt.Errorf("ошибка конвертации %s.%s: %w", column.Table, column.Column, err)
+		}
+	}
+	return nil
+}
+
+// MoveIngredientStockToLedger переносит остатки из старой колонки ingredients.quantity
+// в складской журнал записями корректировки и удаляет колонку. Вызывается после
+// создания таблицы stock_movements; если колонки уже нет, ничего не делает.
+func MoveIngredientStockToLedger(db *gorm.DB) error {
+	if !db.Migrator().HasColumn("ingredients", "quantity") {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`INSERT INTO stock_movements (id, ingredient_id, type, quantity, comment, created_at)
+			SELECT gen_random_uuid()::text, id, 'корректировка', quantity, 'перенос остатка', NOW()
+			FROM ingredients WHERE quantity <> 0`).Error; err != nil {
+			return fmt.Errorf("ошибка переноса остатков: %w", err)
+		}
+		return tx.Migrator().DropColumn("ingredients", "quantity")
+	})
+}
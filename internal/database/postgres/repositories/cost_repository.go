@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"coffe/internal/inventory/entity"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CostRepository реализует методы доступа к закупочным ценам ингредиентов в базе данных.
+type CostRepository struct {
+	db *gorm.DB
+}
+
+// NewCostRepository создает новый экземпляр CostRepository.
+func NewCostRepository(db *gorm.DB) *CostRepository {
+	return &CostRepository{db: db}
+}
+
+// AddCost сохраняет новую закупочную цену
+func (r *CostRepository) AddCost(ctx context.Context, cost *entity.IngredientCost) error {
+	if cost.IngredientID == uuid.Nil {
+		return errors.New("ID ингредиента не может быть пустым")
+	}
+	if cost.ID == uuid.Nil {
+		cost.ID = uuid.New()
+	}
+	return conn(ctx, r.db).Create(cost).Error
+}
+
+// GetCostHistory получает историю цен ингредиента, начиная с последней
+func (r *CostRepository) GetCostHistory(ctx context.Context, ingredientID uuid.UUID) ([]*entity.IngredientCost, error) {
+	var costs []*entity.IngredientCost
+	if err := conn(ctx, r.db).
+		Where("ingredient_id = ?", ingredientID).
+		Order("valid_from DESC, created_at DESC").
+		Find(&costs).Error; err != nil {
+		return nil, err
+	}
+	return costs, nil
+}
+
+// GetCurrentCosts получает последние цены ингредиентов, вступившие в силу к моменту at
+func (r *CostRepository) GetCurrentCosts(ctx context.Context, ingredientIDs []uuid.UUID, at time.Time) (map[uuid.UUID]*entity.IngredientCost, error) {
+	costs := make(map[uuid.UUID]*entity.IngredientCost, len(ingredientIDs))
+	if len(ingredientIDs) == 0 {
+		return costs, nil
+	}
+
+	var found []*entity.IngredientCost
+	if err := conn(ctx, r.db).
+		Raw(`SELECT DISTINCT ON (ingredient_id) * FROM ingredient_costs
+			WHERE ingredient_id IN ? AND valid_from <= ?
+			ORDER BY ingredient_id, valid_from DESC, created_at DESC`, ingredientIDs, at).
+		Scan(&found).Error; err != nil {
+		return nil, err
+	}
+	for _, cost := range found {
+		costs[cost.IngredientID] = cost
+	}
+	return costs, nil
+}
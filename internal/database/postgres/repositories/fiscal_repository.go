@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"coffe/internal/fiscal/entity"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FiscalRepository реализует методы доступа к очереди фискальных чеков в базе данных.
+type FiscalRepository struct {
+	db *gorm.DB
+}
+
+// NewFiscalRepository создает новый экземпляр FiscalRepository.
+func NewFiscalRepository(db *gorm.DB) *FiscalRepository {
+	return &FiscalRepository{db: db}
+}
+
+// Create ставит чек в очередь
+func (r *FiscalRepository) Create(ctx context.Context, entry *entity.OutboxEntry) error {
+	if entry.ID == uuid.Nil {
+		entry.ID = uuid.New()
+	}
+	return conn(ctx, r.db).Create(entry).Error
+}
+
+// Update обновляет запись очереди
+func (r *FiscalRepository) Update(ctx context.Context, entry *entity.OutboxEntry) error {
+	return conn(ctx, r.db).Save(entry).Error
+}
+
+// GetByID получает запись очереди по ID
+func (r *FiscalRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.OutboxEntry, error) {
+	var entry entity.OutboxEntry
+	if err := conn(ctx, r.db).Where("id = ?", id).First(&entry).Error; err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// GetByOrder получает чек заказа
+func (r *FiscalRepository) GetByOrder(ctx context.Context, orderID uuid.UUID) (*entity.OutboxEntry, error) {
+	var entry entity.OutboxEntry
+	if err := conn(ctx, r.db).Where("order_id = ?", orderID).First(&entry).Error; err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// GetByStatus получает записи очереди по статусу, новые первыми
+func (r *FiscalRepository) GetByStatus(ctx context.Context, status entity.OutboxStatus) ([]*entity.OutboxEntry, error) {
+	var entries []*entity.OutboxEntry
+	query := conn(ctx, r.db).Order("created_at DESC")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := query.Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// LockNextDue блокирует самый старый чек, ожидающий отправки; nil, если очередь пуста
+func (r *FiscalRepository) LockNextDue(ctx context.Context, now time.Time) (*entity.OutboxEntry, error) {
+	var entries []*entity.OutboxEntry
+	if err := conn(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND next_attempt_at <= ?", entity.OutboxPending, now).
+		Order("next_attempt_at").
+		Limit(1).
+		Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	return entries[0], nil
+}
@@ -1,12 +1,15 @@
 package repositories
 
 import (
+	inventoryentity "coffe/internal/inventory/entity"
 	"coffe/internal/menu/entity"
+	"coffe/internal/units"
 	"context"
 	"errors"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // IngredientRepository реализует методы доступа к ингредиентам в базе данных.
@@ -19,25 +22,37 @@ func NewIngredientRepository(db *gorm.DB) *IngredientRepository {
 	return &IngredientRepository{db: db}
 }
 
-// Create добавляет ингредиент в базу данных.
+// Create добавляет ингредиент в базу данных. Ненулевое количество
+// записывается в складской журнал как начальный приход.
 func (r *IngredientRepository) Create(ctx context.Context, ingredient *entity.Ingredient) error {
-	if ingredient.Name == "" {
-		return errors.New("название ингредиента не может быть пустым")
-	}
-	if ingredient.Quantity <= 0 {
-		return errors.New("количество ингредиента должно быть больше нуля")
+	if err := r.ValidateIngredient(ctx, ingredient); err != nil {
+		return err
 	}
-	if ingredient.Unit == "" {
-		return errors.New("единица измерения не может быть пустой")
+	if ingredient.ID == uuid.Nil {
+		ingredient.ID = uuid.New()
 	}
 
-	return r.db.WithContext(ctx).Create(ingredient).Error
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(ingredient).Error; err != nil {
+			return err
+		}
+		if ingredient.Quantity == 0 {
+			return nil
+		}
+		return tx.Create(&inventoryentity.StockMovement{
+			ID:           uuid.New(),
+			IngredientID: ingredient.ID,
+			Type:         inventoryentity.MovementReceipt,
+			Quantity:     ingredient.Quantity,
+			Comment:      "начальный остаток",
+		}).Error
+	})
 }
 
 // GetByName возвращает ингредиент по названию.
 func (r *IngredientRepository) GetByName(ctx context.Context, name string) (*entity.Ingredient, error) {
 	var ingredient entity.Ingredient
-	if err := r.db.WithContext(ctx).Where("name = ?", name).First(&ingredient).Error; err != nil {
+	if err := r.withStock(ctx).Where("ingredients.name = ?", name).First(&ingredient).Error; err != nil {
 		return nil, err
 	}
 	return &ingredient, nil
@@ -46,25 +61,72 @@ func (r *IngredientRepository) GetByName(ctx context.Context, name string) (*ent
 // GetByID возвращает ингредиент по идентификатору.
 func (r *IngredientRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Ingredient, error) {
 	var ingredient entity.Ingredient
-	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&ingredient).Error; err != nil {
+	if err := r.withStock(ctx).Where("ingredients.id = ?", id).First(&ingredient).Error; err != nil {
 		return nil, err
 	}
 	return &ingredient, nil
 }
 
-// Update обновляет ингредиент
+// Update обновляет ингредиент. Единицу и плотность можно менять, только пока
+// ингредиент нигде не используется: количества в журнале и рецептах записаны
+// в старой единице и после смены изменили бы смысл.
 func (r *IngredientRepository) Update(ctx context.Context, ingredient *entity.Ingredient) error {
 	if ingredient.ID == uuid.Nil {
 		return errors.New("ID ингредиента не может быть пустым")
 	}
-	if ingredient.Name == "" {
-		return errors.New("название ингредиента не может быть пустым")
-	}
-	if ingredient.Quantity <= 0 {
-		return errors.New("количество ингредиента должно быть больше нуля")
+	if err := r.ValidateIngredient(ctx, ingredient); err != nil {
+		return err
 	}
 
-	return r.db.WithContext(ctx).Save(ingredient).Error
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var current entity.Ingredient
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", ingredient.ID).First(&current).Error; err != nil {
+			return err
+		}
+		// ValidateIngredient приводит единицу к коду, а старые записи могут хранить
+		// ее в исходном написании ("кг"), поэтому сравниваются коды единиц
+		storedUnit := current.Unit
+		if unit, err := units.Normalize(current.Unit); err == nil {
+			storedUnit = unit
+		}
+		if storedUnit != ingredient.Unit || current.Density != ingredient.Density {
+			used, err := r.inUse(tx, ingredient.ID)
+			if err != nil {
+				return err
+			}
+			if used {
+				return entity.ErrIngredientInUse
+			}
+		}
+
+		// Остаток меняется только через складской журнал
+		return tx.Save(ingredient).Error
+	})
+}
+
+// inUse проверяет, есть ли у ингредиента движения по складу, рецепты или закупочные цены
+func (r *IngredientRepository) inUse(tx *gorm.DB, id uuid.UUID) (bool, error) {
+	usages := []struct {
+		model  interface{}
+		column string
+	}{
+		{&inventoryentity.StockMovement{}, "ingredient_id"},
+		{&inventoryentity.IngredientCost{}, "ingredient_id"},
+		{&entity.ProductIngredient{}, "ingredient_id"},
+		{&entity.VariantIngredient{}, "ingredient_id"},
+		{&entity.Modifier{}, "ingredient_id"},
+		{&entity.Modifier{}, "replaces_ingredient_id"},
+	}
+	for _, usage := range usages {
+		var count int64
+		if err := tx.Model(usage.model).Where(usage.column+" = ?", id).Limit(1).Count(&count).Error; err != nil {
+			return false, err
+		}
+		if count > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
 }
 
 // Delete удаляет ингредиент по ID
@@ -78,7 +140,7 @@ func (r *IngredientRepository) Delete(ctx context.Context, id uuid.UUID) error {
 // GetAll получает все ингредиенты
 func (r *IngredientRepository) GetAll(ctx context.Context) ([]*entity.Ingredient, error) {
 	var ingredients []*entity.Ingredient
-	if err := r.db.WithContext(ctx).Find(&ingredients).Error; err != nil {
+	if err := r.withStock(ctx).Find(&ingredients).Error; err != nil {
 		return nil, err
 	}
 	return ingredients, nil
@@ -87,7 +149,7 @@ func (r *IngredientRepository) GetAll(ctx context.Context) ([]*entity.Ingredient
 // Search ищет ингредиенты по названию
 func (r *IngredientRepository) Search(ctx context.Context, query string) ([]*entity.Ingredient, error) {
 	var ingredients []*entity.Ingredient
-	if err := r.db.WithContext(ctx).Where("name ILIKE ?", "%"+query+"%").Find(&ingredients).Error; err != nil {
+	if err := r.withStock(ctx).Where("ingredients.name ILIKE ?", "%"+query+"%").Find(&ingredients).Error; err != nil {
 		return nil, err
 	}
 	return ingredients, nil
@@ -96,7 +158,7 @@ func (r *IngredientRepository) Search(ctx context.Context, query string) ([]*ent
 // GetByUnit получает ингредиенты по единице измерения
 func (r *IngredientRepository) GetByUnit(ctx context.Context, unit string) ([]*entity.Ingredient, error) {
 	var ingredients []*entity.Ingredient
-	if err := r.db.WithContext(ctx).Where("unit = ?", unit).Find(&ingredients).Error; err != nil {
+	if err := r.withStock(ctx).Where("ingredients.unit = ?", unit).Find(&ingredients).Error; err != nil {
 		return nil, err
 	}
 	return ingredients, nil
@@ -131,3 +193,76 @@ func (r *IngredientRepository) GetProductsByIngredient(ctx context.Context, ingr
 	}
 	return products, nil
 }
+
+// GetByQuantityRange получает ингредиенты, остаток которых находится в диапазоне
+func (r *IngredientRepository) GetByQuantityRange(ctx context.Context, min, max float64) ([]*entity.Ingredient, error) {
+	var ingredients []*entity.Ingredient
+	if err := r.withStock(ctx).
+		Where(stockQuantityExpr+" BETWEEN ? AND ?", min, max).
+		Find(&ingredients).Error; err != nil {
+		return nil, err
+	}
+	return ingredients, nil
+}
+
+// GetIngredientsByProduct получает ингредиенты продукта
+func (r *IngredientRepository) GetIngredientsByProduct(ctx context.Context, productID uuid.UUID) ([]*entity.Ingredient, error) {
+	var ingredients []*entity.Ingredient
+	if err := r.withStock(ctx).
+		Joins("JOIN product_ingredients ON ingredients.id = product_ingredients.ingredient_id").
+		Where("product_ingredients.product_id = ?", productID).
+		Find(&ingredients).Error; err != nil {
+		return nil, err
+	}
+	return ingredients, nil
+}
+
+// AddIngredientToProduct добавляет ингредиент к продукту в единицах ингредиента
+func (r *IngredientRepository) AddIngredientToProduct(ctx context.Context, productID, ingredientID uuid.UUID, quantity float64) error {
+	ingredient, err := r.GetByID(ctx, ingredientID)
+	if err != nil {
+		return errors.New("ингредиент не найден")
+	}
+	return NewProductRepository(r.db).AddIngredientToProduct(ctx, productID, ingredientID, quantity, ingredient.Unit)
+}
+
+// RemoveIngredientFromProduct удаляет ингредиент из продукта
+func (r *IngredientRepository) RemoveIngredientFromProduct(ctx context.Context, productID, ingredientID uuid.UUID) error {
+	return NewProductRepository(r.db).RemoveIngredientFromProduct(ctx, productID, ingredientID)
+}
+
+// ValidateIngredient проверяет данные ингредиента перед сохранением и приводит единицу к стандартному коду
+func (r *IngredientRepository) ValidateIngredient(ctx context.Context, ingredient *entity.Ingredient) error {
+	if ingredient.Name == "" {
+		return errors.New("название ингредиента не может быть пустым")
+	}
+	if ingredient.Quantity < 0 {
+		return errors.New("начальный остаток не может быть отрицательным")
+	}
+	unit, err := units.Normalize(ingredient.Unit)
+	if err != nil {
+		return err
+	}
+	if ingredient.Density < 0 {
+		return errors.New("плотность не может быть отрицательной")
+	}
+
+	ingredient.Unit = unit
+	return nil
+}
+
+// CheckAvailability проверяет, что на складе есть нужное количество ингредиента
+func (r *IngredientRepository) CheckAvailability(ctx context.Context, ingredientID uuid.UUID, quantity float64) (bool, error) {
+	level, err := NewStockRepository(r.db).GetStockLevel(ctx, ingredientID)
+	if err != nil {
+		return false, err
+	}
+	return level.Quantity >= quantity, nil
+}
+
+// withStock добавляет к выборке ингредиентов текущий остаток по складскому журналу
+func (r *IngredientRepository) withStock(ctx context.Context) *gorm.DB {
+	return conn(ctx, r.db).
+		Model(&entity.Ingredient{}).
+		Select("ingredients.*, " + stockQuantityExpr + " AS quantity")
+}
@@ -0,0 +1,150 @@
+package repositories
+
+import (
+	"coffe/internal/kitchen/entity"
+	orderentity "coffe/internal/order/entity"
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// KitchenRepository реализует методы доступа к станциям и тикетам кухни в базе данных.
+type KitchenRepository struct {
+	db *gorm.DB
+}
+
+// NewKitchenRepository создает новый экземпляр KitchenRepository.
+func NewKitchenRepository(db *gorm.DB) *KitchenRepository {
+	return &KitchenRepository{db: db}
+}
+
+// CreateStation создает станцию. Категории, закрепленные за другими станциями, переходят к новой
+func (r *KitchenRepository) CreateStation(ctx context.Context, station *entity.Station) error {
+	if station.ID == uuid.Nil {
+		station.ID = uuid.New()
+	}
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Categories").Create(station).Error; err != nil {
+			return err
+		}
+		return replaceStationCategories(tx, station)
+	})
+}
+
+// UpdateStation обновляет станцию и заменяет ее категории
+func (r *KitchenRepository) UpdateStation(ctx context.Context, station *entity.Station) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Categories").Save(station).Error; err != nil {
+			return err
+		}
+		return replaceStationCategories(tx, station)
+	})
+}
+
+// replaceStationCategories закрепляет за станцией ровно ее категории
+func replaceStationCategories(tx *gorm.DB, station *entity.Station) error {
+	categories := make([]string, len(station.Categories))
+	for i := range station.Categories {
+		station.Categories[i].StationID = station.ID
+		categories[i] = station.Categories[i].Category
+	}
+	if err := tx.Where("station_id = ? OR category IN ?", station.ID, categories).
+		Delete(&entity.StationCategory{}).Error; err != nil {
+		return err
+	}
+	if len(station.Categories) == 0 {
+		return nil
+	}
+	return tx.Create(&station.Categories).Error
+}
+
+// DeleteStation удаляет станцию вместе с ее категориями
+func (r *KitchenRepository) DeleteStation(ctx context.Context, id uuid.UUID) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("station_id = ?", id).Delete(&entity.StationCategory{}).Error; err != nil {
+			return err
+		}
+		result := tx.Where("id = ?", id).Delete(&entity.Station{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+// GetStationByID получает станцию с категориями по ID
+func (r *KitchenRepository) GetStationByID(ctx context.Context, id uuid.UUID) (*entity.Station, error) {
+	var station entity.Station
+	if err := conn(ctx, r.db).Preload("Categories").Where("id = ?", id).First(&station).Error; err != nil {
+		return nil, err
+	}
+	return &station, nil
+}
+
+// GetStations получает все станции с категориями в порядке отображения
+func (r *KitchenRepository) GetStations(ctx context.Context) ([]*entity.Station, error) {
+	var stations []*entity.Station
+	if err := conn(ctx, r.db).Preload("Categories").Order("sort_order, name").Find(&stations).Error; err != nil {
+		return nil, err
+	}
+	return stations, nil
+}
+
+// CreateTickets создает тикеты заказа вместе с позициями
+func (r *KitchenRepository) CreateTickets(ctx context.Context, tickets []*entity.Ticket) error {
+	if len(tickets) == 0 {
+		return nil
+	}
+	return conn(ctx, r.db).Create(&tickets).Error
+}
+
+// UpdateTicket обновляет тикет без позиций
+func (r *KitchenRepository) UpdateTicket(ctx context.Context, ticket *entity.Ticket) error {
+	return conn(ctx, r.db).Omit("Lines").Save(ticket).Error
+}
+
+// GetTicketByID получает тикет с позициями по ID
+func (r *KitchenRepository) GetTicketByID(ctx context.Context, id uuid.UUID) (*entity.Ticket, error) {
+	var ticket entity.Ticket
+	if err := conn(ctx, r.db).Preload("Lines").Where("id = ?", id).First(&ticket).Error; err != nil {
+		return nil, err
+	}
+	return &ticket, nil
+}
+
+// LockByOrder получает тикеты заказа с блокировкой строк до конца транзакции
+func (r *KitchenRepository) LockByOrder(ctx context.Context, orderID uuid.UUID) ([]*entity.Ticket, error) {
+	var tickets []*entity.Ticket
+	if err := conn(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("order_id = ?", orderID).
+		Order("created_at").
+		Find(&tickets).Error; err != nil {
+		return nil, err
+	}
+	return tickets, nil
+}
+
+// GetByStation получает тикеты станции по готовящимся и готовым заказам, старые первыми;
+// пустой статус - все тикеты
+func (r *KitchenRepository) GetByStation(ctx context.Context, stationID uuid.UUID, status entity.TicketStatus) ([]*entity.Ticket, error) {
+	var tickets []*entity.Ticket
+	query := conn(ctx, r.db).
+		Preload("Lines").
+		Joins("JOIN orders ON orders.id = kitchen_tickets.order_id").
+		Where("kitchen_tickets.station_id = ? AND orders.status IN ?", stationID,
+			[]orderentity.OrderStatus{orderentity.OrderStatusPreparing, orderentity.OrderStatusReady}).
+		Order("kitchen_tickets.opened_at")
+	if status != "" {
+		query = query.Where("kitchen_tickets.status = ?", status)
+	}
+	if err := query.Find(&tickets).Error; err != nil {
+		return nil, err
+	}
+	return tickets, nil
+}
@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"coffe/internal/common"
+	"coffe/internal/loyalty/entity"
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LoyaltyRepository реализует методы доступа к журналу баллов в базе данных.
+type LoyaltyRepository struct {
+	db *gorm.DB
+}
+
+// NewLoyaltyRepository создает новый экземпляр LoyaltyRepository.
+func NewLoyaltyRepository(db *gorm.DB) *LoyaltyRepository {
+	return &LoyaltyRepository{db: db}
+}
+
+// AddEntries записывает операции с баллами
+func (r *LoyaltyRepository) AddEntries(ctx context.Context, entries ...*entity.Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	for _, entry := range entries {
+		if entry.ID == uuid.Nil {
+			entry.ID = uuid.New()
+		}
+	}
+	return conn(ctx, r.db).Create(&entries).Error
+}
+
+// GetHistory получает последние операции клиента
+func (r *LoyaltyRepository) GetHistory(ctx context.Context, customerID uuid.UUID, limit int) ([]*entity.Entry, error) {
+	var entries []*entity.Entry
+	query := conn(ctx, r.db).
+		Where("customer_id = ?", customerID).
+		Order("created_at DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if err := query.Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// GetOrderEntries получает операции с баллами по заказу
+func (r *LoyaltyRepository) GetOrderEntries(ctx context.Context, orderID uuid.UUID) ([]*entity.Entry, error) {
+	var entries []*entity.Entry
+	if err := conn(ctx, r.db).
+		Where("order_id = ?", orderID).
+		Order("created_at").
+		Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// GetBalance считает баланс клиента и баллы, начисленные за все время.
+// Отрицательные отмены - это отмененные начисления, они уменьшают накопленное.
+func (r *LoyaltyRepository) GetBalance(ctx context.Context, customerID uuid.UUID) (int64, int64, error) {
+	var row struct {
+		Points int64
+		Earned int64
+	}
+	err := conn(ctx, r.db).
+		Model(&entity.Entry{}).
+		Select(`COALESCE(SUM(points), 0) AS points,
+			COALESCE(SUM(points) FILTER (WHERE type = ? OR (type = ? AND points < 0)), 0) AS earned`,
+			entity.EntryEarn, entity.EntryReversal).
+		Where("customer_id = ?", customerID).
+		Scan(&row).Error
+	return row.Points, row.Earned, err
+}
+
+// LockCustomer блокирует строку пользователя и возвращает название его роли
+func (r *LoyaltyRepository) LockCustomer(ctx context.Context, customerID uuid.UUID) (string, error) {
+	var user common.User
+	if err := conn(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", customerID).
+		First(&user).Error; err != nil {
+		return "", err
+	}
+
+	var role common.Role
+	if err := conn(ctx, r.db).Where("id = ?", user.RoleID).First(&role).Error; err != nil {
+		return "", err
+	}
+	return role.Name, nil
+}
@@ -5,6 +5,7 @@ import (
 	"coffe/internal/menu/entity"
 	"context"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -179,7 +180,7 @@ func (r *MenuRepository) CreateMenuItem(ctx context.Context, item *entity.MenuIt
 func (r *MenuRepository) GetItemsByCategory(ctx context.Context, categoryID uuid.UUID) ([]*entity.MenuItem, error) {
 	var items []*entity.MenuItem
 	if err := r.db.WithContext(ctx).
-		Preload("Product").
+		Scopes(withProductOptions).
 		Preload("Category").
 		Where("category_id = ?", categoryID).
 		Order("sort_order").
@@ -193,7 +194,7 @@ func (r *MenuRepository) GetItemsByCategory(ctx context.Context, categoryID uuid
 func (r *MenuRepository) GetActiveItems(ctx context.Context) ([]*entity.MenuItem, error) {
 	var items []*entity.MenuItem
 	if err := r.db.WithContext(ctx).
-		Preload("Product").
+		Scopes(withProductOptions).
 		Preload("Category").
 		Where("is_active = ?", true).
 		Order("sort_order").
@@ -207,7 +208,7 @@ func (r *MenuRepository) GetActiveItems(ctx context.Context) ([]*entity.MenuItem
 func (r *MenuRepository) GetMenuItemByID(ctx context.Context, id uuid.UUID) (*entity.MenuItem, error) {
 	var item entity.MenuItem
 	if err := r.db.WithContext(ctx).
-		Preload("Product").
+		Scopes(withProductOptions).
 		Preload("Category").
 		Where("id = ?", id).
 		First(&item).Error; err != nil {
@@ -253,7 +254,7 @@ func (r *MenuRepository) DeactivateMenuItem(ctx context.Context, id uuid.UUID) e
 func (r *MenuRepository) GetMenuItemsByMenu(ctx context.Context, menuID uuid.UUID) ([]*entity.MenuItem, error) {
 	var items []*entity.MenuItem
 	if err := r.db.WithContext(ctx).
-		Preload("Product").
+		Scopes(withProductOptions).
 		Preload("Category").
 		Where("menu_id = ?", menuID).
 		Order("sort_order").
@@ -263,9 +264,89 @@ func (r *MenuRepository) GetMenuItemsByMenu(ctx context.Context, menuID uuid.UUI
 	return items, nil
 }
 
-func (r *MenuRepository) SearchMenuItems(ctx context.Context, dto dto.MenuSearchDTO) ([]*entity.MenuItem, int64, error) {
-	query := r.db.Model(&entity.MenuItem{}).
-		Preload("Product").
+// IsProductInActiveMenu проверяет, что продукт входит в действующее меню позицией,
+// которая активна и не находится в стоп-листе
+func (r *MenuRepository) IsProductInActiveMenu(ctx context.Context, productID uuid.UUID) (bool, error) {
+	now := time.Now()
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&entity.MenuItem{}).
+		Scopes(availableMenuItems).
+		Joins("JOIN menus ON menus.id = menu_items.menu_id").
+		Where("menu_items.product_id = ?", productID).
+		Where("menus.is_active = ? AND menus.valid_from <= ?", true, now).
+		Where("menus.valid_to IS NULL OR menus.valid_to > ?", now).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// GetAvailableItems получает активные позиции меню, не попавшие в стоп-лист
+func (r *MenuRepository) GetAvailableItems(ctx context.Context) ([]*entity.MenuItem, error) {
+	var items []*entity.MenuItem
+	if err := r.db.WithContext(ctx).
+		Scopes(withProductOptions).
+		Preload("Category").
+		Scopes(availableMenuItems).
+		Order("sort_order").
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// GetStopList получает позиции, которым не хватает ингредиентов или которые персонал снял вручную
+func (r *MenuRepository) GetStopList(ctx context.Context) ([]*entity.MenuItem, error) {
+	var items []*entity.MenuItem
+	if err := r.db.WithContext(ctx).
+		Scopes(withProductOptions).
+		Where("out_of_stock = ? OR stop_list_override <> ?", true, entity.StopListAuto).
+		Order("sort_order").
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// SetOutOfStock отмечает все позиции продукта как закончившиеся или снова доступные
+func (r *MenuRepository) SetOutOfStock(ctx context.Context, productID uuid.UUID, outOfStock bool, reason string) error {
+	return conn(ctx, r.db).
+		Model(&entity.MenuItem{}).
+		Where("product_id = ?", productID).
+		Updates(map[string]interface{}{
+			"out_of_stock":        outOfStock,
+			"out_of_stock_reason": reason,
+		}).Error
+}
+
+// SetStopListOverride сохраняет ручное решение персонала по позиции
+func (r *MenuRepository) SetStopListOverride(ctx context.Context, itemID uuid.UUID, override entity.StopListOverride) error {
+	result := r.db.WithContext(ctx).
+		Model(&entity.MenuItem{}).
+		Where("id = ?", itemID).
+		Update("stop_list_override", override)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// availableMenuItems оставляет активные позиции с учетом стоп-листа и решения персонала
+func availableMenuItems(db *gorm.DB) *gorm.DB {
+	return db.
+		Where("menu_items.is_active = ?", true).
+		Where("menu_items.stop_list_override = ? OR (menu_items.stop_list_override = ? AND menu_items.out_of_stock = ?)",
+			entity.StopListAvailable, entity.StopListAuto, false)
+}
+
+// SearchMenuItems ищет позиции меню по фильтрам и возвращает общее количество найденных
+func (r *MenuRepository) SearchMenuItems(ctx context.Context, dto *dto.MenuSearchDTO) ([]*entity.MenuItem, int64, error) {
+	query := r.db.WithContext(ctx).Model(&entity.MenuItem{}).
+		Scopes(withProductOptions).
 		Preload("Category")
 
 	// Применяем фильтры
@@ -289,11 +370,11 @@ func (r *MenuRepository) SearchMenuItems(ctx context.Context, dto dto.MenuSearch
 		query = query.Where("is_active = ?", *dto.IsActive)
 	}
 
-	if dto.PriceRange[0] > 0 {
+	if dto.PriceRange[0].IsPositive() {
 		query = query.Where("price >= ?", dto.PriceRange[0])
 	}
 
-	if dto.PriceRange[1] > 0 {
+	if dto.PriceRange[1].IsPositive() {
 		query = query.Where("price <= ?", dto.PriceRange[1])
 	}
 
@@ -346,3 +427,12 @@ func (r *MenuRepository) Exists(ctx context.Context, id uuid.UUID) (bool, error)
 	}
 	return count > 0, nil
 }
+
+// withProductOptions загружает продукт позиции вместе с активными вариантами и модификаторами
+func withProductOptions(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Product").
+		Preload("Product.Variants", activeVariants).
+		Preload("Product.ModifierGroups", orderedGroups).
+		Preload("Product.ModifierGroups.Modifiers", activeModifiers)
+}
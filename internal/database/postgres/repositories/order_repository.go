@@ -4,9 +4,11 @@ import (
 	"coffe/internal/order/entity"
 	"context"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type OrderRepository struct {
@@ -24,7 +26,7 @@ func (r *OrderRepository) Create(ctx context.Context, order *entity.Order) error
 	if len(order.Items) == 0 {
 		return errors.New("заказ не может быть пустым")
 	}
-	return r.db.WithContext(ctx).Create(order).Error
+	return conn(ctx, r.db).Create(order).Error
 }
 
 func (r *OrderRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Order, error) {
@@ -32,16 +34,199 @@ func (r *OrderRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Or
 	if id == uuid.Nil {
 		return nil, errors.New("передан пустой id")
 	}
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(&order).Error
+	err := conn(ctx, r.db).Preload("Items.Modifiers").Preload("Discounts").Where("id = ?", id).First(&order).Error
 	if err != nil {
-		return nil, errors.New("заказ с таким id не найден")
+		return nil, err
 	}
 	return order, nil
 }
 
+// LockByID получает заказ по ID с блокировкой строки до конца транзакции, чтобы
+// параллельные оплаты заказа выполнялись по очереди
+func (r *OrderRepository) LockByID(ctx context.Context, id uuid.UUID) (*entity.Order, error) {
+	var order entity.Order
+	err := conn(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("Items.Modifiers").
+		Preload("Discounts").
+		Where("id = ?", id).
+		First(&order).Error
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
 func (r *OrderRepository) Update(ctx context.Context, order *entity.Order) error {
 	if order.Id == uuid.Nil {
 		return errors.New("id не может быть пустым")
 	}
-	return r.db.WithContext(ctx).Save(order).Error
+	return conn(ctx, r.db).Save(order).Error
+}
+
+// SetFiscal сохраняет реквизиты фискального чека, не затрагивая остальные поля заказа
+func (r *OrderRepository) SetFiscal(ctx context.Context, orderID uuid.UUID, fiscal entity.FiscalData) error {
+	return conn(ctx, r.db).Model(&entity.Order{}).Where("id = ?", orderID).Updates(map[string]interface{}{
+		"fiscal_document_number": fiscal.DocumentNumber,
+		"fiscal_sign":            fiscal.Sign,
+		"fiscal_drive_number":    fiscal.DriveNumber,
+		"fiscal_registered_at":   fiscal.RegisteredAt,
+	}).Error
+}
+
+// Delete удаляет заказ вместе с позициями, их модификаторами, скидками и историей статусов
+func (r *OrderRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return errors.New("id не может быть пустым")
+	}
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		items := tx.Model(&entity.ItemsOrders{}).Select("id").Where("order_id = ?", id)
+		if err := tx.Where("item_id IN (?)", items).Delete(&entity.ItemModifier{}).Error; err != nil {
+			return err
+		}
+		children := []interface{}{&entity.ItemsOrders{}, &entity.OrderDiscount{}, &entity.OrderStatusHistory{}}
+		for _, child := range children {
+			if err := tx.Where("order_id = ?", id).Delete(child).Error; err != nil {
+				return err
+			}
+		}
+		result := tx.Where("id = ?", id).Delete(&entity.Order{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("заказ не найден")
+		}
+		return nil
+	})
+}
+
+// GetByCustomer получает заказы клиента, начиная с последних
+func (r *OrderRepository) GetByCustomer(ctx context.Context, customerID uuid.UUID) ([]*entity.Order, error) {
+	var orders []*entity.Order
+	if err := conn(ctx, r.db).
+		Preload("Items.Modifiers").
+		Preload("Discounts").
+		Where("customer_id = ?", customerID).
+		Order("created_at DESC").
+		Find(&orders).Error; err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// GetByStatus получает заказы с указанным статусом в порядке поступления
+func (r *OrderRepository) GetByStatus(ctx context.Context, status entity.OrderStatus) ([]*entity.Order, error) {
+	var orders []*entity.Order
+	if err := conn(ctx, r.db).
+		Preload("Items.Modifiers").
+		Preload("Discounts").
+		Where("status = ?", status).
+		Order("created_at").
+		Find(&orders).Error; err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// UpdateStatus переводит заказ из статуса FromStatus в ToStatus и записывает
+// изменение в историю. Если статус уже изменился, возвращает entity.ErrStatusConflict.
+func (r *OrderRepository) UpdateStatus(ctx context.Context, change *entity.OrderStatusHistory) error {
+	if change.OrderID == uuid.Nil {
+		return errors.New("id не может быть пустым")
+	}
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.Order{}).
+			Where("id = ? AND status = ?", change.OrderID, change.FromStatus).
+			Update("status", change.ToStatus)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return entity.ErrStatusConflict
+		}
+		return tx.Create(change).Error
+	})
+}
+
+// GetStatusHistory получает историю статусов заказа в хронологическом порядке
+func (r *OrderRepository) GetStatusHistory(ctx context.Context, orderID uuid.UUID) ([]*entity.OrderStatusHistory, error) {
+	var history []*entity.OrderStatusHistory
+	if err := conn(ctx, r.db).
+		Where("order_id = ?", orderID).
+		Order("created_at").
+		Find(&history).Error; err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// Count подсчитывает общее количество заказов
+func (r *OrderRepository) Count(ctx context.Context) (int64, error) {
+	var count int64
+	if err := conn(ctx, r.db).Model(&entity.Order{}).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// GetForBoard получает номера и статусы готовящихся и готовых заказов, созданных после since;
+// предзаказы отбираются по времени получения
+func (r *OrderRepository) GetForBoard(ctx context.Context, since time.Time) ([]*entity.Order, error) {
+	var orders []*entity.Order
+	if err := conn(ctx, r.db).
+		Select("id", "number", "status").
+		Where("status IN ? AND COALESCE(pickup_at, created_at) >= ?", []entity.OrderStatus{entity.OrderStatusPreparing, entity.OrderStatusReady}, since).
+		Order("number").
+		Find(&orders).Error; err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// LockPickupSlot блокирует слот получения до конца транзакции, чтобы параллельные
+// заказы не превысили его вместимость
+func (r *OrderRepository) LockPickupSlot(ctx context.Context, slot time.Time) error {
+	return conn(ctx, r.db).Exec("SELECT pg_advisory_xact_lock(?)", slot.Unix()).Error
+}
+
+// GetPickupTimes получает время получения неотмененных заказов в периоде [from, to)
+func (r *OrderRepository) GetPickupTimes(ctx context.Context, from, to time.Time) ([]time.Time, error) {
+	var times []time.Time
+	if err := conn(ctx, r.db).
+		Model(&entity.Order{}).
+		Where("pickup_at >= ? AND pickup_at < ? AND status <> ?", from, to, entity.OrderStatusCancelled).
+		Pluck("pickup_at", &times).Error; err != nil {
+		return nil, err
+	}
+	return times, nil
+}
+
+// GetDuePreorders получает ожидающие предзаказы со слотом получения не позже before, ранние первыми
+func (r *OrderRepository) GetDuePreorders(ctx context.Context, before time.Time) ([]*entity.Order, error) {
+	var orders []*entity.Order
+	if err := conn(ctx, r.db).
+		Where("status = ? AND pickup_at IS NOT NULL AND pickup_at <= ?", entity.OrderStatusPending, before).
+		Order("pickup_at").
+		Find(&orders).Error; err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// GetToday получает заказы, созданные с начала текущих суток
+func (r *OrderRepository) GetToday(ctx context.Context) ([]*entity.Order, error) {
+	now := time.Now()
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	var orders []*entity.Order
+	if err := conn(ctx, r.db).
+		Preload("Items.Modifiers").
+		Preload("Discounts").
+		Where("created_at >= ?", startOfDay).
+		Order("created_at").
+		Find(&orders).Error; err != nil {
+		return nil, err
+	}
+	return orders, nil
 }
@@ -0,0 +1,156 @@
+package repositories
+
+import (
+	"coffe/internal/common"
+	"coffe/internal/payment/entity"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PaymentRepository реализует методы доступа к платежам в базе данных.
+type PaymentRepository struct {
+	db *gorm.DB
+}
+
+// NewPaymentRepository создает новый экземпляр PaymentRepository.
+func NewPaymentRepository(db *gorm.DB) *PaymentRepository {
+	return &PaymentRepository{db: db}
+}
+
+// Create создает платеж вместе с оплаченными позициями
+func (r *PaymentRepository) Create(ctx context.Context, payment *entity.Payment) error {
+	if payment.ID == uuid.Nil {
+		payment.ID = uuid.New()
+	}
+	for i := range payment.Lines {
+		if payment.Lines[i].ID == uuid.Nil {
+			payment.Lines[i].ID = uuid.New()
+		}
+		payment.Lines[i].PaymentID = payment.ID
+	}
+	return conn(ctx, r.db).Create(payment).Error
+}
+
+// Update обновляет платеж
+func (r *PaymentRepository) Update(ctx context.Context, payment *entity.Payment) error {
+	if payment.ID == uuid.Nil {
+		return errors.New("ID платежа не может быть пустым")
+	}
+	return conn(ctx, r.db).Omit("Lines").Save(payment).Error
+}
+
+// GetByID получает платеж по ID
+func (r *PaymentRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Payment, error) {
+	var payment entity.Payment
+	if err := conn(ctx, r.db).Where("id = ?", id).First(&payment).Error; err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
+// GetByOrder получает платежи заказа в порядке создания
+func (r *PaymentRepository) GetByOrder(ctx context.Context, orderID uuid.UUID) ([]*entity.Payment, error) {
+	var payments []*entity.Payment
+	if err := conn(ctx, r.db).
+		Preload("Lines").
+		Where("order_id = ?", orderID).
+		Order("created_at").
+		Find(&payments).Error; err != nil {
+		return nil, err
+	}
+	return payments, nil
+}
+
+// GetByExternalID получает платеж по его ID в платежном шлюзе
+func (r *PaymentRepository) GetByExternalID(ctx context.Context, provider, externalID string) (*entity.Payment, error) {
+	var payment entity.Payment
+	if err := conn(ctx, r.db).
+		Where("provider = ? AND external_id = ?", provider, externalID).
+		First(&payment).Error; err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
+// LockByID получает платеж по ID с оплаченными позициями и блокировкой строки до конца транзакции
+func (r *PaymentRepository) LockByID(ctx context.Context, id uuid.UUID) (*entity.Payment, error) {
+	var payment entity.Payment
+	if err := conn(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("Lines").
+		Where("id = ?", id).
+		First(&payment).Error; err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
+// CreateRefund записывает возврат вместе с позициями
+func (r *PaymentRepository) CreateRefund(ctx context.Context, refund *entity.Refund) error {
+	if refund.ID == uuid.Nil {
+		refund.ID = uuid.New()
+	}
+	for i := range refund.Lines {
+		if refund.Lines[i].ID == uuid.Nil {
+			refund.Lines[i].ID = uuid.New()
+		}
+		refund.Lines[i].RefundID = refund.ID
+	}
+	return conn(ctx, r.db).Create(refund).Error
+}
+
+// GetRefundsByOrder получает возвраты заказа с позициями
+func (r *PaymentRepository) GetRefundsByOrder(ctx context.Context, orderID uuid.UUID) ([]*entity.Refund, error) {
+	var refunds []*entity.Refund
+	if err := conn(ctx, r.db).
+		Preload("Lines").
+		Where("order_id = ?", orderID).
+		Order("created_at").
+		Find(&refunds).Error; err != nil {
+		return nil, err
+	}
+	return refunds, nil
+}
+
+// GetTotals считает суммы списаний, чаевых и возвратов за период. Чаевые
+// считаются за вычетом возвращенных клиентам
+func (r *PaymentRepository) GetTotals(ctx context.Context, from, to time.Time) (*entity.Totals, error) {
+	var captured struct {
+		Amount int64
+		Tip    int64
+		Count  int64
+	}
+	var refunded struct {
+		Amount int64
+		Count  int64
+	}
+	if err := conn(ctx, r.db).
+		Model(&entity.Payment{}).
+		Select("COALESCE(SUM(amount), 0) AS amount, COALESCE(SUM(tip - COALESCE(refunded_tip, 0)), 0) AS tip, COUNT(*) AS count").
+		Where("captured_at >= ? AND captured_at < ?", from, to).
+		Scan(&captured).Error; err != nil {
+		return nil, err
+	}
+	if err := conn(ctx, r.db).
+		Model(&entity.Refund{}).
+		Select("COALESCE(SUM(amount), 0) AS amount, COUNT(*) AS count").
+		Where("created_at >= ? AND created_at < ?", from, to).
+		Scan(&refunded).Error; err != nil {
+		return nil, err
+	}
+
+	return &entity.Totals{
+		From:     from,
+		To:       to,
+		Captured: common.NewMoney(captured.Amount),
+		Payments: captured.Count,
+		Refunded: common.NewMoney(refunded.Amount),
+		Refunds:  refunded.Count,
+		Tips:     common.NewMoney(captured.Tip),
+	}, nil
+}
@@ -1,9 +1,12 @@
 package repositories
 
 import (
+	"coffe/internal/common"
 	"coffe/internal/menu/entity"
+	"coffe/internal/units"
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -25,7 +28,7 @@ func (r *ProductRepository) Create(ctx context.Context, product *entity.Product)
 	if product.Category == "" {
 		return errors.New("категория продукта не может быть пустой")
 	}
-	if product.Price <= 0 {
+	if !product.Price.IsPositive() {
 		return errors.New("цена продукта должна быть больше нуля")
 	}
 	if product.Description == "" {
@@ -37,20 +40,18 @@ func (r *ProductRepository) Create(ctx context.Context, product *entity.Product)
 
 // CreateIngredient создает ингредиент (должно быть в IngredientRepository)
 func (r *ProductRepository) CreateIngredient(ctx context.Context, ingredient *entity.Ingredient) error {
-	if ingredient.Name == "" {
-		return errors.New("название ингредиента не может быть пустым")
-	}
-	if ingredient.Quantity <= 0 {
-		return errors.New("количество ингредиента должно быть больше нуля")
-	}
-
-	return r.db.WithContext(ctx).Create(ingredient).Error
+	return NewIngredientRepository(r.db).Create(ctx, ingredient)
 }
 
 // GetByID получает продукт по ID
 func (r *ProductRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Product, error) {
 	var product entity.Product
-	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&product).Error; err != nil {
+	if err := r.db.WithContext(ctx).
+		Preload("Variants", activeVariants).
+		Preload("ModifierGroups", orderedGroups).
+		Preload("ModifierGroups.Modifiers", activeModifiers).
+		Where("id = ?", id).
+		First(&product).Error; err != nil {
 		return nil, err
 	}
 	return &product, nil
@@ -67,11 +68,23 @@ func (r *ProductRepository) Update(ctx context.Context, product *entity.Product)
 	if product.Category == "" {
 		return errors.New("категория продукта не может быть пустой")
 	}
-	if product.Price <= 0 {
+	if !product.Price.IsPositive() {
 		return errors.New("цена продукта должна быть больше нуля")
 	}
 
-	return r.db.WithContext(ctx).Save(product).Error
+	// Цена вариантов с надбавкой считается от цены продукта и не должна стать неположительной
+	var variants []*entity.ProductVariant
+	if err := r.db.WithContext(ctx).Where("product_id = ? AND price IS NULL", product.ID).Find(&variants).Error; err != nil {
+		return err
+	}
+	for _, variant := range variants {
+		if !variant.PriceFor(product.Price).IsPositive() {
+			return fmt.Errorf("цена варианта %s с учетом надбавки должна быть больше нуля", variant.Name)
+		}
+	}
+
+	// Варианты сохраняются отдельными методами
+	return r.db.WithContext(ctx).Omit("Variants", "Ingredients", "ModifierGroups").Save(product).Error
 }
 
 // Delete удаляет продукт по ID
@@ -137,7 +150,7 @@ func (r *ProductRepository) Search(ctx context.Context, query string) ([]*entity
 }
 
 // GetByPriceRange получает продукты в диапазоне цен
-func (r *ProductRepository) GetByPriceRange(ctx context.Context, minPrice, maxPrice float64) ([]*entity.Product, error) {
+func (r *ProductRepository) GetByPriceRange(ctx context.Context, minPrice, maxPrice common.Money) ([]*entity.Product, error) {
 	var products []*entity.Product
 	if err := r.db.WithContext(ctx).Where("price BETWEEN ? AND ?", minPrice, maxPrice).Find(&products).Error; err != nil {
 		return nil, err
@@ -203,10 +216,10 @@ func (r *ProductRepository) AddIngredientToProduct(ctx context.Context, productI
 		return errors.New("продукт не найден")
 	}
 
-	// Проверяем, существует ли ингредиент
-	var ingredient entity.Ingredient
-	if err := r.db.WithContext(ctx).Where("id = ?", ingredientID).First(&ingredient).Error; err != nil {
-		return errors.New("ингредиент не найден")
+	// Проверяем, существует ли ингредиент и можно ли пересчитать единицу рецепта в его единицу
+	unit, err = r.recipeUnit(ctx, ingredientID, unit)
+	if err != nil {
+		return err
 	}
 
 	// Проверяем, не добавлен ли уже этот ингредиент к продукту
@@ -260,6 +273,11 @@ func (r *ProductRepository) UpdateProductIngredient(ctx context.Context, product
 		return errors.New("единица измерения не может быть пустой")
 	}
 
+	unit, err := r.recipeUnit(ctx, ingredientID, unit)
+	if err != nil {
+		return err
+	}
+
 	return r.db.WithContext(ctx).
 		Model(&entity.ProductIngredient{}).
 		Where("product_id = ? AND ingredient_id = ?", productID, ingredientID).
@@ -268,3 +286,323 @@ func (r *ProductRepository) UpdateProductIngredient(ctx context.Context, product
 			"unit":     unit,
 		}).Error
 }
+
+// recipeUnit проверяет, что единицу из рецепта можно пересчитать в единицу ингредиента
+// на складе, и возвращает ее стандартный код
+func (r *ProductRepository) recipeUnit(ctx context.Context, ingredientID uuid.UUID, unit string) (string, error) {
+	var ingredient entity.Ingredient
+	if err := r.db.WithContext(ctx).Where("id = ?", ingredientID).First(&ingredient).Error; err != nil {
+		return "", errors.New("ингредиент не найден")
+	}
+	if err := units.Compatible(unit, ingredient.Unit, ingredient.Density); err != nil {
+		return "", fmt.Errorf("ингредиент %s: %w", ingredient.Name, err)
+	}
+	return units.Normalize(unit)
+}
+
+// GetVariantsByProduct получает все варианты продукта вместе с явным составом
+func (r *ProductRepository) GetVariantsByProduct(ctx context.Context, productID uuid.UUID) ([]*entity.ProductVariant, error) {
+	var variants []*entity.ProductVariant
+	if err := r.db.WithContext(ctx).
+		Preload("Ingredients.Ingredient").
+		Where("product_id = ?", productID).
+		Order("sort_order").
+		Find(&variants).Error; err != nil {
+		return nil, err
+	}
+	return variants, nil
+}
+
+// GetVariantByID получает вариант по ID вместе с явным составом
+func (r *ProductRepository) GetVariantByID(ctx context.Context, id uuid.UUID) (*entity.ProductVariant, error) {
+	var variant entity.ProductVariant
+	if err := r.db.WithContext(ctx).
+		Preload("Ingredients.Ingredient").
+		Where("id = ?", id).
+		First(&variant).Error; err != nil {
+		return nil, err
+	}
+	return &variant, nil
+}
+
+// CreateVariant создает вариант продукта. Если вариант отмечен как вариант
+// по умолчанию, отметка снимается с остальных вариантов продукта
+func (r *ProductRepository) CreateVariant(ctx context.Context, variant *entity.ProductVariant) error {
+	if err := validateVariant(variant); err != nil {
+		return err
+	}
+	if exists, err := r.Exists(ctx, variant.ProductID); err != nil || !exists {
+		return errors.New("продукт не найден")
+	}
+	if variant.ID == uuid.Nil {
+		variant.ID = uuid.New()
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkVariantPrice(tx, variant); err != nil {
+			return err
+		}
+		if err := resetDefaultVariant(tx, variant); err != nil {
+			return err
+		}
+		return tx.Omit("Ingredients").Create(variant).Error
+	})
+}
+
+// UpdateVariant обновляет вариант продукта
+func (r *ProductRepository) UpdateVariant(ctx context.Context, variant *entity.ProductVariant) error {
+	if variant.ID == uuid.Nil {
+		return errors.New("ID варианта не может быть пустым")
+	}
+	if err := validateVariant(variant); err != nil {
+		return err
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkVariantPrice(tx, variant); err != nil {
+			return err
+		}
+		if err := resetDefaultVariant(tx, variant); err != nil {
+			return err
+		}
+		return tx.Omit("Ingredients").Save(variant).Error
+	})
+}
+
+// DeleteVariant удаляет вариант вместе с его составом
+func (r *ProductRepository) DeleteVariant(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return errors.New("ID варианта не может быть пустым")
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("variant_id = ?", id).Delete(&entity.VariantIngredient{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&entity.ProductVariant{}).Error
+	})
+}
+
+// SetVariantIngredients заменяет явный состав варианта. Пустой список возвращает
+// вариант к составу продукта с множителем
+func (r *ProductRepository) SetVariantIngredients(ctx context.Context, variantID uuid.UUID, ingredients []*entity.VariantIngredient) error {
+	if variantID == uuid.Nil {
+		return errors.New("ID варианта не может быть пустым")
+	}
+	for _, ingredient := range ingredients {
+		if ingredient.Quantity <= 0 {
+			return errors.New("количество должно быть больше нуля")
+		}
+		unit, err := r.recipeUnit(ctx, ingredient.IngredientID, ingredient.Unit)
+		if err != nil {
+			return err
+		}
+		ingredient.VariantID = variantID
+		ingredient.Unit = unit
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("variant_id = ?", variantID).Delete(&entity.VariantIngredient{}).Error; err != nil {
+			return err
+		}
+		if len(ingredients) == 0 {
+			return nil
+		}
+		return tx.Omit("Ingredient").Create(&ingredients).Error
+	})
+}
+
+// validateVariant проверяет данные варианта продукта
+func validateVariant(variant *entity.ProductVariant) error {
+	if variant.ProductID == uuid.Nil {
+		return errors.New("ID продукта не может быть пустым")
+	}
+	if variant.Name == "" {
+		return errors.New("название варианта не может быть пустым")
+	}
+	if variant.Price != nil && !variant.Price.IsPositive() {
+		return errors.New("цена варианта должна быть больше нуля")
+	}
+	if variant.RecipeMultiplier < 0 {
+		return errors.New("множитель состава не может быть отрицательным")
+	}
+	return nil
+}
+
+// checkVariantPrice проверяет, что итоговая цена варианта с учетом цены продукта больше нуля:
+// отрицательная надбавка не должна делать позицию бесплатной
+func checkVariantPrice(tx *gorm.DB, variant *entity.ProductVariant) error {
+	var product entity.Product
+	if err := tx.Select("price").Where("id = ?", variant.ProductID).First(&product).Error; err != nil {
+		return errors.New("продукт не найден")
+	}
+	if !variant.PriceFor(product.Price).IsPositive() {
+		return errors.New("цена варианта с учетом надбавки должна быть больше нуля")
+	}
+	return nil
+}
+
+// resetDefaultVariant снимает отметку "по умолчанию" с остальных вариантов продукта
+func resetDefaultVariant(tx *gorm.DB, variant *entity.ProductVariant) error {
+	if !variant.IsDefault {
+		return nil
+	}
+	return tx.Model(&entity.ProductVariant{}).
+		Where("product_id = ? AND id <> ?", variant.ProductID, variant.ID).
+		Update("is_default", false).Error
+}
+
+// activeVariants оставляет активные варианты в порядке отображения
+func activeVariants(db *gorm.DB) *gorm.DB {
+	return db.Where("is_active = ?", true).Order("sort_order")
+}
+
+// GetModifierGroups получает группы модификаторов продукта со всеми модификаторами
+func (r *ProductRepository) GetModifierGroups(ctx context.Context, productID uuid.UUID) ([]*entity.ModifierGroup, error) {
+	var groups []*entity.ModifierGroup
+	if err := r.db.WithContext(ctx).
+		Preload("Modifiers", func(db *gorm.DB) *gorm.DB { return db.Order("sort_order") }).
+		Preload("Modifiers.Ingredient").
+		Where("product_id = ?", productID).
+		Order("sort_order").
+		Find(&groups).Error; err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+// GetModifierGroupByID получает группу модификаторов по ID
+func (r *ProductRepository) GetModifierGroupByID(ctx context.Context, id uuid.UUID) (*entity.ModifierGroup, error) {
+	var group entity.ModifierGroup
+	if err := r.db.WithContext(ctx).
+		Preload("Modifiers", func(db *gorm.DB) *gorm.DB { return db.Order("sort_order") }).
+		Where("id = ?", id).
+		First(&group).Error; err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+// CreateModifierGroup создает группу модификаторов продукта
+func (r *ProductRepository) CreateModifierGroup(ctx context.Context, group *entity.ModifierGroup) error {
+	if group.ProductID == uuid.Nil {
+		return errors.New("ID продукта не может быть пустым")
+	}
+	if err := group.Validate(); err != nil {
+		return err
+	}
+	if exists, err := r.Exists(ctx, group.ProductID); err != nil || !exists {
+		return errors.New("продукт не найден")
+	}
+	if group.ID == uuid.Nil {
+		group.ID = uuid.New()
+	}
+	return r.db.WithContext(ctx).Omit("Modifiers").Create(group).Error
+}
+
+// UpdateModifierGroup обновляет группу модификаторов
+func (r *ProductRepository) UpdateModifierGroup(ctx context.Context, group *entity.ModifierGroup) error {
+	if group.ID == uuid.Nil {
+		return errors.New("ID группы не может быть пустым")
+	}
+	if err := group.Validate(); err != nil {
+		return err
+	}
+	return r.db.WithContext(ctx).Omit("Modifiers").Save(group).Error
+}
+
+// DeleteModifierGroup удаляет группу вместе с ее модификаторами
+func (r *ProductRepository) DeleteModifierGroup(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return errors.New("ID группы не может быть пустым")
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("group_id = ?", id).Delete(&entity.Modifier{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&entity.ModifierGroup{}).Error
+	})
+}
+
+// GetModifierByID получает модификатор по ID
+func (r *ProductRepository) GetModifierByID(ctx context.Context, id uuid.UUID) (*entity.Modifier, error) {
+	var modifier entity.Modifier
+	if err := r.db.WithContext(ctx).
+		Preload("Ingredient").
+		Where("id = ?", id).
+		First(&modifier).Error; err != nil {
+		return nil, err
+	}
+	return &modifier, nil
+}
+
+// CreateModifier создает модификатор в группе
+func (r *ProductRepository) CreateModifier(ctx context.Context, modifier *entity.Modifier) error {
+	if modifier.GroupID == uuid.Nil {
+		return errors.New("ID группы не может быть пустым")
+	}
+	if err := r.validateModifier(ctx, modifier); err != nil {
+		return err
+	}
+	if modifier.ID == uuid.Nil {
+		modifier.ID = uuid.New()
+	}
+	return r.db.WithContext(ctx).Omit("Ingredient").Create(modifier).Error
+}
+
+// UpdateModifier обновляет модификатор
+func (r *ProductRepository) UpdateModifier(ctx context.Context, modifier *entity.Modifier) error {
+	if modifier.ID == uuid.Nil {
+		return errors.New("ID модификатора не может быть пустым")
+	}
+	if err := r.validateModifier(ctx, modifier); err != nil {
+		return err
+	}
+	return r.db.WithContext(ctx).Omit("Ingredient").Save(modifier).Error
+}
+
+// DeleteModifier удаляет модификатор
+func (r *ProductRepository) DeleteModifier(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return errors.New("ID модификатора не может быть пустым")
+	}
+	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&entity.Modifier{}).Error
+}
+
+// validateModifier проверяет данные модификатора и приводит единицу добавляемого
+// ингредиента к стандартному коду
+func (r *ProductRepository) validateModifier(ctx context.Context, modifier *entity.Modifier) error {
+	if modifier.Name == "" {
+		return errors.New("название модификатора не может быть пустым")
+	}
+	if modifier.Quantity < 0 {
+		return errors.New("количество не может быть отрицательным")
+	}
+	if modifier.IngredientID == nil {
+		return nil
+	}
+	if modifier.ReplacesIngredientID == nil && modifier.Quantity == 0 {
+		return errors.New("для добавки нужно указать количество")
+	}
+	if modifier.Unit == "" {
+		if modifier.Quantity > 0 {
+			return errors.New("единица измерения не может быть пустой")
+		}
+		return nil
+	}
+	unit, err := r.recipeUnit(ctx, *modifier.IngredientID, modifier.Unit)
+	if err != nil {
+		return err
+	}
+	modifier.Unit = unit
+	return nil
+}
+
+// orderedGroups упорядочивает группы модификаторов для отображения
+func orderedGroups(db *gorm.DB) *gorm.DB {
+	return db.Order("sort_order")
+}
+
+// activeModifiers оставляет активные модификаторы в порядке отображения
+func activeModifiers(db *gorm.DB) *gorm.DB {
+	return db.Where("is_active = ?", true).Order("sort_order")
+}
@@ -0,0 +1,180 @@
+package repositories
+
+import (
+	"coffe/internal/promotion/entity"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PromotionRepository реализует методы доступа к акциям и промокодам в базе данных.
+type PromotionRepository struct {
+	db *gorm.DB
+}
+
+// NewPromotionRepository создает новый экземпляр PromotionRepository.
+func NewPromotionRepository(db *gorm.DB) *PromotionRepository {
+	return &PromotionRepository{db: db}
+}
+
+// Create создает акцию вместе с условиями
+func (r *PromotionRepository) Create(ctx context.Context, promotion *entity.Promotion) error {
+	if promotion.ID == uuid.Nil {
+		promotion.ID = uuid.New()
+	}
+	prepareConditions(promotion)
+	return conn(ctx, r.db).Create(promotion).Error
+}
+
+// Update обновляет акцию и заменяет ее условия
+func (r *PromotionRepository) Update(ctx context.Context, promotion *entity.Promotion) error {
+	if promotion.ID == uuid.Nil {
+		return errors.New("ID акции не может быть пустым")
+	}
+	prepareConditions(promotion)
+
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Conditions").Save(promotion).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("promotion_id = ?", promotion.ID).Delete(&entity.Condition{}).Error; err != nil {
+			return err
+		}
+		if len(promotion.Conditions) == 0 {
+			return nil
+		}
+		return tx.Create(&promotion.Conditions).Error
+	})
+}
+
+// GetByID получает акцию по ID
+func (r *PromotionRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Promotion, error) {
+	var promotion entity.Promotion
+	if err := conn(ctx, r.db).
+		Preload("Conditions").
+		Where("id = ?", id).
+		First(&promotion).Error; err != nil {
+		return nil, err
+	}
+	return &promotion, nil
+}
+
+// GetAll получает все акции, начиная с новых
+func (r *PromotionRepository) GetAll(ctx context.Context) ([]*entity.Promotion, error) {
+	var promotions []*entity.Promotion
+	if err := conn(ctx, r.db).
+		Preload("Conditions").
+		Order("created_at DESC").
+		Find(&promotions).Error; err != nil {
+		return nil, err
+	}
+	return promotions, nil
+}
+
+// Deactivate выключает акцию. Акции не удаляются, чтобы сохранить историю применений
+func (r *PromotionRepository) Deactivate(ctx context.Context, id uuid.UUID) error {
+	result := conn(ctx, r.db).
+		Model(&entity.Promotion{}).
+		Where("id = ?", id).
+		Update("is_active", false)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetByCode получает акцию по промокоду
+func (r *PromotionRepository) GetByCode(ctx context.Context, code string) (*entity.Promotion, error) {
+	var promotion entity.Promotion
+	if err := conn(ctx, r.db).
+		Preload("Conditions").
+		Where("code = ?", code).
+		First(&promotion).Error; err != nil {
+		return nil, err
+	}
+	return &promotion, nil
+}
+
+// GetAutomatic получает включенные акции без промокода, действующие в момент at
+func (r *PromotionRepository) GetAutomatic(ctx context.Context, at time.Time) ([]*entity.Promotion, error) {
+	var promotions []*entity.Promotion
+	if err := conn(ctx, r.db).
+		Preload("Conditions").
+		Where("code IS NULL AND is_active = ?", true).
+		Where("(valid_from IS NULL OR valid_from <= ?) AND (valid_to IS NULL OR valid_to > ?)", at, at).
+		Order("created_at").
+		Find(&promotions).Error; err != nil {
+		return nil, err
+	}
+	return promotions, nil
+}
+
+// LockUsage блокирует строки акций и считает их применения всего и клиентом
+func (r *PromotionRepository) LockUsage(ctx context.Context, promotionIDs []uuid.UUID, customerID uuid.UUID) (map[uuid.UUID]entity.UsageCount, error) {
+	counts := make(map[uuid.UUID]entity.UsageCount, len(promotionIDs))
+	if len(promotionIDs) == 0 {
+		return counts, nil
+	}
+
+	db := conn(ctx, r.db)
+	var locked []uuid.UUID
+	if err := db.Model(&entity.Promotion{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id IN ?", promotionIDs).
+		Order("id").
+		Pluck("id", &locked).Error; err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		PromotionID uuid.UUID
+		Total       int64
+		Customer    int64
+	}
+	if err := db.Model(&entity.Usage{}).
+		Select("promotion_id, COUNT(*) AS total, COUNT(*) FILTER (WHERE customer_id = ?) AS customer", customerID).
+		Where("promotion_id IN ?", promotionIDs).
+		Group("promotion_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts[row.PromotionID] = entity.UsageCount{Total: row.Total, Customer: row.Customer}
+	}
+	return counts, nil
+}
+
+// AddUsages записывает применения акций
+func (r *PromotionRepository) AddUsages(ctx context.Context, usages ...*entity.Usage) error {
+	if len(usages) == 0 {
+		return nil
+	}
+	for _, usage := range usages {
+		if usage.ID == uuid.Nil {
+			usage.ID = uuid.New()
+		}
+	}
+	return conn(ctx, r.db).Create(&usages).Error
+}
+
+// DeleteUsagesByOrder удаляет применения акций в заказе
+func (r *PromotionRepository) DeleteUsagesByOrder(ctx context.Context, orderID uuid.UUID) error {
+	return conn(ctx, r.db).Where("order_id = ?", orderID).Delete(&entity.Usage{}).Error
+}
+
+// prepareConditions заполняет идентификаторы условий акции
+func prepareConditions(promotion *entity.Promotion) {
+	for _, condition := range promotion.Conditions {
+		if condition.ID == uuid.Nil {
+			condition.ID = uuid.New()
+		}
+		condition.PromotionID = promotion.ID
+	}
+}
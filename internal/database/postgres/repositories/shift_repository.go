@@ -0,0 +1,58 @@
+package repositories
+
+import (
+	"coffe/internal/staff/entity"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ShiftRepository реализует методы доступа к сменам сотрудников в базе данных.
+type ShiftRepository struct {
+	db *gorm.DB
+}
+
+// NewShiftRepository создает новый экземпляр ShiftRepository.
+func NewShiftRepository(db *gorm.DB) *ShiftRepository {
+	return &ShiftRepository{db: db}
+}
+
+// Create открывает смену
+func (r *ShiftRepository) Create(ctx context.Context, shift *entity.Shift) error {
+	if shift.ID == uuid.Nil {
+		shift.ID = uuid.New()
+	}
+	return conn(ctx, r.db).Omit("User").Create(shift).Error
+}
+
+// Update обновляет смену
+func (r *ShiftRepository) Update(ctx context.Context, shift *entity.Shift) error {
+	return conn(ctx, r.db).Omit("User").Save(shift).Error
+}
+
+// GetOpen получает открытую смену сотрудника
+func (r *ShiftRepository) GetOpen(ctx context.Context, userID uuid.UUID) (*entity.Shift, error) {
+	var shift entity.Shift
+	if err := conn(ctx, r.db).
+		Where("user_id = ? AND ended_at IS NULL", userID).
+		Order("started_at DESC").
+		First(&shift).Error; err != nil {
+		return nil, err
+	}
+	return &shift, nil
+}
+
+// GetInPeriod получает смены, пересекающие период, вместе с сотрудниками
+func (r *ShiftRepository) GetInPeriod(ctx context.Context, from, to time.Time) ([]*entity.Shift, error) {
+	var shifts []*entity.Shift
+	if err := conn(ctx, r.db).
+		Preload("User").
+		Where("started_at < ? AND (ended_at IS NULL OR ended_at > ?)", to, from).
+		Order("started_at").
+		Find(&shifts).Error; err != nil {
+		return nil, err
+	}
+	return shifts, nil
+}
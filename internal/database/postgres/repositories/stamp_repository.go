@@ -0,0 +1,163 @@
+package repositories
+
+import (
+	"coffe/internal/loyalty/entity"
+	menuentity "coffe/internal/menu/entity"
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// StampRepository реализует методы доступа к штамп-картам в базе данных.
+type StampRepository struct {
+	db *gorm.DB
+}
+
+// NewStampRepository создает новый экземпляр StampRepository.
+func NewStampRepository(db *gorm.DB) *StampRepository {
+	return &StampRepository{db: db}
+}
+
+// CreateCampaign создает кампанию штамп-карты
+func (r *StampRepository) CreateCampaign(ctx context.Context, campaign *entity.StampCampaign) error {
+	if campaign.ID == uuid.Nil {
+		campaign.ID = uuid.New()
+	}
+	return conn(ctx, r.db).Create(campaign).Error
+}
+
+// UpdateCampaign обновляет кампанию штамп-карты
+func (r *StampRepository) UpdateCampaign(ctx context.Context, campaign *entity.StampCampaign) error {
+	if campaign.ID == uuid.Nil {
+		return errors.New("ID кампании не может быть пустым")
+	}
+	return conn(ctx, r.db).Save(campaign).Error
+}
+
+// GetCampaignByID получает кампанию по ID
+func (r *StampRepository) GetCampaignByID(ctx context.Context, id uuid.UUID) (*entity.StampCampaign, error) {
+	var campaign entity.StampCampaign
+	if err := conn(ctx, r.db).Where("id = ?", id).First(&campaign).Error; err != nil {
+		return nil, err
+	}
+	return &campaign, nil
+}
+
+// GetCampaigns получает кампании в порядке создания
+func (r *StampRepository) GetCampaigns(ctx context.Context, activeOnly bool) ([]*entity.StampCampaign, error) {
+	var campaigns []*entity.StampCampaign
+	query := conn(ctx, r.db).Order("created_at")
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+	if err := query.Find(&campaigns).Error; err != nil {
+		return nil, err
+	}
+	return campaigns, nil
+}
+
+// AddEntries записывает операции со штампами
+func (r *StampRepository) AddEntries(ctx context.Context, entries ...*entity.StampEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	for _, entry := range entries {
+		if entry.ID == uuid.Nil {
+			entry.ID = uuid.New()
+		}
+	}
+	return conn(ctx, r.db).Create(&entries).Error
+}
+
+// GetOrderEntries получает операции со штампами по заказу
+func (r *StampRepository) GetOrderEntries(ctx context.Context, orderID uuid.UUID) ([]*entity.StampEntry, error) {
+	var entries []*entity.StampEntry
+	if err := conn(ctx, r.db).
+		Where("order_id = ?", orderID).
+		Order("created_at").
+		Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// GetBalances считает штампы клиента по каждой кампании
+func (r *StampRepository) GetBalances(ctx context.Context, customerID uuid.UUID) (map[uuid.UUID]int, error) {
+	var rows []struct {
+		CampaignID uuid.UUID
+		Stamps     int
+	}
+	if err := conn(ctx, r.db).
+		Model(&entity.StampEntry{}).
+		Select("campaign_id, COALESCE(SUM(stamps), 0) AS stamps").
+		Where("customer_id = ?", customerID).
+		Group("campaign_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	balances := make(map[uuid.UUID]int, len(rows))
+	for _, row := range rows {
+		balances[row.CampaignID] = row.Stamps
+	}
+	return balances, nil
+}
+
+// GetProductCategories получает категории продуктов
+func (r *StampRepository) GetProductCategories(ctx context.Context, productIDs []uuid.UUID) (map[uuid.UUID]string, error) {
+	categories := make(map[uuid.UUID]string, len(productIDs))
+	if len(productIDs) == 0 {
+		return categories, nil
+	}
+
+	var products []*menuentity.Product
+	if err := conn(ctx, r.db).
+		Select("id, category").
+		Where("id IN ?", productIDs).
+		Find(&products).Error; err != nil {
+		return nil, err
+	}
+	for _, product := range products {
+		categories[product.ID] = product.Category
+	}
+	return categories, nil
+}
+
+// GetAvailableRewards получает доступные награды клиента
+func (r *StampRepository) GetAvailableRewards(ctx context.Context, customerID uuid.UUID) ([]*entity.StampReward, error) {
+	var rewards []*entity.StampReward
+	if err := conn(ctx, r.db).
+		Where("customer_id = ? AND status = ?", customerID, entity.RewardAvailable).
+		Order("created_at").
+		Find(&rewards).Error; err != nil {
+		return nil, err
+	}
+	return rewards, nil
+}
+
+// GetOrderRewards получает награды, полученные или использованные в заказе
+func (r *StampRepository) GetOrderRewards(ctx context.Context, orderID uuid.UUID) ([]*entity.StampReward, error) {
+	var rewards []*entity.StampReward
+	if err := conn(ctx, r.db).
+		Where("earned_order_id = ? OR redeemed_order_id = ?", orderID, orderID).
+		Order("created_at").
+		Find(&rewards).Error; err != nil {
+		return nil, err
+	}
+	return rewards, nil
+}
+
+// SaveRewards создает или обновляет награды
+func (r *StampRepository) SaveRewards(ctx context.Context, rewards ...*entity.StampReward) error {
+	if len(rewards) == 0 {
+		return nil
+	}
+	for _, reward := range rewards {
+		if reward.ID == uuid.Nil {
+			reward.ID = uuid.New()
+		}
+	}
+	return conn(ctx, r.db).Save(&rewards).Error
+}
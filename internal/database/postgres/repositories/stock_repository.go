@@ -0,0 +1,245 @@
+package repositories
+
+import (
+	"coffe/internal/inventory/entity"
+	menuentity "coffe/internal/menu/entity"
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// stockQuantityExpr вычисляет текущий остаток ингредиента по складскому журналу.
+const stockQuantityExpr = "COALESCE((SELECT SUM(stock_movements.quantity) FROM stock_movements WHERE stock_movements.ingredient_id = ingredients.id), 0)"
+
+// StockRepository реализует методы доступа к складскому журналу в базе данных.
+type StockRepository struct {
+	db *gorm.DB
+}
+
+// NewStockRepository создает новый экземпляр StockRepository.
+func NewStockRepository(db *gorm.DB) *StockRepository {
+	return &StockRepository{db: db}
+}
+
+// AddMovements записывает операции в журнал одной вставкой
+func (r *StockRepository) AddMovements(ctx context.Context, movements ...*entity.StockMovement) error {
+	if len(movements) == 0 {
+		return nil
+	}
+	for _, movement := range movements {
+		if movement.IngredientID == uuid.Nil {
+			return errors.New("ID ингредиента не может быть пустым")
+		}
+		if !movement.Type.IsValid() {
+			return errors.New("неизвестный тип складской операции")
+		}
+		if movement.ID == uuid.Nil {
+			movement.ID = uuid.New()
+		}
+	}
+	return conn(ctx, r.db).Create(&movements).Error
+}
+
+// GetMovementsByIngredient получает журнал ингредиента, начиная с последних операций
+func (r *StockRepository) GetMovementsByIngredient(ctx context.Context, ingredientID uuid.UUID) ([]*entity.StockMovement, error) {
+	var movements []*entity.StockMovement
+	if err := conn(ctx, r.db).
+		Where("ingredient_id = ?", ingredientID).
+		Order("created_at DESC").
+		Find(&movements).Error; err != nil {
+		return nil, err
+	}
+	return movements, nil
+}
+
+// GetMovementsByOrder получает операции, произведенные по заказу
+func (r *StockRepository) GetMovementsByOrder(ctx context.Context, orderID uuid.UUID) ([]*entity.StockMovement, error) {
+	var movements []*entity.StockMovement
+	if err := conn(ctx, r.db).
+		Where("order_id = ?", orderID).
+		Order("created_at").
+		Find(&movements).Error; err != nil {
+		return nil, err
+	}
+	return movements, nil
+}
+
+// GetStockLevels получает остатки всех ингредиентов
+func (r *StockRepository) GetStockLevels(ctx context.Context) ([]*entity.StockLevel, error) {
+	var levels []*entity.StockLevel
+	if err := r.stockLevels(ctx).Order("ingredients.name").Scan(&levels).Error; err != nil {
+		return nil, err
+	}
+	return levels, nil
+}
+
+// GetStockLevel получает остаток ингредиента
+func (r *StockRepository) GetStockLevel(ctx context.Context, ingredientID uuid.UUID) (*entity.StockLevel, error) {
+	var levels []*entity.StockLevel
+	if err := r.stockLevels(ctx).Where("ingredients.id = ?", ingredientID).Scan(&levels).Error; err != nil {
+		return nil, err
+	}
+	if len(levels) == 0 {
+		return nil, errors.New("ингредиент не найден")
+	}
+	return levels[0], nil
+}
+
+// LockStockLevels блокирует строки ингредиентов (в порядке ID, чтобы избежать взаимных
+// блокировок) и возвращает их остатки. Должен вызываться внутри транзакции.
+func (r *StockRepository) LockStockLevels(ctx context.Context, ingredientIDs []uuid.UUID) (map[uuid.UUID]*entity.StockLevel, error) {
+	if len(ingredientIDs) == 0 {
+		return map[uuid.UUID]*entity.StockLevel{}, nil
+	}
+
+	var locked []uuid.UUID
+	if err := conn(ctx, r.db).
+		Model(&menuentity.Ingredient{}).
+		Where("id IN ?", ingredientIDs).
+		Order("id").
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Pluck("id", &locked).Error; err != nil {
+		return nil, err
+	}
+
+	return r.GetStockLevelsByIDs(ctx, ingredientIDs)
+}
+
+// GetStockLevelsByIDs получает остатки выбранных ингредиентов
+func (r *StockRepository) GetStockLevelsByIDs(ctx context.Context, ingredientIDs []uuid.UUID) (map[uuid.UUID]*entity.StockLevel, error) {
+	levels := make(map[uuid.UUID]*entity.StockLevel, len(ingredientIDs))
+	if len(ingredientIDs) == 0 {
+		return levels, nil
+	}
+
+	var found []*entity.StockLevel
+	if err := r.stockLevels(ctx).Where("ingredients.id IN ?", ingredientIDs).Scan(&found).Error; err != nil {
+		return nil, err
+	}
+	for _, level := range found {
+		levels[level.IngredientID] = level
+	}
+	return levels, nil
+}
+
+// GetRecipes получает состав продуктов вместе с ингредиентами
+func (r *StockRepository) GetRecipes(ctx context.Context, productIDs []uuid.UUID) ([]*menuentity.ProductIngredient, error) {
+	var recipes []*menuentity.ProductIngredient
+	if len(productIDs) == 0 {
+		return recipes, nil
+	}
+	if err := conn(ctx, r.db).
+		Preload("Ingredient").
+		Where("product_id IN ?", productIDs).
+		Find(&recipes).Error; err != nil {
+		return nil, err
+	}
+	return recipes, nil
+}
+
+// GetVariants получает варианты продуктов вместе с явным составом
+func (r *StockRepository) GetVariants(ctx context.Context, variantIDs []uuid.UUID) ([]*menuentity.ProductVariant, error) {
+	var variants []*menuentity.ProductVariant
+	if len(variantIDs) == 0 {
+		return variants, nil
+	}
+	if err := conn(ctx, r.db).
+		Preload("Ingredients.Ingredient").
+		Where("id IN ?", variantIDs).
+		Find(&variants).Error; err != nil {
+		return nil, err
+	}
+	return variants, nil
+}
+
+// GetModifiers получает модификаторы вместе с добавляемыми ингредиентами
+func (r *StockRepository) GetModifiers(ctx context.Context, modifierIDs []uuid.UUID) ([]*menuentity.Modifier, error) {
+	var modifiers []*menuentity.Modifier
+	if len(modifierIDs) == 0 {
+		return modifiers, nil
+	}
+	if err := conn(ctx, r.db).
+		Preload("Ingredient").
+		Where("id IN ?", modifierIDs).
+		Find(&modifiers).Error; err != nil {
+		return nil, err
+	}
+	return modifiers, nil
+}
+
+// GetProductIDsByIngredients получает продукты, в состав которых входят ингредиенты:
+// через основной состав, явный состав вариантов или модификаторы
+func (r *StockRepository) GetProductIDsByIngredients(ctx context.Context, ingredientIDs []uuid.UUID) ([]uuid.UUID, error) {
+	if len(ingredientIDs) == 0 {
+		return []uuid.UUID{}, nil
+	}
+	db := conn(ctx, r.db)
+	queries := []*gorm.DB{
+		db.Model(&menuentity.ProductIngredient{}).
+			Where("ingredient_id IN ?", ingredientIDs),
+		db.Model(&menuentity.ProductVariant{}).
+			Joins("JOIN variant_ingredients ON variant_ingredients.variant_id = product_variants.id").
+			Where("variant_ingredients.ingredient_id IN ?", ingredientIDs),
+		db.Model(&menuentity.ModifierGroup{}).
+			Joins("JOIN modifiers ON modifiers.group_id = modifier_groups.id").
+			Where("modifiers.ingredient_id IN ? OR modifiers.replaces_ingredient_id IN ?", ingredientIDs, ingredientIDs),
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	productIDs := []uuid.UUID{}
+	for _, query := range queries {
+		var found []uuid.UUID
+		if err := query.Distinct("product_id").Pluck("product_id", &found).Error; err != nil {
+			return nil, err
+		}
+		for _, id := range found {
+			if !seen[id] {
+				seen[id] = true
+				productIDs = append(productIDs, id)
+			}
+		}
+	}
+	return productIDs, nil
+}
+
+// GetProductVariants получает активные варианты продуктов вместе с явным составом
+func (r *StockRepository) GetProductVariants(ctx context.Context, productIDs []uuid.UUID) ([]*menuentity.ProductVariant, error) {
+	var variants []*menuentity.ProductVariant
+	if len(productIDs) == 0 {
+		return variants, nil
+	}
+	if err := activeVariants(conn(ctx, r.db)).
+		Preload("Ingredients.Ingredient").
+		Where("product_id IN ?", productIDs).
+		Find(&variants).Error; err != nil {
+		return nil, err
+	}
+	return variants, nil
+}
+
+// GetModifierGroups получает группы модификаторов продуктов с активными модификаторами
+// и их ингредиентами
+func (r *StockRepository) GetModifierGroups(ctx context.Context, productIDs []uuid.UUID) ([]*menuentity.ModifierGroup, error) {
+	var groups []*menuentity.ModifierGroup
+	if len(productIDs) == 0 {
+		return groups, nil
+	}
+	if err := orderedGroups(conn(ctx, r.db)).
+		Preload("Modifiers", activeModifiers).
+		Preload("Modifiers.Ingredient").
+		Where("product_id IN ?", productIDs).
+		Find(&groups).Error; err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+// stockLevels строит запрос остатков по ингредиентам
+func (r *StockRepository) stockLevels(ctx context.Context) *gorm.DB {
+	return conn(ctx, r.db).
+		Model(&menuentity.Ingredient{}).
+		Select("ingredients.id AS ingredient_id, ingredients.name, ingredients.unit, ingredients.density, " + stockQuantityExpr + " AS quantity")
+}
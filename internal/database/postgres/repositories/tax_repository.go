@@ -0,0 +1,68 @@
+package repositories
+
+import (
+	"coffe/internal/menu/entity"
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TaxRepository реализует методы доступа к ставкам налога в базе данных.
+type TaxRepository struct {
+	db *gorm.DB
+}
+
+// NewTaxRepository создает новый экземпляр TaxRepository.
+func NewTaxRepository(db *gorm.DB) *TaxRepository {
+	return &TaxRepository{db: db}
+}
+
+// CreateRate создает ставку налога
+func (r *TaxRepository) CreateRate(ctx context.Context, rate *entity.TaxRate) error {
+	if rate.ID == uuid.Nil {
+		rate.ID = uuid.New()
+	}
+	return conn(ctx, r.db).Create(rate).Error
+}
+
+// UpdateRate обновляет ставку налога
+func (r *TaxRepository) UpdateRate(ctx context.Context, rate *entity.TaxRate) error {
+	return conn(ctx, r.db).Save(rate).Error
+}
+
+// DeleteRate удаляет ставку налога
+func (r *TaxRepository) DeleteRate(ctx context.Context, id uuid.UUID) error {
+	return conn(ctx, r.db).Delete(&entity.TaxRate{}, "id = ?", id).Error
+}
+
+// GetRateByID получает ставку налога по ID
+func (r *TaxRepository) GetRateByID(ctx context.Context, id uuid.UUID) (*entity.TaxRate, error) {
+	var rate entity.TaxRate
+	if err := conn(ctx, r.db).First(&rate, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &rate, nil
+}
+
+// GetRates получает все ставки налога
+func (r *TaxRepository) GetRates(ctx context.Context) ([]*entity.TaxRate, error) {
+	var rates []*entity.TaxRate
+	if err := conn(ctx, r.db).Order("category, created_at").Find(&rates).Error; err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
+
+// GetRatesForMenu получает общие ставки и ставки указанного меню
+func (r *TaxRepository) GetRatesForMenu(ctx context.Context, menuID *uuid.UUID) ([]*entity.TaxRate, error) {
+	var rates []*entity.TaxRate
+	query := conn(ctx, r.db).Where("menu_id IS NULL")
+	if menuID != nil {
+		query = conn(ctx, r.db).Where("menu_id IS NULL OR menu_id = ?", *menuID)
+	}
+	if err := query.Find(&rates).Error; err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
@@ -0,0 +1,36 @@
+package repositories
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// txKey - ключ контекста, под которым хранится текущая транзакция.
+type txKey struct{}
+
+// TransactionManager реализует repository.TransactionManager поверх GORM.
+type TransactionManager struct {
+	db *gorm.DB
+}
+
+// NewTransactionManager создает новый экземпляр TransactionManager.
+func NewTransactionManager(db *gorm.DB) *TransactionManager {
+	return &TransactionManager{db: db}
+}
+
+// WithinTransaction открывает транзакцию (или точку сохранения, если транзакция
+// уже начата) и передает ее репозиториям через контекст.
+func (m *TransactionManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return conn(ctx, m.db).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// conn возвращает транзакцию из контекста, а если ее нет - обычное соединение.
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
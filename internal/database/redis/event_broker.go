@@ -0,0 +1,57 @@
+package redis
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// eventsChannel - канал Redis, через который экземпляры сервера обмениваются событиями заказов.
+const eventsChannel = "order_events"
+
+// EventBroker рассылает события заказов между экземплярами сервера через Redis pub/sub.
+type EventBroker struct {
+	client *redis.Client
+}
+
+func NewEventBroker(client *redis.Client) *EventBroker {
+	return &EventBroker{client: client}
+}
+
+// Publish публикует событие в канал
+func (b *EventBroker) Publish(ctx context.Context, payload []byte) error {
+	return b.client.Publish(ctx, eventsChannel, payload).Err()
+}
+
+// Subscribe подписывается на канал событий. При обрыве соединения клиент Redis
+// переподключается сам, события за время обрыва теряются.
+func (b *EventBroker) Subscribe(ctx context.Context) (<-chan []byte, error) {
+	pubsub := b.client.Subscribe(ctx, eventsChannel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return nil, err
+	}
+
+	out := make(chan []byte)
+	go func() {
+		defer close(out)
+		defer pubsub.Close()
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case message, ok := <-messages:
+				if !ok {
+					return
+				}
+				select {
+				case out <- []byte(message.Payload):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
@@ -0,0 +1,41 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// orderNumberTTL - срок хранения счетчика от начала его дня: сутки плюс запас на заказы около полуночи.
+const orderNumberTTL = 48 * time.Hour
+
+// OrderNumberRepository выдает номера заказов за день счетчиком в Redis.
+type OrderNumberRepository struct {
+	client *redis.Client
+}
+
+func NewOrderNumberRepository(client *redis.Client) *OrderNumberRepository {
+	return &OrderNumberRepository{client: client}
+}
+
+// Next увеличивает счетчик дня командой INCR, поэтому номера уникальны
+// при любом числе экземпляров сервера. Счетчик будущего дня для предзаказов
+// хранится до конца этого дня.
+func (r *OrderNumberRepository) Next(ctx context.Context, day time.Time) (int, error) {
+	key := r.key(day)
+	var incr *redis.IntCmd
+	if _, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, key)
+		midnight := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+		pipe.ExpireAt(ctx, key, midnight.Add(orderNumberTTL))
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	return int(incr.Val()), nil
+}
+
+func (r *OrderNumberRepository) key(day time.Time) string {
+	return "order_number:" + day.Format("2006-01-02")
+}
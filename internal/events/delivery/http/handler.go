@@ -0,0 +1,112 @@
+package http
+
+import (
+	"coffe/internal/common"
+	"coffe/internal/events/entity"
+	"coffe/internal/events/usecase"
+	userentity "coffe/internal/user/entity"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+)
+
+// keepAliveInterval - период служебных сообщений, чтобы прокси не закрывали простаивающее соединение.
+const keepAliveInterval = 25 * time.Second
+
+type EventsHandler struct {
+	hub *usecase.Hub
+}
+
+func NewEventsHandler(hub *usecase.Hub) *EventsHandler {
+	return &EventsHandler{hub: hub}
+}
+
+// поток событий заказов по Server-Sent Events
+func (h *EventsHandler) StreamSSE(ctx *gin.Context) {
+	subscriber, ok := currentSubscriber(ctx)
+	if !ok {
+		return
+	}
+	subscription := h.hub.Subscribe(subscriber)
+	defer h.hub.Unsubscribe(subscription)
+
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no")
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+
+	ctx.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Request.Context().Done():
+			return false
+		case event, ok := <-subscription.Events():
+			if !ok {
+				return false
+			}
+			ctx.SSEvent(string(event.Type), event)
+			return true
+		case <-keepAlive.C:
+			_, err := io.WriteString(w, ": ping\n\n")
+			return err == nil
+		}
+	})
+}
+
+// поток событий заказов по WebSocket
+func (h *EventsHandler) StreamWebSocket(ctx *gin.Context) {
+	subscriber, ok := currentSubscriber(ctx)
+	if !ok {
+		return
+	}
+
+	server := websocket.Server{
+		// Доступ проверен по токену, поэтому соединения принимаются с любого источника
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(conn *websocket.Conn) {
+			subscription := h.hub.Subscribe(subscriber)
+			defer h.hub.Unsubscribe(subscription)
+
+			// клиент ничего не отправляет; чтение нужно, чтобы заметить закрытие соединения
+			closed := make(chan struct{})
+			go func() {
+				defer close(closed)
+				var message string
+				for websocket.Message.Receive(conn, &message) == nil {
+				}
+			}()
+
+			for {
+				select {
+				case <-closed:
+					return
+				case event, ok := <-subscription.Events():
+					if !ok || websocket.JSON.Send(conn, event) != nil {
+						return
+					}
+				}
+			}
+		},
+	}
+	server.ServeHTTP(ctx.Writer, ctx.Request)
+}
+
+// currentSubscriber определяет подписчика по пользователю из контекста запроса.
+func currentSubscriber(ctx *gin.Context) (entity.Subscriber, bool) {
+	userInterface, exists := ctx.Get("user")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Пользователь не авторизован"})
+		return entity.Subscriber{}, false
+	}
+	user, ok := userInterface.(*common.User)
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения пользователя"})
+		return entity.Subscriber{}, false
+	}
+	staff := user.Role != nil && (user.Role.Name == userentity.RoleAdmin || user.Role.Name == userentity.RoleManager)
+	return entity.Subscriber{UserID: user.ID, Staff: staff}, true
+}
@@ -0,0 +1,19 @@
+package http
+
+import (
+	"coffe/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupEventsRoutes настраивает потоки событий заказов. Браузерные EventSource и WebSocket
+// не умеют передавать заголовок авторизации, поэтому токен принимается и в параметре access_token.
+func SetupEventsRoutes(router *gin.RouterGroup, handler *EventsHandler, jwtMiddleware *middleware.JWTMiddleware) {
+	events := router.Group("/events")
+	events.Use(middleware.TokenFromQuery("access_token"))
+	events.Use(jwtMiddleware.Authenticate())
+	{
+		events.GET("/orders", handler.StreamSSE)
+		events.GET("/orders/ws", handler.StreamWebSocket)
+	}
+}
@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"coffe/internal/common"
+	orderentity "coffe/internal/order/entity"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventType определяет тип события жизненного цикла заказа.
+type EventType string
+
+const (
+	EventOrderCreated       EventType = "order.created"        // заказ оформлен
+	EventOrderStatusChanged EventType = "order.status_changed" // статус заказа изменен
+	EventOrderPaid          EventType = "order.paid"           // по заказу списан платеж
+	EventOrderCancelled     EventType = "order.cancelled"      // заказ отменен
+)
+
+// Event - событие заказа, которое получают подписчики в реальном времени.
+type Event struct {
+	ID             uuid.UUID               `json:"id"`
+	Type           EventType               `json:"type"`
+	OrderID        uuid.UUID               `json:"order_id"`
+	CustomerID     uuid.UUID               `json:"customer_id"`
+	Number         int                     `json:"number"` // номер заказа за день
+	Status         orderentity.OrderStatus `json:"status"`
+	PreviousStatus orderentity.OrderStatus `json:"previous_status,omitempty"`
+	Amount         *common.Money           `json:"amount,omitempty"` // сумма платежа для order.paid
+	OccurredAt     time.Time               `json:"occurred_at"`
+}
+
+// NewOrderEvent создает событие по текущему состоянию заказа.
+func NewOrderEvent(eventType EventType, order *orderentity.Order) *Event {
+	return &Event{
+		ID:         uuid.New(),
+		Type:       eventType,
+		OrderID:    order.Id,
+		CustomerID: order.CustomerID,
+		Number:     order.Number,
+		Status:     order.Status,
+		OccurredAt: time.Now(),
+	}
+}
+
+// Subscriber описывает получателя событий.
+type Subscriber struct {
+	UserID uuid.UUID
+	Staff  bool // персонал видит все заказы кофейни
+}
+
+// Allows сообщает, что подписчик может получить событие: клиент видит только
+// свои заказы, персонал - все.
+func (s Subscriber) Allows(event *Event) bool {
+	return s.Staff || event.CustomerID == s.UserID
+}
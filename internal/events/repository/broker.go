@@ -0,0 +1,12 @@
+package repository
+
+import "context"
+
+// Broker передает события между экземплярами сервера.
+type Broker interface {
+	Publish(ctx context.Context, payload []byte) error // отправка события всем экземплярам
+
+	// Subscribe возвращает канал событий от всех экземпляров, включая текущий.
+	// Канал закрывается после отмены ctx.
+	Subscribe(ctx context.Context) (<-chan []byte, error)
+}
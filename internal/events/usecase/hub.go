@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"coffe/internal/events/entity"
+	"coffe/internal/events/repository"
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+)
+
+// subscriptionBuffer - число событий, которые ждут отправки медленному подписчику.
+// Если подписчик не успевает их забирать, новые события для него пропускаются.
+const subscriptionBuffer = 32
+
+// Задержка перед повторной подпиской на брокер растет вдвое после каждой неудачи.
+const (
+	minReconnectDelay = time.Second
+	maxReconnectDelay = 30 * time.Second
+)
+
+// Subscription - подписка одного соединения SSE или WebSocket.
+type Subscription struct {
+	subscriber entity.Subscriber
+	events     chan *entity.Event
+}
+
+// Events возвращает канал событий подписки. Канал закрывается при отписке.
+func (s *Subscription) Events() <-chan *entity.Event {
+	return s.events
+}
+
+// Hub публикует события заказов через брокер и раздает полученные от брокера события
+// подписчикам этого экземпляра с учетом их прав.
+type Hub struct {
+	broker repository.Broker
+
+	mu            sync.RWMutex
+	subscriptions map[*Subscription]struct{}
+}
+
+// NewHub создает новый экземпляр Hub.
+func NewHub(broker repository.Broker) *Hub {
+	return &Hub{
+		broker:        broker,
+		subscriptions: make(map[*Subscription]struct{}),
+	}
+}
+
+// Publish отправляет событие всем экземплярам сервера. События доставляются
+// по возможности: ошибка брокера не должна отменять действие с заказом, поэтому
+// она только записывается в журнал.
+func (h *Hub) Publish(ctx context.Context, event *entity.Event) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("событие %s заказа %s: %v", event.Type, event.OrderID, err)
+		return
+	}
+	if err := h.broker.Publish(ctx, payload); err != nil {
+		log.Printf("публикация события %s заказа %s: %v", event.Type, event.OrderID, err)
+	}
+}
+
+// Subscribe регистрирует подписчика на события этого экземпляра.
+func (h *Hub) Subscribe(subscriber entity.Subscriber) *Subscription {
+	subscription := &Subscription{
+		subscriber: subscriber,
+		events:     make(chan *entity.Event, subscriptionBuffer),
+	}
+	h.mu.Lock()
+	h.subscriptions[subscription] = struct{}{}
+	h.mu.Unlock()
+	return subscription
+}
+
+// Unsubscribe удаляет подписку и закрывает ее канал.
+func (h *Hub) Unsubscribe(subscription *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.subscriptions[subscription]; ok {
+		delete(h.subscriptions, subscription)
+		close(subscription.events)
+	}
+}
+
+// Close закрывает все подписки, чтобы открытые потоки завершились при остановке сервера.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for subscription := range h.subscriptions {
+		delete(h.subscriptions, subscription)
+		close(subscription.events)
+	}
+}
+
+// Run получает события от брокера и раздает их подписчикам до отмены ctx.
+// Если подписаться не удалось или брокер закрыл подписку, Run подписывается заново
+// с растущей задержкой.
+func (h *Hub) Run(ctx context.Context) {
+	delay := minReconnectDelay
+	for {
+		messages, err := h.broker.Subscribe(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("подписка на события заказов: %v", err)
+			}
+		} else {
+			delay = minReconnectDelay
+			h.receive(messages)
+			if ctx.Err() == nil {
+				log.Printf("подписка на события заказов закрыта брокером")
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxReconnectDelay)
+	}
+}
+
+// receive раздает подписчикам события из messages, пока канал не закроется.
+func (h *Hub) receive(messages <-chan []byte) {
+	for payload := range messages {
+		var event entity.Event
+		if err := json.Unmarshal(payload, &event); err != nil {
+			log.Printf("некорректное событие заказа: %v", err)
+			continue
+		}
+		h.dispatch(&event)
+	}
+}
+
+// dispatch передает событие подписчикам, которым оно разрешено.
+func (h *Hub) dispatch(event *entity.Event) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for subscription := range h.subscriptions {
+		if !subscription.subscriber.Allows(event) {
+			continue
+		}
+		select {
+		case subscription.events <- event:
+		default:
+		}
+	}
+}
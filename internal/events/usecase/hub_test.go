@@ -0,0 +1,141 @@
+package usecase_test
+
+import (
+	"coffe/internal/events/entity"
+	"coffe/internal/events/usecase"
+	orderentity "coffe/internal/order/entity"
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// memoryBroker передает события внутри процесса вместо Redis.
+type memoryBroker struct {
+	messages chan []byte
+}
+
+func newMemoryBroker() *memoryBroker {
+	return &memoryBroker{messages: make(chan []byte, 16)}
+}
+
+func (b *memoryBroker) Publish(_ context.Context, payload []byte) error {
+	b.messages <- payload
+	return nil
+}
+
+func (b *memoryBroker) Subscribe(ctx context.Context) (<-chan []byte, error) {
+	out := make(chan []byte)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case payload := <-b.messages:
+				out <- payload
+			}
+		}
+	}()
+	return out, nil
+}
+
+// flakyBroker не дает подписаться с первой попытки, как недоступный Redis.
+type flakyBroker struct {
+	*memoryBroker
+	attempts atomic.Int32
+}
+
+func (b *flakyBroker) Subscribe(ctx context.Context) (<-chan []byte, error) {
+	if b.attempts.Add(1) == 1 {
+		return nil, errors.New("connection refused")
+	}
+	return b.memoryBroker.Subscribe(ctx)
+}
+
+func receive(t *testing.T, subscription *usecase.Subscription) *entity.Event {
+	t.Helper()
+	select {
+	case event := <-subscription.Events():
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("событие не доставлено")
+		return nil
+	}
+}
+
+func TestHub_FiltersByRole(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	hub := usecase.NewHub(newMemoryBroker())
+	go hub.Run(ctx)
+
+	customerID := uuid.New()
+	staff := hub.Subscribe(entity.Subscriber{UserID: uuid.New(), Staff: true})
+	owner := hub.Subscribe(entity.Subscriber{UserID: customerID})
+	stranger := hub.Subscribe(entity.Subscriber{UserID: uuid.New()})
+
+	order := &orderentity.Order{Id: uuid.New(), CustomerID: customerID, Number: 7, Status: orderentity.OrderStatusPending}
+	hub.Publish(ctx, entity.NewOrderEvent(entity.EventOrderCreated, order))
+
+	for _, subscription := range []*usecase.Subscription{staff, owner} {
+		event := receive(t, subscription)
+		if event.Type != entity.EventOrderCreated || event.OrderID != order.Id || event.Number != 7 {
+			t.Errorf("неверное событие: %+v", event)
+		}
+	}
+	select {
+	case event := <-stranger.Events():
+		t.Errorf("клиент получил событие чужого заказа: %+v", event)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestHub_Unsubscribe(t *testing.T) {
+	hub := usecase.NewHub(newMemoryBroker())
+	subscription := hub.Subscribe(entity.Subscriber{Staff: true})
+	hub.Unsubscribe(subscription)
+	hub.Unsubscribe(subscription)
+
+	if _, ok := <-subscription.Events(); ok {
+		t.Error("канал подписки должен закрываться при отписке")
+	}
+}
+
+func TestHub_Resubscribe(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	broker := &flakyBroker{memoryBroker: newMemoryBroker()}
+	hub := usecase.NewHub(broker)
+	done := make(chan struct{})
+	go func() {
+		hub.Run(ctx)
+		close(done)
+	}()
+
+	staff := hub.Subscribe(entity.Subscriber{UserID: uuid.New(), Staff: true})
+	order := &orderentity.Order{Id: uuid.New(), Status: orderentity.OrderStatusPending}
+	hub.Publish(ctx, entity.NewOrderEvent(entity.EventOrderCreated, order))
+
+	// после неудачной подписки Run подписывается снова и доставляет событие
+	select {
+	case event := <-staff.Events():
+		if event.OrderID != order.Id {
+			t.Errorf("неверное событие: %+v", event)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("событие не доставлено после повторной подписки")
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run не завершился после отмены контекста")
+	}
+}
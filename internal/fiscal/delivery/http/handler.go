@@ -0,0 +1,80 @@
+package http
+
+import (
+	"coffe/internal/fiscal/entity"
+	"coffe/internal/fiscal/usecase"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type FiscalHandler struct {
+	fiscalUsecase *usecase.FiscalUsecase
+}
+
+func NewFiscalHandler(fiscalUsecase *usecase.FiscalUsecase) *FiscalHandler {
+	return &FiscalHandler{fiscalUsecase: fiscalUsecase}
+}
+
+// очередь фискальных чеков (?status=ожидает|зарегистрирован|ошибка)
+func (h *FiscalHandler) GetOutbox(ctx *gin.Context) {
+	status := entity.OutboxStatus(ctx.Query("status"))
+
+	entries, err := h.fiscalUsecase.GetEntries(ctx.Request.Context(), status)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения очереди чеков"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"entries": entries,
+		"total":   len(entries),
+	})
+}
+
+// фискальный чек заказа вместе с документом для регистратора
+func (h *FiscalHandler) GetOrderFiscal(ctx *gin.Context) {
+	orderID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный ID заказа"})
+		return
+	}
+
+	entry, err := h.fiscalUsecase.GetByOrder(ctx.Request.Context(), orderID)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"entry":    entry,
+		"document": json.RawMessage(entry.Payload),
+	})
+}
+
+// повторная отправка чека, попытки которого исчерпаны
+func (h *FiscalHandler) RetryEntry(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный ID чека"})
+		return
+	}
+
+	entry, err := h.fiscalUsecase.Retry(ctx.Request.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrEntryNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, usecase.ErrNotFailed):
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка повторной отправки чека"})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entry)
+}
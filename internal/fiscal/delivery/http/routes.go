@@ -0,0 +1,21 @@
+package http
+
+import (
+	"coffe/internal/middleware"
+	userentity "coffe/internal/user/entity"
+	"coffe/internal/user/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupFiscalRoutes настраивает маршруты очереди фискальных чеков
+func SetupFiscalRoutes(router *gin.RouterGroup, handler *FiscalHandler, jwtMiddleware *middleware.JWTMiddleware, permissionUC usecase.PermissionUsecase) {
+	admin := router.Group("/admin/fiscal")
+	admin.Use(jwtMiddleware.Authenticate())
+	admin.Use(jwtMiddleware.RequireRole(userentity.RoleAdmin, userentity.RoleManager))
+	{
+		admin.GET("/outbox", middleware.PermissionMiddleware(permissionUC, "read_order"), handler.GetOutbox)
+		admin.POST("/outbox/:id/retry", middleware.PermissionMiddleware(permissionUC, "update_order"), handler.RetryEntry)
+		admin.GET("/orders/:id", middleware.PermissionMiddleware(permissionUC, "read_order"), handler.GetOrderFiscal)
+	}
+}
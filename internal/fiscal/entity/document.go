@@ -0,0 +1,250 @@
+package entity
+
+import (
+	"coffe/internal/common"
+	orderentity "coffe/internal/order/entity"
+	paymententity "coffe/internal/payment/entity"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ErrUnsupportedVAT возвращается, если ставку налога позиции нельзя передать в чек.
+var ErrUnsupportedVAT = errors.New("ставка НДС не поддерживается фискальным регистратором")
+
+// ErrEmptyReceipt возвращается, если в заказе нет позиций для чека.
+var ErrEmptyReceipt = errors.New("в чеке нет позиций")
+
+// TimestampLayout - формат времени документа в протоколе регистратора.
+const TimestampLayout = "02.01.2006 15:04:05"
+
+// maxItemName - максимальная длина наименования предмета расчета (тег 1030).
+const maxItemName = 128
+
+// Operation определяет тип фискальной операции.
+type Operation string
+
+const (
+	OperationSell Operation = "sell" // приход
+)
+
+// Типы оплаты по протоколу регистратора.
+const (
+	PaymentTypeCash       = 0 // наличные
+	PaymentTypeElectronic = 1 // безналичный расчет
+)
+
+// Company содержит реквизиты продавца для фискального чека.
+type Company struct {
+	Email          string `json:"email"`
+	SNO            string `json:"sno"` // система налогообложения: osn, usn_income, usn_income_outcome, esn, patent
+	INN            string `json:"inn"`
+	PaymentAddress string `json:"payment_address"` // место расчетов
+}
+
+// Document - команда регистрации чека в формате JSON протокола АТОЛ Онлайн.
+type Document struct {
+	ExternalID string  `json:"external_id"` // ключ идемпотентности, ID заказа
+	Receipt    Receipt `json:"receipt"`
+	Timestamp  string  `json:"timestamp"`
+}
+
+// Receipt - содержимое фискального чека.
+type Receipt struct {
+	Client   Client    `json:"client"`
+	Company  Company   `json:"company"`
+	Items    []Item    `json:"items"`
+	Payments []Payment `json:"payments"`
+	Vats     []Vat     `json:"vats"`
+	Total    Sum       `json:"total"`
+}
+
+// Client содержит контакты покупателя для отправки электронного чека.
+type Client struct {
+	Email string `json:"email,omitempty"`
+	Phone string `json:"phone,omitempty"`
+}
+
+// Item - предмет расчета.
+type Item struct {
+	Name            string  `json:"name"`
+	Price           Sum     `json:"price"` // цена единицы с учетом скидок
+	Quantity        float64 `json:"quantity"`
+	Sum             Sum     `json:"sum"`
+	MeasurementUnit string  `json:"measurement_unit"`
+	PaymentMethod   string  `json:"payment_method"` // признак способа расчета
+	PaymentObject   string  `json:"payment_object"` // признак предмета расчета
+	Vat             Vat     `json:"vat"`
+}
+
+// Payment - оплата чека одним способом.
+type Payment struct {
+	Type int `json:"type"`
+	Sum  Sum `json:"sum"`
+}
+
+// Vat - налог по ставке.
+type Vat struct {
+	Type string `json:"type"`
+	Sum  Sum    `json:"sum"`
+}
+
+// Sum - сумма в копейках, которая в документе записывается числом в рублях
+// с двумя знаками после запятой.
+type Sum int64
+
+// MarshalJSON записывает сумму числом в рублях без потери точности.
+func (s Sum) MarshalJSON() ([]byte, error) {
+	return []byte(common.Money{Amount: int64(s)}.String()), nil
+}
+
+// UnmarshalJSON читает сумму в рублях.
+func (s *Sum) UnmarshalJSON(data []byte) error {
+	var money common.Money
+	if err := money.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	*s = Sum(money.Amount)
+	return nil
+}
+
+// VatType возвращает код ставки НДС по протоколу регистратора. Ставка 0 означает
+// продажу без НДС.
+func VatType(rate float64) (string, error) {
+	switch rate {
+	case 0:
+		return "none", nil
+	case 5, 7, 10, 20, 22:
+		return fmt.Sprintf("vat%g", rate), nil
+	}
+	return "", fmt.Errorf("%w: %g%%", ErrUnsupportedVAT, rate)
+}
+
+// PaymentType возвращает тип оплаты по протоколу регистратора для способа оплаты.
+func PaymentType(method orderentity.PaymentMethod) int {
+	if method == orderentity.PaymentMethodCash {
+		return PaymentTypeCash
+	}
+	return PaymentTypeElectronic
+}
+
+// NewSellDocument формирует чек прихода по выполненному заказу. Оплата баллами
+// отражается в чеке как скидка и распределяется по позициям; чаевые в чек не входят.
+// Оплаты берутся из списанных платежей, а недостающая до итога сумма относится
+// на способ оплаты заказа.
+func NewSellDocument(order *orderentity.Order, payments []*paymententity.Payment, client Client, company Company, now time.Time) (*Document, error) {
+	if len(order.Items) == 0 {
+		return nil, ErrEmptyReceipt
+	}
+
+	weights := make([]int64, len(order.Items))
+	for i, item := range order.Items {
+		weights[i] = item.NetAmount.Add(item.TaxAmount).Amount
+	}
+	points := make([]common.Money, len(order.Items))
+	if shares := order.PaidWithPoints.AllocateBy(weights); shares != nil {
+		points = shares
+	}
+
+	receipt := Receipt{Client: client, Company: company}
+	vats := make(map[string]Sum)
+	for i, item := range order.Items {
+		vatType, err := VatType(item.TaxRate)
+		if err != nil {
+			return nil, err
+		}
+		sum := weights[i] - points[i].Amount
+		for _, line := range itemLines(itemName(item), sum, item.Quantity) {
+			line.Vat = Vat{Type: vatType, Sum: vatSum(line.Sum, item.TaxRate)}
+			vats[vatType] += line.Vat.Sum
+			receipt.Total += line.Sum
+			receipt.Items = append(receipt.Items, line)
+		}
+	}
+	for vatType, sum := range vats {
+		receipt.Vats = append(receipt.Vats, Vat{Type: vatType, Sum: sum})
+	}
+	sort.Slice(receipt.Vats, func(i, j int) bool { return receipt.Vats[i].Type < receipt.Vats[j].Type })
+
+	receipt.Payments = receiptPayments(order, payments, receipt.Total)
+	return &Document{
+		ExternalID: order.Id.String(),
+		Receipt:    receipt,
+		Timestamp:  now.Format(TimestampLayout),
+	}, nil
+}
+
+// itemLines формирует предметы расчета позиции. Если сумма со скидкой не делится
+// на количество без остатка, последняя единица выделяется в отдельную строку,
+// чтобы цена, умноженная на количество, совпадала с суммой.
+func itemLines(name string, sum int64, quantity int) []Item {
+	line := func(price int64, quantity int) Item {
+		return Item{
+			Name:            name,
+			Price:           Sum(price),
+			Quantity:        float64(quantity),
+			Sum:             Sum(price * int64(quantity)),
+			MeasurementUnit: "шт",
+			PaymentMethod:   "full_payment",
+			PaymentObject:   "commodity",
+		}
+	}
+
+	price, remainder := sum/int64(quantity), sum%int64(quantity)
+	if remainder == 0 {
+		return []Item{line(price, quantity)}
+	}
+	if quantity == 1 {
+		return []Item{line(sum, 1)}
+	}
+	return []Item{line(price, quantity-1), line(price+remainder, 1)}
+}
+
+// itemName возвращает наименование предмета расчета с модификаторами.
+func itemName(item orderentity.ItemsOrders) string {
+	name := item.DisplayName()
+	if len(item.Modifiers) > 0 {
+		modifiers := make([]string, len(item.Modifiers))
+		for i, modifier := range item.Modifiers {
+			modifiers[i] = modifier.Name
+		}
+		name += " + " + strings.Join(modifiers, ", ")
+	}
+	if utf8.RuneCountInString(name) > maxItemName {
+		name = string([]rune(name)[:maxItemName])
+	}
+	return name
+}
+
+// vatSum выделяет налог из суммы с налогом.
+func vatSum(sum Sum, rate float64) Sum {
+	return Sum(math.Round(float64(sum) * rate / (100 + rate)))
+}
+
+// receiptPayments распределяет итог чека по типам оплаты.
+func receiptPayments(order *orderentity.Order, payments []*paymententity.Payment, total Sum) []Payment {
+	byType := make(map[int]Sum)
+	remaining := total
+	for _, payment := range payments {
+		if !payment.IsCaptured() || remaining <= 0 {
+			continue
+		}
+		sum := min(Sum(payment.Amount.Amount), remaining)
+		byType[PaymentType(orderentity.PaymentMethod(payment.Method))] += sum
+		remaining -= sum
+	}
+	if remaining > 0 {
+		byType[PaymentType(order.PaymentMethod)] += remaining
+	}
+
+	result := make([]Payment, 0, len(byType))
+	for paymentType, sum := range byType {
+		result = append(result, Payment{Type: paymentType, Sum: sum})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Type < result[j].Type })
+	return result
+}
@@ -0,0 +1,127 @@
+package entity_test
+
+import (
+	"coffe/internal/common"
+	"coffe/internal/fiscal/entity"
+	orderentity "coffe/internal/order/entity"
+	paymententity "coffe/internal/payment/entity"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func completedOrder() *orderentity.Order {
+	return &orderentity.Order{
+		Id:            uuid.New(),
+		PaymentMethod: orderentity.PaymentMethodCard,
+		Items: []orderentity.ItemsOrders{
+			// 3 капучино по 100 ₽ со скидкой до 200 ₽: цена единицы не делится без остатка
+			{Name: "Капучино", Quantity: 3, Price: common.NewMoney(10000), TaxRate: 20,
+				NetAmount: common.NewMoney(16667), TaxAmount: common.NewMoney(3333)},
+			{Name: "Круассан", Quantity: 1, Price: common.NewMoney(10000), TaxRate: 10,
+				NetAmount: common.NewMoney(9091), TaxAmount: common.NewMoney(909)},
+		},
+		TotalPrice:     common.NewMoney(30000),
+		PaidWithPoints: common.NewMoney(3001),
+	}
+}
+
+func TestNewSellDocument(t *testing.T) {
+	order := completedOrder()
+	payments := []*paymententity.Payment{
+		{Method: string(orderentity.PaymentMethodCash), Amount: common.NewMoney(10000), Tip: common.NewMoney(500), Status: paymententity.PaymentCaptured},
+		{Method: string(orderentity.PaymentMethodCard), Amount: common.NewMoney(17000), Status: paymententity.PaymentCaptured},
+		{Method: string(orderentity.PaymentMethodCard), Amount: common.NewMoney(17000), Status: paymententity.PaymentFailed},
+	}
+
+	doc, err := entity.NewSellDocument(order, payments, entity.Client{Email: "guest@example.com"}, entity.Company{INN: "7701234567"}, time.Now())
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+
+	// баллы вычитаются из позиций: чек на сумму к оплате деньгами без чаевых
+	if doc.Receipt.Total != 26999 {
+		t.Errorf("ожидали итог 269.99, получили %d", doc.Receipt.Total)
+	}
+	var items, paid entity.Sum
+	for _, item := range doc.Receipt.Items {
+		if entity.Sum(float64(item.Price)*item.Quantity) != item.Sum {
+			t.Errorf("цена, умноженная на количество, не равна сумме: %+v", item)
+		}
+		items += item.Sum
+	}
+	for _, payment := range doc.Receipt.Payments {
+		paid += payment.Sum
+	}
+	if items != doc.Receipt.Total || paid != doc.Receipt.Total {
+		t.Errorf("сумма позиций %d и оплат %d должны совпадать с итогом %d", items, paid, doc.Receipt.Total)
+	}
+	if len(doc.Receipt.Items) != 3 {
+		t.Errorf("капучино должно разделиться на две строки, получили %d позиций", len(doc.Receipt.Items))
+	}
+	if len(doc.Receipt.Payments) != 2 || doc.Receipt.Payments[0].Type != entity.PaymentTypeCash || doc.Receipt.Payments[0].Sum != 10000 {
+		t.Errorf("неверные оплаты: %+v", doc.Receipt.Payments)
+	}
+	if len(doc.Receipt.Vats) != 2 || doc.Receipt.Vats[0].Type != "vat10" || doc.Receipt.Vats[1].Type != "vat20" {
+		t.Errorf("неверные налоги: %+v", doc.Receipt.Vats)
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if !strings.Contains(string(data), `"total":269.99`) {
+		t.Errorf("суммы должны записываться числом в рублях: %s", data)
+	}
+}
+
+func TestNewSellDocument_UnsupportedVAT(t *testing.T) {
+	order := completedOrder()
+	order.Items[0].TaxRate = 18
+
+	if _, err := entity.NewSellDocument(order, nil, entity.Client{}, entity.Company{}, time.Now()); !errors.Is(err, entity.ErrUnsupportedVAT) {
+		t.Errorf("ожидали ErrUnsupportedVAT, получили %v", err)
+	}
+}
+
+func TestOutboxEntry_Failed(t *testing.T) {
+	now := time.Now()
+	entry := &entity.OutboxEntry{Status: entity.OutboxPending}
+
+	entry.Failed(errors.New("регистратор недоступен"), now, 3)
+	if entry.Status != entity.OutboxPending || !entry.NextAttemptAt.Equal(now.Add(entity.RetryDelay(1))) {
+		t.Errorf("после первой ошибки чек должен ждать повтора: %+v", entry)
+	}
+	entry.Failed(errors.New("регистратор недоступен"), now, 3)
+	entry.Failed(errors.New("регистратор недоступен"), now, 3)
+	if entry.Status != entity.OutboxFailed || entry.Attempts != 3 {
+		t.Errorf("после исчерпания попыток ожидали статус ошибки: %+v", entry)
+	}
+
+	if entity.RetryDelay(2) != 2*entity.RetryDelay(1) || entity.RetryDelay(100) != time.Hour {
+		t.Error("задержка должна удваиваться и не превышать часа")
+	}
+}
+
+func TestDocument_RoundTrip(t *testing.T) {
+	doc, err := entity.NewSellDocument(completedOrder(), nil, entity.Client{}, entity.Company{}, time.Now())
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+
+	var decoded entity.Document
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("документ из очереди должен читаться обратно: %v", err)
+	}
+	if decoded.Receipt.Total != doc.Receipt.Total || decoded.Receipt.Items[0].Sum != doc.Receipt.Items[0].Sum {
+		t.Errorf("суммы изменились при чтении: %+v", decoded.Receipt)
+	}
+}
@@ -0,0 +1,81 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxStatus определяет статус отправки чека в фискальный регистратор.
+type OutboxStatus string
+
+const (
+	OutboxPending    OutboxStatus = "ожидает"         // ждет отправки или повторной попытки
+	OutboxRegistered OutboxStatus = "зарегистрирован" // чек зарегистрирован, реквизиты записаны в заказ
+	OutboxFailed     OutboxStatus = "ошибка"          // попытки исчерпаны, нужна повторная отправка вручную
+)
+
+// Интервалы между попытками отправки: удваиваются от минимального до максимального.
+const (
+	minRetryDelay = 30 * time.Second
+	maxRetryDelay = time.Hour
+)
+
+// OutboxEntry - чек, ожидающий регистрации. Запись создается в транзакции выполнения
+// заказа, поэтому чек не теряется при недоступности регистратора. Документ формируется
+// обработчиком очереди перед первой отправкой.
+type OutboxEntry struct {
+	ID            uuid.UUID    `json:"id" db:"id"`
+	OrderID       uuid.UUID    `json:"order_id" db:"order_id" gorm:"uniqueIndex"`
+	Operation     Operation    `json:"operation" db:"operation"`
+	Payload       string       `json:"payload" db:"payload"` // документ для регистратора, пусто - еще не сформирован
+	Status        OutboxStatus `json:"status" db:"status" gorm:"index"`
+	Attempts      int          `json:"attempts" db:"attempts"`
+	NextAttemptAt time.Time    `json:"next_attempt_at" db:"next_attempt_at" gorm:"index"`
+	LastError     string       `json:"last_error,omitempty" db:"last_error"`
+	CreatedAt     time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time    `json:"updated_at" db:"updated_at"`
+}
+
+// Registered отмечает успешную регистрацию чека.
+func (e *OutboxEntry) Registered() {
+	e.Attempts++
+	e.Status = OutboxRegistered
+	e.LastError = ""
+}
+
+// Failed записывает неудачную попытку и назначает следующую с экспоненциальной задержкой.
+// После maxAttempts попыток запись переходит в статус ошибки.
+func (e *OutboxEntry) Failed(err error, now time.Time, maxAttempts int) {
+	e.Attempts++
+	e.LastError = err.Error()
+	if e.Attempts >= maxAttempts {
+		e.Status = OutboxFailed
+		return
+	}
+	e.NextAttemptAt = now.Add(RetryDelay(e.Attempts))
+}
+
+// Rejected переводит запись в статус ошибки без повторных попыток: документ по заказу
+// сформировать нельзя, и повтор без исправления данных ничего не изменит.
+func (e *OutboxEntry) Rejected(err error) {
+	e.Attempts++
+	e.LastError = err.Error()
+	e.Status = OutboxFailed
+}
+
+// Retry возвращает запись в очередь для немедленной отправки.
+func (e *OutboxEntry) Retry(now time.Time) {
+	e.Status = OutboxPending
+	e.Attempts = 0
+	e.NextAttemptAt = now
+}
+
+// RetryDelay возвращает задержку перед попыткой после attempts неудачных.
+func RetryDelay(attempts int) time.Duration {
+	delay := minRetryDelay
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxRetryDelay)
+}
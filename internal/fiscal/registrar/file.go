@@ -0,0 +1,109 @@
+package registrar
+
+import (
+	"coffe/internal/fiscal/entity"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// FileName - название файлового регистратора.
+const FileName = "file"
+
+// fileDriveNumber - номер фискального накопителя, который сообщает файловый регистратор.
+const fileDriveNumber = "9999078900000001"
+
+// FileRegistrar - заглушка регистратора для локального запуска: записывает каждый
+// документ в отдельный JSON-файл в каталоге и выдает ему очередной номер.
+// Повторная регистрация того же документа возвращает прежние реквизиты.
+type FileRegistrar struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// fileRecord - содержимое файла документа.
+type fileRecord struct {
+	DocumentNumber int64            `json:"fiscal_document_number"`
+	Sign           string           `json:"fiscal_document_attribute"`
+	DriveNumber    string           `json:"fn_number"`
+	RegisteredAt   time.Time        `json:"registered_at"`
+	Document       *entity.Document `json:"document"`
+}
+
+// NewFileRegistrar создает файловый регистратор, пишущий документы в каталог dir.
+func NewFileRegistrar(dir string) *FileRegistrar {
+	return &FileRegistrar{dir: dir}
+}
+
+// Name возвращает название регистратора.
+func (r *FileRegistrar) Name() string {
+	return FileName
+}
+
+// Register записывает документ в файл <external_id>.json.
+func (r *FileRegistrar) Register(_ context.Context, doc *entity.Document) (*Result, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := os.MkdirAll(r.dir, 0o755); err != nil {
+		return nil, fmt.Errorf("каталог регистратора: %w", err)
+	}
+	path := filepath.Join(r.dir, doc.ExternalID+".json")
+	if data, err := os.ReadFile(path); err == nil {
+		var record fileRecord
+		if err := json.Unmarshal(data, &record); err == nil {
+			return record.result(), nil
+		}
+	}
+
+	number, err := r.nextNumber()
+	if err != nil {
+		return nil, err
+	}
+	record := fileRecord{
+		DocumentNumber: number,
+		Sign:           sign(doc, number),
+		DriveNumber:    fileDriveNumber,
+		RegisteredAt:   time.Now(),
+		Document:       doc,
+	}
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return nil, fmt.Errorf("запись документа: %w", err)
+	}
+	return record.result(), nil
+}
+
+// nextNumber возвращает номер следующего документа: число уже записанных плюс один.
+func (r *FileRegistrar) nextNumber() (int64, error) {
+	files, err := filepath.Glob(filepath.Join(r.dir, "*.json"))
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(files)) + 1, nil
+}
+
+func (rec *fileRecord) result() *Result {
+	return &Result{
+		DocumentNumber: rec.DocumentNumber,
+		Sign:           rec.Sign,
+		DriveNumber:    rec.DriveNumber,
+		RegisteredAt:   rec.RegisteredAt,
+	}
+}
+
+// sign вычисляет десятизначный фискальный признак документа.
+func sign(doc *entity.Document, number int64) string {
+	sum := sha256.Sum256([]byte(doc.ExternalID + strconv.FormatInt(number, 10)))
+	return strconv.FormatUint(binary.BigEndian.Uint64(sum[:8])%1e10, 10)
+}
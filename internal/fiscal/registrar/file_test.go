@@ -0,0 +1,37 @@
+package registrar_test
+
+import (
+	"coffe/internal/fiscal/entity"
+	"coffe/internal/fiscal/registrar"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileRegistrar_Register(t *testing.T) {
+	dir := t.TempDir()
+	file := registrar.NewFileRegistrar(dir)
+	ctx := context.Background()
+
+	first, err := file.Register(ctx, &entity.Document{ExternalID: "order-1"})
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	second, err := file.Register(ctx, &entity.Document{ExternalID: "order-2"})
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if first.DocumentNumber != 1 || second.DocumentNumber != 2 || first.Sign == "" {
+		t.Errorf("ожидали последовательные номера документов: %+v, %+v", first, second)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "order-1.json")); err != nil {
+		t.Errorf("документ должен быть записан в файл: %v", err)
+	}
+
+	// повторная отправка того же документа возвращает прежние реквизиты
+	again, err := file.Register(ctx, &entity.Document{ExternalID: "order-1"})
+	if err != nil || again.DocumentNumber != first.DocumentNumber || again.Sign != first.Sign {
+		t.Errorf("ожидали реквизиты первой регистрации, получили %+v, %v", again, err)
+	}
+}
@@ -0,0 +1,21 @@
+package registrar
+
+import (
+	"coffe/internal/fiscal/entity"
+	"context"
+	"time"
+)
+
+// Registrar определяет операции фискального регистратора или облачной кассы.
+type Registrar interface {
+	Name() string                                                        // название регистратора
+	Register(ctx context.Context, doc *entity.Document) (*Result, error) // зарегистрировать чек прихода
+}
+
+// Result содержит реквизиты зарегистрированного чека.
+type Result struct {
+	DocumentNumber int64  // номер фискального документа (ФД)
+	Sign           string // фискальный признак документа (ФП)
+	DriveNumber    string // номер фискального накопителя (ФН)
+	RegisteredAt   time.Time
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"coffe/internal/fiscal/entity"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FiscalRepository определяет методы для работы с очередью фискальных чеков.
+type FiscalRepository interface {
+	Create(ctx context.Context, entry *entity.OutboxEntry) error                                // постановка чека в очередь
+	Update(ctx context.Context, entry *entity.OutboxEntry) error                                // обновление записи
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.OutboxEntry, error)                     // поиск по id
+	GetByOrder(ctx context.Context, orderID uuid.UUID) (*entity.OutboxEntry, error)             // чек заказа
+	GetByStatus(ctx context.Context, status entity.OutboxStatus) ([]*entity.OutboxEntry, error) // записи по статусу, пустой - все
+
+	// LockNextDue блокирует до конца транзакции следующий чек, время отправки которого
+	// наступило, или nil, если таких нет. Записи, заблокированные другими обработчиками,
+	// пропускаются.
+	LockNextDue(ctx context.Context, now time.Time) (*entity.OutboxEntry, error)
+}
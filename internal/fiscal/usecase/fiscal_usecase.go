@@ -0,0 +1,237 @@
+package usecase
+
+import (
+	"coffe/internal/common"
+	commonrepository "coffe/internal/common/repository"
+	"coffe/internal/fiscal/entity"
+	"coffe/internal/fiscal/registrar"
+	"coffe/internal/fiscal/repository"
+	orderentity "coffe/internal/order/entity"
+	orderrepository "coffe/internal/order/repository"
+	paymententity "coffe/internal/payment/entity"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrEntryNotFound возвращается, если чек в очереди не найден.
+var ErrEntryNotFound = errors.New("фискальный чек не найден")
+
+// ErrNotFailed возвращается при повторной отправке чека, попытки которого не исчерпаны.
+var ErrNotFailed = errors.New("повторно отправить можно только чек с ошибкой")
+
+// batchSize - максимальное число чеков, отправляемых за один проход очереди.
+const batchSize = 100
+
+// Customers возвращает данные клиента для отправки электронного чека.
+type Customers interface {
+	GetUserByID(ctx context.Context, id uuid.UUID) (*common.User, error)
+}
+
+// PaymentSource возвращает платежи заказа.
+type PaymentSource interface {
+	GetByOrder(ctx context.Context, orderID uuid.UUID) ([]*paymententity.Payment, error)
+}
+
+// FiscalUsecase формирует фискальные чеки выполненных заказов и отправляет их
+// в регистратор через очередь с повторными попытками.
+type FiscalUsecase struct {
+	fiscalRepo  repository.FiscalRepository
+	orderRepo   orderrepository.OrderRepository
+	customers   Customers
+	payments    PaymentSource
+	txManager   commonrepository.TransactionManager
+	registrar   registrar.Registrar
+	company     entity.Company
+	maxAttempts int
+}
+
+// NewFiscalUsecase создает новый экземпляр FiscalUsecase.
+func NewFiscalUsecase(
+	fiscalRepo repository.FiscalRepository,
+	orderRepo orderrepository.OrderRepository,
+	customers Customers,
+	payments PaymentSource,
+	txManager commonrepository.TransactionManager,
+	registrar registrar.Registrar,
+	company entity.Company,
+	maxAttempts int,
+) *FiscalUsecase {
+	return &FiscalUsecase{
+		fiscalRepo:  fiscalRepo,
+		orderRepo:   orderRepo,
+		customers:   customers,
+		payments:    payments,
+		txManager:   txManager,
+		registrar:   registrar,
+		company:     company,
+		maxAttempts: maxAttempts,
+	}
+}
+
+// Enqueue ставит в очередь чек прихода по выполненному заказу. Вызывается в транзакции
+// выполнения заказа; повторный вызов для того же заказа ничего не делает. Документ
+// формируется обработчиком очереди, поэтому ошибка в данных чека не мешает выполнить заказ.
+func (u *FiscalUsecase) Enqueue(ctx context.Context, order *orderentity.Order) error {
+	if existing, err := u.fiscalRepo.GetByOrder(ctx, order.Id); err == nil && existing != nil {
+		return nil
+	}
+	return u.fiscalRepo.Create(ctx, &entity.OutboxEntry{
+		ID:            uuid.New(),
+		OrderID:       order.Id,
+		Operation:     entity.OperationSell,
+		Status:        entity.OutboxPending,
+		NextAttemptAt: time.Now(),
+	})
+}
+
+// ProcessDue отправляет в регистратор чеки, время отправки которых наступило,
+// и возвращает число попыток. Реквизиты зарегистрированного чека записываются в заказ,
+// при ошибке регистратора попытка откладывается. Чек, документ которого нельзя
+// сформировать, сразу получает статус ошибки.
+func (u *FiscalUsecase) ProcessDue(ctx context.Context) (int, error) {
+	processed := 0
+	for processed < batchSize {
+		found, err := u.processNext(ctx)
+		if err != nil || !found {
+			return processed, err
+		}
+		processed++
+	}
+	return processed, nil
+}
+
+// processNext отправляет один чек из очереди; found=false, если отправлять нечего.
+func (u *FiscalUsecase) processNext(ctx context.Context) (found bool, err error) {
+	err = u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		entry, err := u.fiscalRepo.LockNextDue(ctx, now)
+		if err != nil || entry == nil {
+			return err
+		}
+		found = true
+
+		if entry.Payload == "" {
+			payload, err := u.document(ctx, entry)
+			if err != nil {
+				if errors.Is(err, entity.ErrUnsupportedVAT) || errors.Is(err, entity.ErrEmptyReceipt) {
+					entry.Rejected(err)
+				} else {
+					entry.Failed(err, now, u.maxAttempts)
+				}
+				return u.fiscalRepo.Update(ctx, entry)
+			}
+			entry.Payload = payload
+		}
+
+		result, err := u.register(ctx, entry)
+		if err != nil {
+			entry.Failed(err, now, u.maxAttempts)
+			return u.fiscalRepo.Update(ctx, entry)
+		}
+
+		entry.Registered()
+		if err := u.fiscalRepo.Update(ctx, entry); err != nil {
+			return err
+		}
+		registeredAt := result.RegisteredAt
+		return u.orderRepo.SetFiscal(ctx, entry.OrderID, orderentity.FiscalData{
+			DocumentNumber: result.DocumentNumber,
+			Sign:           result.Sign,
+			DriveNumber:    result.DriveNumber,
+			RegisteredAt:   &registeredAt,
+		})
+	})
+	return found, err
+}
+
+// document формирует документ чека по заказу записи. Время документа - время
+// постановки в очередь, то есть выполнения заказа.
+func (u *FiscalUsecase) document(ctx context.Context, entry *entity.OutboxEntry) (string, error) {
+	order, err := u.orderRepo.GetByID(ctx, entry.OrderID)
+	if err != nil {
+		return "", fmt.Errorf("ошибка получения заказа: %w", err)
+	}
+	payments, err := u.payments.GetByOrder(ctx, order.Id)
+	if err != nil {
+		return "", err
+	}
+	var client entity.Client
+	if customer, err := u.customers.GetUserByID(ctx, order.CustomerID); err == nil {
+		client.Email = customer.Email
+	}
+	if client.Email == "" {
+		// без контактов покупателя электронный чек отправляется на почту продавца
+		client.Email = u.company.Email
+	}
+
+	doc, err := entity.NewSellDocument(order, payments, client, u.company, entry.CreatedAt)
+	if err != nil {
+		return "", fmt.Errorf("фискальный чек заказа %s: %w", order.Id, err)
+	}
+	payload, err := json.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+	return string(payload), nil
+}
+
+// register отправляет документ записи в регистратор.
+func (u *FiscalUsecase) register(ctx context.Context, entry *entity.OutboxEntry) (*registrar.Result, error) {
+	var doc entity.Document
+	if err := json.Unmarshal([]byte(entry.Payload), &doc); err != nil {
+		return nil, fmt.Errorf("некорректный документ: %w", err)
+	}
+	return u.registrar.Register(ctx, &doc)
+}
+
+// Run обрабатывает очередь каждые interval до отмены ctx.
+func (u *FiscalUsecase) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := u.ProcessDue(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("очередь фискальных чеков: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// GetEntries возвращает чеки очереди по статусу; пустой статус - все.
+func (u *FiscalUsecase) GetEntries(ctx context.Context, status entity.OutboxStatus) ([]*entity.OutboxEntry, error) {
+	return u.fiscalRepo.GetByStatus(ctx, status)
+}
+
+// GetByOrder возвращает фискальный чек заказа.
+func (u *FiscalUsecase) GetByOrder(ctx context.Context, orderID uuid.UUID) (*entity.OutboxEntry, error) {
+	entry, err := u.fiscalRepo.GetByOrder(ctx, orderID)
+	if err != nil {
+		return nil, ErrEntryNotFound
+	}
+	return entry, nil
+}
+
+// Retry возвращает в очередь чек, попытки отправки которого исчерпаны.
+func (u *FiscalUsecase) Retry(ctx context.Context, id uuid.UUID) (*entity.OutboxEntry, error) {
+	entry, err := u.fiscalRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, ErrEntryNotFound
+	}
+	if entry.Status != entity.OutboxFailed {
+		return nil, ErrNotFailed
+	}
+	entry.Retry(time.Now())
+	if err := u.fiscalRepo.Update(ctx, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
@@ -0,0 +1,192 @@
+package usecase_test
+
+import (
+	"coffe/internal/common"
+	"coffe/internal/common/repository/repositorytest"
+	"coffe/internal/fiscal/entity"
+	"coffe/internal/fiscal/registrar"
+	"coffe/internal/fiscal/usecase"
+	"coffe/internal/fiscal/usecase/mocks"
+	orderentity "coffe/internal/order/entity"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+)
+
+type fiscalMocks struct {
+	fiscalRepo *mocks.MockFiscalRepository
+	orderRepo  *mocks.MockOrderRepository
+	customers  *mocks.MockCustomers
+	payments   *mocks.MockPaymentSource
+	registrar  *mocks.MockRegistrar
+}
+
+func newFiscalUsecase(t *testing.T) (*usecase.FiscalUsecase, fiscalMocks) {
+	ctrl := gomock.NewController(t)
+	m := fiscalMocks{
+		fiscalRepo: mocks.NewMockFiscalRepository(ctrl),
+		orderRepo:  mocks.NewMockOrderRepository(ctrl),
+		customers:  mocks.NewMockCustomers(ctrl),
+		payments:   mocks.NewMockPaymentSource(ctrl),
+		registrar:  mocks.NewMockRegistrar(ctrl),
+	}
+	company := entity.Company{Email: "shop@example.com", SNO: "osn", INN: "7701234567"}
+	return usecase.NewFiscalUsecase(m.fiscalRepo, m.orderRepo, m.customers, m.payments, repositorytest.InlineTx{}, m.registrar, company, 3), m
+}
+
+func TestFiscalUsecase_Enqueue(t *testing.T) {
+	fiscal, m := newFiscalUsecase(t)
+	ctx := context.Background()
+	order := &orderentity.Order{Id: uuid.New()}
+
+	m.fiscalRepo.EXPECT().GetByOrder(ctx, order.Id).Return(nil, errors.New("не найден"))
+	m.fiscalRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, entry *entity.OutboxEntry) error {
+		if entry.Status != entity.OutboxPending || entry.OrderID != order.Id || entry.Payload != "" {
+			t.Errorf("неверная запись очереди: %+v", entry)
+		}
+		return nil
+	})
+
+	if err := fiscal.Enqueue(ctx, order); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+}
+
+func TestFiscalUsecase_ProcessDue_BuildsDocument(t *testing.T) {
+	fiscal, m := newFiscalUsecase(t)
+	ctx := context.Background()
+	order := &orderentity.Order{
+		Id:            uuid.New(),
+		CustomerID:    uuid.New(),
+		PaymentMethod: orderentity.PaymentMethodCash,
+		Items: []orderentity.ItemsOrders{{Name: "Латте", Quantity: 1, Price: common.NewMoney(20000),
+			TaxRate: 20, NetAmount: common.NewMoney(16667), TaxAmount: common.NewMoney(3333)}},
+		TotalPrice: common.NewMoney(20000),
+	}
+	entry := &entity.OutboxEntry{ID: uuid.New(), OrderID: order.Id, Status: entity.OutboxPending, CreatedAt: time.Now()}
+
+	gomock.InOrder(
+		m.fiscalRepo.EXPECT().LockNextDue(ctx, gomock.Any()).Return(entry, nil),
+		m.fiscalRepo.EXPECT().LockNextDue(ctx, gomock.Any()).Return(nil, nil),
+	)
+	m.orderRepo.EXPECT().GetByID(ctx, order.Id).Return(order, nil)
+	m.payments.EXPECT().GetByOrder(ctx, order.Id).Return(nil, nil)
+	m.customers.EXPECT().GetUserByID(ctx, order.CustomerID).Return(&common.User{Email: "guest@example.com"}, nil)
+	m.registrar.EXPECT().Register(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, doc *entity.Document) (*registrar.Result, error) {
+		if doc.ExternalID != order.Id.String() || doc.Receipt.Client.Email != "guest@example.com" {
+			t.Errorf("неверный документ: %+v", doc)
+		}
+		return &registrar.Result{DocumentNumber: 1}, nil
+	})
+	m.fiscalRepo.EXPECT().Update(ctx, entry).Return(nil)
+	m.orderRepo.EXPECT().SetFiscal(ctx, order.Id, gomock.Any()).Return(nil)
+
+	if _, err := fiscal.ProcessDue(ctx); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	var doc entity.Document
+	if err := json.Unmarshal([]byte(entry.Payload), &doc); err != nil || doc.ExternalID != order.Id.String() {
+		t.Errorf("документ должен сохраняться в записи очереди: %v", err)
+	}
+}
+
+func TestFiscalUsecase_ProcessDue_InvalidDocument(t *testing.T) {
+	fiscal, m := newFiscalUsecase(t)
+	ctx := context.Background()
+	order := &orderentity.Order{
+		Id:    uuid.New(),
+		Items: []orderentity.ItemsOrders{{Name: "Латте", Quantity: 1, Price: common.NewMoney(20000), TaxRate: 18}},
+	}
+	entry := &entity.OutboxEntry{ID: uuid.New(), OrderID: order.Id, Status: entity.OutboxPending}
+
+	gomock.InOrder(
+		m.fiscalRepo.EXPECT().LockNextDue(ctx, gomock.Any()).Return(entry, nil),
+		m.fiscalRepo.EXPECT().LockNextDue(ctx, gomock.Any()).Return(nil, nil),
+	)
+	m.orderRepo.EXPECT().GetByID(ctx, order.Id).Return(order, nil)
+	m.payments.EXPECT().GetByOrder(ctx, order.Id).Return(nil, nil)
+	m.customers.EXPECT().GetUserByID(ctx, order.CustomerID).Return(nil, errors.New("не найден"))
+	m.fiscalRepo.EXPECT().Update(ctx, entry).Return(nil)
+
+	if _, err := fiscal.ProcessDue(ctx); err != nil {
+		t.Fatalf("ошибка документа не должна прерывать обработку очереди: %v", err)
+	}
+	if entry.Status != entity.OutboxFailed || entry.LastError == "" {
+		t.Errorf("чек с некорректной ставкой должен сразу получить статус ошибки: %+v", entry)
+	}
+}
+
+func TestFiscalUsecase_ProcessDue(t *testing.T) {
+	fiscal, m := newFiscalUsecase(t)
+	ctx := context.Background()
+	orderID := uuid.New()
+	entry := &entity.OutboxEntry{ID: uuid.New(), OrderID: orderID, Payload: `{"external_id":"` + orderID.String() + `"}`, Status: entity.OutboxPending}
+
+	gomock.InOrder(
+		m.fiscalRepo.EXPECT().LockNextDue(ctx, gomock.Any()).Return(entry, nil),
+		m.fiscalRepo.EXPECT().LockNextDue(ctx, gomock.Any()).Return(nil, nil),
+	)
+	m.registrar.EXPECT().Register(ctx, gomock.Any()).Return(&registrar.Result{DocumentNumber: 42, Sign: "1234567890", DriveNumber: "9999"}, nil)
+	m.fiscalRepo.EXPECT().Update(ctx, entry).Return(nil)
+	m.orderRepo.EXPECT().SetFiscal(ctx, orderID, gomock.Any()).DoAndReturn(func(_ context.Context, _ uuid.UUID, data orderentity.FiscalData) error {
+		if data.DocumentNumber != 42 || data.Sign != "1234567890" || data.RegisteredAt == nil {
+			t.Errorf("в заказ должны записываться реквизиты чека: %+v", data)
+		}
+		return nil
+	})
+
+	processed, err := fiscal.ProcessDue(ctx)
+	if err != nil || processed != 1 {
+		t.Fatalf("ожидали одну отправку, получили %d, %v", processed, err)
+	}
+	if entry.Status != entity.OutboxRegistered {
+		t.Errorf("ожидали статус %q, получили %q", entity.OutboxRegistered, entry.Status)
+	}
+}
+
+func TestFiscalUsecase_ProcessDue_RegistrarError(t *testing.T) {
+	fiscal, m := newFiscalUsecase(t)
+	ctx := context.Background()
+	entry := &entity.OutboxEntry{ID: uuid.New(), OrderID: uuid.New(), Payload: `{}`, Status: entity.OutboxPending}
+
+	gomock.InOrder(
+		m.fiscalRepo.EXPECT().LockNextDue(ctx, gomock.Any()).Return(entry, nil),
+		m.fiscalRepo.EXPECT().LockNextDue(ctx, gomock.Any()).Return(nil, nil),
+	)
+	m.registrar.EXPECT().Register(ctx, gomock.Any()).Return(nil, errors.New("касса недоступна"))
+	m.fiscalRepo.EXPECT().Update(ctx, entry).Return(nil)
+
+	start := time.Now()
+	if _, err := fiscal.ProcessDue(ctx); err != nil {
+		t.Fatalf("ошибка регистратора не должна прерывать обработку очереди: %v", err)
+	}
+	if entry.Status != entity.OutboxPending || entry.Attempts != 1 || !entry.NextAttemptAt.After(start) || entry.LastError == "" {
+		t.Errorf("чек должен ждать повторной попытки: %+v", entry)
+	}
+}
+
+func TestFiscalUsecase_Retry(t *testing.T) {
+	fiscal, m := newFiscalUsecase(t)
+	ctx := context.Background()
+	pending := &entity.OutboxEntry{ID: uuid.New(), Status: entity.OutboxPending}
+	failed := &entity.OutboxEntry{ID: uuid.New(), Status: entity.OutboxFailed, Attempts: 3}
+
+	m.fiscalRepo.EXPECT().GetByID(ctx, pending.ID).Return(pending, nil)
+	if _, err := fiscal.Retry(ctx, pending.ID); !errors.Is(err, usecase.ErrNotFailed) {
+		t.Errorf("ожидали ErrNotFailed, получили %v", err)
+	}
+
+	m.fiscalRepo.EXPECT().GetByID(ctx, failed.ID).Return(failed, nil)
+	m.fiscalRepo.EXPECT().Update(ctx, failed).Return(nil)
+	if _, err := fiscal.Retry(ctx, failed.ID); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if failed.Status != entity.OutboxPending || failed.Attempts != 0 {
+		t.Errorf("чек должен вернуться в очередь: %+v", failed)
+	}
+}
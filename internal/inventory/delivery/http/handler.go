@@ -0,0 +1,227 @@
+package http
+
+import (
+	"coffe/internal/common"
+	"coffe/internal/inventory/entity"
+	"coffe/internal/inventory/usecase"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// MovementRequest содержит данные складской операции.
+type MovementRequest struct {
+	IngredientID uuid.UUID           `json:"ingredient_id" binding:"required"`
+	Type         entity.MovementType `json:"type" binding:"required"`
+	Quantity     float64             `json:"quantity" binding:"required"`
+	Unit         string              `json:"unit"` // необязательно, по умолчанию единица ингредиента
+	Comment      string              `json:"comment"`
+}
+
+// CostRequest содержит закупочную цену ингредиента.
+type CostRequest struct {
+	Cost      common.Money `json:"cost" binding:"required"`     // цена упаковки
+	Quantity  float64      `json:"quantity" binding:"required"` // количество в упаковке
+	Unit      string       `json:"unit"`                        // по умолчанию единица ингредиента
+	ValidFrom time.Time    `json:"valid_from"`                  // по умолчанию - сейчас
+}
+
+type InventoryHandler struct {
+	inventoryUsecase *usecase.InventoryUsecase
+	costingUsecase   *usecase.CostingUsecase
+}
+
+func NewInventoryHandler(inventoryUsecase *usecase.InventoryUsecase, costingUsecase *usecase.CostingUsecase) *InventoryHandler {
+	return &InventoryHandler{
+		inventoryUsecase: inventoryUsecase,
+		costingUsecase:   costingUsecase,
+	}
+}
+
+// текущие остатки всех ингредиентов
+func (h *InventoryHandler) GetStockLevels(ctx *gin.Context) {
+	levels, err := h.inventoryUsecase.GetStockLevels(ctx.Request.Context())
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения остатков"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"stock": levels,
+		"total": len(levels),
+	})
+}
+
+// журнал операций по ингредиенту
+func (h *InventoryHandler) GetMovements(ctx *gin.Context) {
+	ingredientID, ok := ingredientIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	movements, err := h.inventoryUsecase.GetMovements(ctx.Request.Context(), ingredientID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения журнала"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"ingredient_id": ingredientID,
+		"movements":     movements,
+	})
+}
+
+// запись прихода, списания или корректировки
+func (h *InventoryHandler) RecordMovement(ctx *gin.Context) {
+	var req MovementRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат данных", "details": err.Error()})
+		return
+	}
+
+	movementReq := usecase.MovementRequest{
+		IngredientID: req.IngredientID,
+		Type:         req.Type,
+		Quantity:     req.Quantity,
+		Unit:         req.Unit,
+		Comment:      req.Comment,
+	}
+	movementReq.CreatedBy = currentUserID(ctx)
+
+	movement, err := h.inventoryUsecase.RecordMovement(ctx.Request.Context(), movementReq)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidMovement) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка записи складской операции"})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"message": "Операция записана", "movement": movement})
+}
+
+// новая закупочная цена ингредиента
+func (h *InventoryHandler) SetCost(ctx *gin.Context) {
+	ingredientID, ok := ingredientIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	var req CostRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат данных", "details": err.Error()})
+		return
+	}
+
+	cost, err := h.costingUsecase.SetCost(ctx.Request.Context(), usecase.CostRequest{
+		IngredientID: ingredientID,
+		Cost:         req.Cost,
+		Quantity:     req.Quantity,
+		Unit:         req.Unit,
+		ValidFrom:    req.ValidFrom,
+		CreatedBy:    currentUserID(ctx),
+	})
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidCost) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка сохранения закупочной цены"})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"message": "Закупочная цена сохранена", "cost": cost})
+}
+
+// история закупочных цен ингредиента
+func (h *InventoryHandler) GetCostHistory(ctx *gin.Context) {
+	ingredientID, ok := ingredientIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	costs, err := h.costingUsecase.GetCostHistory(ctx.Request.Context(), ingredientID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения истории цен"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"ingredient_id": ingredientID,
+		"costs":         costs,
+	})
+}
+
+// себестоимость порции продукта
+func (h *InventoryHandler) GetProductCost(ctx *gin.Context) {
+	productID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID продукта"})
+		return
+	}
+
+	cost, err := h.costingUsecase.GetProductCost(ctx.Request.Context(), productID)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"cost": cost})
+}
+
+// отчет о марже продуктов; ?threshold= задает порог в процентах
+func (h *InventoryHandler) GetMarginReport(ctx *gin.Context) {
+	var threshold float64
+	if value := ctx.Query("threshold"); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil || parsed < 0 || parsed > 100 {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Порог маржи должен быть числом от 0 до 100"})
+			return
+		}
+		threshold = parsed
+	}
+
+	report, err := h.costingUsecase.MarginReport(ctx.Request.Context(), threshold)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка построения отчета"})
+		return
+	}
+
+	below := 0
+	for _, row := range report {
+		if row.BelowThreshold {
+			below++
+		}
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"products":        report,
+		"total":           len(report),
+		"below_threshold": below,
+	})
+}
+
+// ingredientIDParam разбирает ID ингредиента из URL параметра
+func ingredientIDParam(ctx *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID ингредиента"})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// currentUserID возвращает ID аутентифицированного сотрудника или uuid.Nil
+func currentUserID(ctx *gin.Context) uuid.UUID {
+	if user, ok := ctx.Get("user"); ok {
+		if u, ok := user.(*common.User); ok {
+			return u.ID
+		}
+	}
+	return uuid.Nil
+}
@@ -0,0 +1,44 @@
+package http
+
+import (
+	"coffe/internal/middleware"
+	userentity "coffe/internal/user/entity"
+	"coffe/internal/user/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupInventoryRoutes настраивает маршруты складского учета и отчетов по себестоимости
+func SetupInventoryRoutes(router *gin.RouterGroup, handler *InventoryHandler, jwtMiddleware *middleware.JWTMiddleware, permissionUC usecase.PermissionUsecase) {
+	// Маршруты склада
+	setupStockRoutes(router, handler, jwtMiddleware, permissionUC)
+
+	// Отчеты
+	setupReportRoutes(router, handler, jwtMiddleware, permissionUC)
+}
+
+// setupStockRoutes настраивает маршруты остатков, журнала и закупочных цен
+func setupStockRoutes(router *gin.RouterGroup, handler *InventoryHandler, jwtMiddleware *middleware.JWTMiddleware, permissionUC usecase.PermissionUsecase) {
+	inventory := router.Group("/admin/inventory")
+	inventory.Use(jwtMiddleware.Authenticate())
+	inventory.Use(jwtMiddleware.RequireRole(userentity.RoleAdmin, userentity.RoleManager))
+	{
+		inventory.GET("", middleware.PermissionMiddleware(permissionUC, "read_inventory"), handler.GetStockLevels)
+		inventory.GET("/ingredients/:id/movements", middleware.PermissionMiddleware(permissionUC, "read_inventory"), handler.GetMovements)
+		inventory.POST("/movements", middleware.PermissionMiddleware(permissionUC, "update_inventory"), handler.RecordMovement)
+
+		inventory.GET("/ingredients/:id/costs", middleware.PermissionMiddleware(permissionUC, "read_inventory"), handler.GetCostHistory)
+		inventory.POST("/ingredients/:id/costs", middleware.PermissionMiddleware(permissionUC, "update_inventory"), handler.SetCost)
+		inventory.GET("/products/:id/cost", middleware.PermissionMiddleware(permissionUC, "read_inventory"), handler.GetProductCost)
+	}
+}
+
+// setupReportRoutes настраивает админские отчеты по себестоимости
+func setupReportRoutes(router *gin.RouterGroup, handler *InventoryHandler, jwtMiddleware *middleware.JWTMiddleware, permissionUC usecase.PermissionUsecase) {
+	reports := router.Group("/admin/reports")
+	reports.Use(jwtMiddleware.Authenticate())
+	reports.Use(jwtMiddleware.RequireRole(userentity.RoleAdmin))
+	{
+		reports.GET("/margins", middleware.PermissionMiddleware(permissionUC, "read_report"), handler.GetMarginReport)
+	}
+}
@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"coffe/internal/common"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IngredientCost представляет закупочную цену ингредиента, действующую с ValidFrom.
+// Цена хранится за упаковку (например, 1500 ₽ за 1 кг), а стоимость единицы
+// ингредиента вычисляется при расчете себестоимости, чтобы не терять копейки
+// на дешевых единицах вроде грамма.
+type IngredientCost struct {
+	ID           uuid.UUID    `json:"id" db:"id"`
+	IngredientID uuid.UUID    `json:"ingredient_id" db:"ingredient_id" gorm:"index"`
+	Cost         common.Money `json:"cost" db:"cost"`             // цена упаковки
+	Quantity     float64      `json:"quantity" db:"quantity"`     // количество в упаковке
+	Unit         string       `json:"unit" db:"unit"`             // единица количества в упаковке
+	ValidFrom    time.Time    `json:"valid_from" db:"valid_from"` // с какого момента действует цена
+	CreatedBy    *uuid.UUID   `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt    time.Time    `json:"created_at" db:"created_at"`
+}
+
+// ProductCost представляет себестоимость одной порции продукта или его варианта.
+type ProductCost struct {
+	ProductID    uuid.UUID    `json:"product_id"`
+	VariantID    *uuid.UUID   `json:"variant_id,omitempty"` // вариант продукта, nil - продукт без вариантов
+	Name         string       `json:"name"`
+	VariantName  string       `json:"variant_name,omitempty"`
+	Cost         common.Money `json:"cost"`
+	MissingCosts []string     `json:"missing_costs,omitempty"` // ингредиенты без закупочной цены
+}
+
+// ProductMargin представляет строку отчета о марже продукта.
+type ProductMargin struct {
+	ProductCost
+	Price          common.Money `json:"price"`
+	Margin         common.Money `json:"margin"`          // валовая маржа: цена минус себестоимость
+	MarginPercent  float64      `json:"margin_percent"`  // маржа в процентах от цены
+	BelowThreshold bool         `json:"below_threshold"` // маржа ниже порога
+}
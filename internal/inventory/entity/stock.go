@@ -0,0 +1,91 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInsufficientStock является базовой ошибкой нехватки ингредиентов на складе.
+var ErrInsufficientStock = errors.New("недостаточно ингредиентов на складе")
+
+// MovementType определяет тип складской операции.
+type MovementType string
+
+const (
+	MovementReceipt     MovementType = "приход"        // поступление от поставщика
+	MovementConsumption MovementType = "расход"        // списание по заказу
+	MovementWaste       MovementType = "списание"      // порча, бой, истекший срок
+	MovementAdjustment  MovementType = "корректировка" // результат инвентаризации
+	MovementReturn      MovementType = "возврат"       // возврат ингредиентов по возвращенному заказу
+)
+
+// IsValid проверяет, что тип операции входит в список известных.
+func (t MovementType) IsValid() bool {
+	switch t {
+	case MovementReceipt, MovementConsumption, MovementWaste, MovementAdjustment, MovementReturn:
+		return true
+	}
+	return false
+}
+
+// StockMovement представляет запись складского журнала.
+// Текущий остаток ингредиента равен сумме Quantity всех его записей:
+// приход и возврат положительны, расход и списание отрицательны, корректировка - любого знака.
+type StockMovement struct {
+	ID           uuid.UUID    `json:"id" db:"id"`
+	IngredientID uuid.UUID    `json:"ingredient_id" db:"ingredient_id" gorm:"index"`
+	Type         MovementType `json:"type" db:"type"`
+	Quantity     float64      `json:"quantity" db:"quantity"`                        // в единицах ингредиента
+	OrderID      *uuid.UUID   `json:"order_id,omitempty" db:"order_id" gorm:"index"` // заказ, по которому произведен расход
+	CreatedBy    *uuid.UUID   `json:"created_by,omitempty" db:"created_by"`          // сотрудник, пусто для автоматических операций
+	Comment      string       `json:"comment" db:"comment"`
+	CreatedAt    time.Time    `json:"created_at" db:"created_at"`
+}
+
+// StockLevel представляет текущий остаток ингредиента.
+type StockLevel struct {
+	IngredientID uuid.UUID `json:"ingredient_id" db:"ingredient_id"`
+	Name         string    `json:"name" db:"name"`
+	Unit         string    `json:"unit" db:"unit"`
+	Density      float64   `json:"-" db:"density"` // плотность ингредиента в г/мл
+	Quantity     float64   `json:"quantity" db:"quantity"`
+}
+
+// OrderLine описывает продукт и его количество в заказе для расчета расхода.
+type OrderLine struct {
+	ProductID   uuid.UUID
+	VariantID   *uuid.UUID  // выбранный вариант, nil - состав продукта
+	ModifierIDs []uuid.UUID // модификаторы с заменой и добавкой ингредиентов
+	Quantity    int
+}
+
+// Shortage описывает нехватку одного ингредиента.
+type Shortage struct {
+	IngredientID uuid.UUID `json:"ingredient_id"`
+	Name         string    `json:"name"`
+	Unit         string    `json:"unit"`
+	Required     float64   `json:"required"`
+	Available    float64   `json:"available"`
+}
+
+// ShortageError содержит отчет о нехватке ингредиентов для заказа.
+type ShortageError struct {
+	Shortages []Shortage
+}
+
+func (e *ShortageError) Error() string {
+	names := make([]string, 0, len(e.Shortages))
+	for _, s := range e.Shortages {
+		names = append(names, fmt.Sprintf("%s (нужно %g %s, есть %g)", s.Name, s.Required, s.Unit, s.Available))
+	}
+	return fmt.Sprintf("%s: %s", ErrInsufficientStock, strings.Join(names, ", "))
+}
+
+// Unwrap позволяет проверять ошибку через errors.Is(err, ErrInsufficientStock).
+func (e *ShortageError) Unwrap() error {
+	return ErrInsufficientStock
+}
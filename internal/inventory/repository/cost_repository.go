@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"coffe/internal/inventory/entity"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CostRepository определяет методы для работы с закупочными ценами ингредиентов.
+type CostRepository interface {
+	AddCost(ctx context.Context, cost *entity.IngredientCost) error                               // новая закупочная цена
+	GetCostHistory(ctx context.Context, ingredientID uuid.UUID) ([]*entity.IngredientCost, error) // история цен ингредиента
+
+	// GetCurrentCosts возвращает цены, действующие на момент at, для каждого ингредиента, у которого они есть.
+	GetCurrentCosts(ctx context.Context, ingredientIDs []uuid.UUID, at time.Time) (map[uuid.UUID]*entity.IngredientCost, error)
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"coffe/internal/inventory/entity"
+	menuentity "coffe/internal/menu/entity"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// StockRepository определяет методы для работы со складским журналом.
+type StockRepository interface {
+	AddMovements(ctx context.Context, movements ...*entity.StockMovement) error                                   // запись операций в журнал
+	GetMovementsByIngredient(ctx context.Context, ingredientID uuid.UUID) ([]*entity.StockMovement, error)        // журнал ингредиента
+	GetMovementsByOrder(ctx context.Context, orderID uuid.UUID) ([]*entity.StockMovement, error)                  // операции по заказу
+	GetStockLevels(ctx context.Context) ([]*entity.StockLevel, error)                                             // остатки всех ингредиентов
+	GetStockLevel(ctx context.Context, ingredientID uuid.UUID) (*entity.StockLevel, error)                        // остаток ингредиента
+	GetStockLevelsByIDs(ctx context.Context, ingredientIDs []uuid.UUID) (map[uuid.UUID]*entity.StockLevel, error) // остатки выбранных ингредиентов
+
+	// LockStockLevels блокирует ингредиенты до конца текущей транзакции и возвращает их остатки,
+	// чтобы параллельные заказы не списали один и тот же остаток.
+	LockStockLevels(ctx context.Context, ingredientIDs []uuid.UUID) (map[uuid.UUID]*entity.StockLevel, error)
+
+	// GetRecipes возвращает состав продуктов с заполненным ингредиентом.
+	GetRecipes(ctx context.Context, productIDs []uuid.UUID) ([]*menuentity.ProductIngredient, error)
+
+	// GetVariants возвращает варианты продуктов с заполненным явным составом.
+	GetVariants(ctx context.Context, variantIDs []uuid.UUID) ([]*menuentity.ProductVariant, error)
+
+	// GetModifiers возвращает модификаторы с добавляемыми ингредиентами.
+	GetModifiers(ctx context.Context, modifierIDs []uuid.UUID) ([]*menuentity.Modifier, error)
+
+	// GetProductVariants возвращает активные варианты продуктов с явным составом.
+	GetProductVariants(ctx context.Context, productIDs []uuid.UUID) ([]*menuentity.ProductVariant, error)
+
+	// GetModifierGroups возвращает группы модификаторов продуктов с активными модификаторами и их ингредиентами.
+	GetModifierGroups(ctx context.Context, productIDs []uuid.UUID) ([]*menuentity.ModifierGroup, error)
+
+	// GetProductIDsByIngredients возвращает продукты, в основной состав, состав вариантов или модификаторы
+	// которых входит хотя бы один из ингредиентов.
+	GetProductIDsByIngredients(ctx context.Context, ingredientIDs []uuid.UUID) ([]uuid.UUID, error)
+}
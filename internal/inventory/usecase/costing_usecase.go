@@ -0,0 +1,254 @@
+package usecase
+
+import (
+	"coffe/internal/common"
+	"coffe/internal/inventory/entity"
+	"coffe/internal/inventory/repository"
+	menuentity "coffe/internal/menu/entity"
+	menurepository "coffe/internal/menu/repository"
+	"coffe/internal/units"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidCost возвращается при некорректных данных закупочной цены.
+var ErrInvalidCost = errors.New("некорректная закупочная цена")
+
+// CostRequest содержит данные новой закупочной цены ингредиента.
+type CostRequest struct {
+	IngredientID uuid.UUID
+	Cost         common.Money // цена упаковки
+	Quantity     float64      // количество в упаковке
+	Unit         string       // единица количества; пусто - единица ингредиента
+	ValidFrom    time.Time    // пусто - с текущего момента
+	CreatedBy    uuid.UUID
+}
+
+// CostingUsecase рассчитывает себестоимость продуктов по составу и закупочным ценам.
+type CostingUsecase struct {
+	costRepo        repository.CostRepository
+	stockRepo       repository.StockRepository
+	productRepo     menurepository.ProductRepository
+	marginThreshold float64 // порог маржи в процентах по умолчанию
+}
+
+// NewCostingUsecase создает новый экземпляр CostingUsecase.
+func NewCostingUsecase(
+	costRepo repository.CostRepository,
+	stockRepo repository.StockRepository,
+	productRepo menurepository.ProductRepository,
+	marginThreshold float64,
+) *CostingUsecase {
+	return &CostingUsecase{
+		costRepo:        costRepo,
+		stockRepo:       stockRepo,
+		productRepo:     productRepo,
+		marginThreshold: marginThreshold,
+	}
+}
+
+// SetCost записывает новую закупочную цену. Предыдущие цены остаются в истории.
+func (u *CostingUsecase) SetCost(ctx context.Context, req CostRequest) (*entity.IngredientCost, error) {
+	if req.IngredientID == uuid.Nil {
+		return nil, fmt.Errorf("%w: ID ингредиента не может быть пустым", ErrInvalidCost)
+	}
+	if !req.Cost.IsPositive() {
+		return nil, fmt.Errorf("%w: цена должна быть больше нуля", ErrInvalidCost)
+	}
+	if req.Quantity <= 0 {
+		return nil, fmt.Errorf("%w: количество в упаковке должно быть больше нуля", ErrInvalidCost)
+	}
+
+	level, err := u.stockRepo.GetStockLevel(ctx, req.IngredientID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: ингредиент не найден", ErrInvalidCost)
+	}
+	unit := req.Unit
+	if unit == "" {
+		unit = level.Unit
+	}
+	if err := units.Compatible(unit, level.Unit, level.Density); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCost, err)
+	}
+	if unit, err = units.Normalize(unit); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCost, err)
+	}
+
+	cost := &entity.IngredientCost{
+		ID:           uuid.New(),
+		IngredientID: req.IngredientID,
+		Cost:         req.Cost,
+		Quantity:     req.Quantity,
+		Unit:         unit,
+		ValidFrom:    req.ValidFrom,
+	}
+	if cost.ValidFrom.IsZero() {
+		cost.ValidFrom = time.Now()
+	}
+	if req.CreatedBy != uuid.Nil {
+		cost.CreatedBy = &req.CreatedBy
+	}
+
+	if err := u.costRepo.AddCost(ctx, cost); err != nil {
+		return nil, err
+	}
+	return cost, nil
+}
+
+// GetCostHistory возвращает историю закупочных цен ингредиента.
+func (u *CostingUsecase) GetCostHistory(ctx context.Context, ingredientID uuid.UUID) ([]*entity.IngredientCost, error) {
+	if ingredientID == uuid.Nil {
+		return nil, errors.New("ID ингредиента не может быть пустым")
+	}
+	return u.costRepo.GetCostHistory(ctx, ingredientID)
+}
+
+// GetProductCost рассчитывает себестоимость одной порции продукта по текущим ценам.
+// Для продукта с вариантами считается вариант по умолчанию или первый активный.
+func (u *CostingUsecase) GetProductCost(ctx context.Context, productID uuid.UUID) (*entity.ProductCost, error) {
+	product, err := u.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, errors.New("продукт не найден")
+	}
+
+	rows, err := u.productCosts(ctx, []*menuentity.Product{product})
+	if err != nil {
+		return nil, err
+	}
+	if variant := product.DefaultVariant(); variant != nil {
+		for _, row := range rows {
+			if row.VariantID != nil && *row.VariantID == variant.ID {
+				return &row.ProductCost, nil
+			}
+		}
+	}
+	return &rows[0].ProductCost, nil
+}
+
+// MarginReport строит отчет о марже всех продуктов, начиная с наименее маржинальных.
+// Продукт с вариантами представлен строкой на каждый активный вариант со своей ценой
+// и составом. threshold - порог маржи в процентах; 0 - порог из настроек.
+func (u *CostingUsecase) MarginReport(ctx context.Context, threshold float64) ([]*entity.ProductMargin, error) {
+	if threshold <= 0 {
+		threshold = u.marginThreshold
+	}
+
+	products, err := u.productRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	report, err := u.productCosts(ctx, products)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range report {
+		row.Margin = row.Price.Sub(row.Cost)
+		if row.Price.IsPositive() {
+			row.MarginPercent = math.Round(float64(row.Margin.Amount)/float64(row.Price.Amount)*10000) / 100
+		}
+		row.BelowThreshold = row.MarginPercent < threshold
+	}
+
+	sort.SliceStable(report, func(i, j int) bool { return report[i].MarginPercent < report[j].MarginPercent })
+	return report, nil
+}
+
+// productCosts рассчитывает себестоимость и цену порции продуктов: по строке на продукт
+// без вариантов и на каждый активный вариант. Стоимость единицы ингредиента считается
+// в дробных копейках, округление выполняется один раз для всей порции.
+func (u *CostingUsecase) productCosts(ctx context.Context, products []*menuentity.Product) ([]*entity.ProductMargin, error) {
+	productIDs := make([]uuid.UUID, 0, len(products))
+	for _, product := range products {
+		productIDs = append(productIDs, product.ID)
+	}
+
+	recipes, err := u.stockRepo.GetRecipes(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	variants, err := u.stockRepo.GetProductVariants(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	ingredientIDs := make([]uuid.UUID, 0, len(recipes))
+	byProduct := make(map[uuid.UUID][]*menuentity.ProductIngredient, len(products))
+	for _, recipe := range recipes {
+		ingredientIDs = append(ingredientIDs, recipe.IngredientID)
+		byProduct[recipe.ProductID] = append(byProduct[recipe.ProductID], recipe)
+	}
+	variantsByProduct := make(map[uuid.UUID][]*menuentity.ProductVariant)
+	for _, variant := range variants {
+		for _, ingredient := range variant.Ingredients {
+			ingredientIDs = append(ingredientIDs, ingredient.IngredientID)
+		}
+		variantsByProduct[variant.ProductID] = append(variantsByProduct[variant.ProductID], variant)
+	}
+	prices, err := u.costRepo.GetCurrentCosts(ctx, ingredientIDs, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]*entity.ProductMargin, 0, len(products))
+	for _, product := range products {
+		productVariants := variantsByProduct[product.ID]
+		if len(productVariants) == 0 {
+			row := &entity.ProductMargin{
+				ProductCost: entity.ProductCost{ProductID: product.ID, Name: product.Name},
+				Price:       product.Price,
+			}
+			if err := recipeCost(&row.ProductCost, byProduct[product.ID], prices); err != nil {
+				return nil, err
+			}
+			rows = append(rows, row)
+			continue
+		}
+		for _, variant := range productVariants {
+			variantID := variant.ID
+			row := &entity.ProductMargin{
+				ProductCost: entity.ProductCost{ProductID: product.ID, VariantID: &variantID, Name: product.Name, VariantName: variant.Name},
+				Price:       variant.PriceFor(product.Price),
+			}
+			if err := recipeCost(&row.ProductCost, variant.Recipe(byProduct[product.ID]), prices); err != nil {
+				return nil, err
+			}
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+// recipeCost записывает в row себестоимость порции с составом recipe по закупочным ценам prices.
+func recipeCost(row *entity.ProductCost, recipe []*menuentity.ProductIngredient, prices map[uuid.UUID]*entity.IngredientCost) error {
+	amount := 0.0
+	for _, ingredient := range recipe {
+		price, ok := prices[ingredient.IngredientID]
+		if !ok || ingredient.Ingredient == nil {
+			name := ingredient.IngredientID.String()
+			if ingredient.Ingredient != nil {
+				name = ingredient.Ingredient.Name
+			}
+			row.MissingCosts = append(row.MissingCosts, name)
+			continue
+		}
+
+		perUnit, err := recipeQuantity(ingredient)
+		if err != nil {
+			return err
+		}
+		pack, err := units.Convert(price.Quantity, price.Unit, ingredient.Ingredient.Unit, ingredient.Ingredient.Density)
+		if err != nil {
+			return fmt.Errorf("закупочная цена, ингредиент %s: %w", ingredient.Ingredient.Name, err)
+		}
+		amount += perUnit * float64(price.Cost.Amount) / pack
+	}
+	row.Cost = common.NewMoney(int64(math.Round(amount)))
+	return nil
+}
@@ -0,0 +1,107 @@
+package usecase_test
+
+import (
+	"coffe/internal/common"
+	"coffe/internal/inventory/entity"
+	"coffe/internal/inventory/usecase"
+	"coffe/internal/inventory/usecase/mocks"
+	menuentity "coffe/internal/menu/entity"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCostingUsecase_MarginReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockCostRepo := mocks.NewMockCostRepository(ctrl)
+	mockStockRepo := mocks.NewMockStockRepository(ctrl)
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	costing := usecase.NewCostingUsecase(mockCostRepo, mockStockRepo, mockProductRepo, 60)
+
+	ctx := context.Background()
+	latte := &menuentity.Product{ID: uuid.New(), Name: "Латте", Price: common.NewMoney(25000)}
+	tea := &menuentity.Product{ID: uuid.New(), Name: "Чай", Price: common.NewMoney(15000)}
+	milk := &menuentity.Ingredient{ID: uuid.New(), Name: "Молоко", Unit: "ml"}
+	beans := &menuentity.Ingredient{ID: uuid.New(), Name: "Зерно", Unit: "g"}
+	leaves := &menuentity.Ingredient{ID: uuid.New(), Name: "Чай листовой", Unit: "g"}
+
+	mockProductRepo.EXPECT().GetAll(ctx).Return([]*menuentity.Product{latte, tea}, nil)
+	mockStockRepo.EXPECT().GetRecipes(ctx, gomock.Any()).Return([]*menuentity.ProductIngredient{
+		{ProductID: latte.ID, IngredientID: milk.ID, Quantity: 200, Unit: "ml", Ingredient: milk},
+		{ProductID: latte.ID, IngredientID: beans.ID, Quantity: 18, Unit: "g", Ingredient: beans},
+		{ProductID: tea.ID, IngredientID: leaves.ID, Quantity: 5, Unit: "g", Ingredient: leaves},
+	}, nil)
+	mockStockRepo.EXPECT().GetProductVariants(ctx, gomock.Any()).Return(nil, nil)
+	mockCostRepo.EXPECT().GetCurrentCosts(ctx, gomock.Any(), gomock.Any()).Return(map[uuid.UUID]*entity.IngredientCost{
+		milk.ID:  {IngredientID: milk.ID, Cost: common.NewMoney(9000), Quantity: 1, Unit: "l"},     // 90 ₽ за литр
+		beans.ID: {IngredientID: beans.ID, Cost: common.NewMoney(200000), Quantity: 1, Unit: "kg"}, // 2000 ₽ за кг
+	}, nil)
+
+	report, err := costing.MarginReport(ctx, 0)
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if len(report) != 2 {
+		t.Fatalf("ожидали 2 строки отчета, получили %d", len(report))
+	}
+
+	// латте: 200 мл × 9 коп + 18 г × 200 коп = 1800 + 3600 = 5400 коп, маржа 78.4%
+	var latteRow *entity.ProductMargin
+	for _, row := range report {
+		if row.ProductID == latte.ID {
+			latteRow = row
+		}
+	}
+	if latteRow.Cost.Amount != 5400 || latteRow.Margin.Amount != 19600 || latteRow.MarginPercent != 78.4 || latteRow.BelowThreshold {
+		t.Errorf("неверная строка латте: %+v", latteRow)
+	}
+
+	// у чая нет закупочной цены: себестоимость неполная, маржа 100%, поэтому он в конце отчета
+	teaRow := report[1]
+	if teaRow.ProductID != tea.ID || len(teaRow.MissingCosts) != 1 || teaRow.Cost.Amount != 0 {
+		t.Errorf("неверная строка чая: %+v", teaRow)
+	}
+}
+
+func TestCostingUsecase_MarginReport_Variants(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockCostRepo := mocks.NewMockCostRepository(ctrl)
+	mockStockRepo := mocks.NewMockStockRepository(ctrl)
+	mockProductRepo := mocks.NewMockProductRepository(ctrl)
+	costing := usecase.NewCostingUsecase(mockCostRepo, mockStockRepo, mockProductRepo, 60)
+
+	ctx := context.Background()
+	tea := &menuentity.Product{ID: uuid.New(), Name: "Чай", Price: common.NewMoney(15000)}
+	leaves := &menuentity.Ingredient{ID: uuid.New(), Name: "Чай листовой", Unit: "g"}
+	small := &menuentity.ProductVariant{ID: uuid.New(), ProductID: tea.ID, Name: "0.3",
+		Ingredients: []*menuentity.VariantIngredient{{IngredientID: leaves.ID, Quantity: 5, Unit: "g", Ingredient: leaves}}}
+	large := &menuentity.ProductVariant{ID: uuid.New(), ProductID: tea.ID, Name: "0.5", PriceDelta: common.NewMoney(5000),
+		Ingredients: []*menuentity.VariantIngredient{{IngredientID: leaves.ID, Quantity: 10, Unit: "g", Ingredient: leaves}}}
+
+	// состав чая задан только в вариантах
+	mockProductRepo.EXPECT().GetAll(ctx).Return([]*menuentity.Product{tea}, nil)
+	mockStockRepo.EXPECT().GetRecipes(ctx, gomock.Any()).Return(nil, nil)
+	mockStockRepo.EXPECT().GetProductVariants(ctx, []uuid.UUID{tea.ID}).Return([]*menuentity.ProductVariant{small, large}, nil)
+	mockCostRepo.EXPECT().GetCurrentCosts(ctx, gomock.Any(), gomock.Any()).Return(map[uuid.UUID]*entity.IngredientCost{
+		leaves.ID: {IngredientID: leaves.ID, Cost: common.NewMoney(300000), Quantity: 1, Unit: "kg"}, // 3000 ₽ за кг
+	}, nil)
+
+	report, err := costing.MarginReport(ctx, 0)
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if len(report) != 2 {
+		t.Fatalf("ожидали строку на каждый вариант, получили %d", len(report))
+	}
+	// 0.3: 5 г × 300 коп = 1500 коп при цене 150 ₽; 0.5: 3000 коп при цене 200 ₽
+	for _, row := range report {
+		switch {
+		case *row.VariantID == small.ID && row.Cost.Amount == 1500 && row.Price.Amount == 15000:
+		case *row.VariantID == large.ID && row.Cost.Amount == 3000 && row.Price.Amount == 20000:
+		default:
+			t.Errorf("неверная строка отчета: %+v", row)
+		}
+	}
+}
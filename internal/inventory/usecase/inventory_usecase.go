@@ -0,0 +1,350 @@
+package usecase
+
+import (
+	"coffe/internal/inventory/entity"
+	"coffe/internal/inventory/repository"
+	menuentity "coffe/internal/menu/entity"
+	"coffe/internal/units"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidMovement возвращается при некорректных данных складской операции.
+var ErrInvalidMovement = errors.New("некорректная складская операция")
+
+// MovementRequest содержит данные ручной складской операции.
+type MovementRequest struct {
+	IngredientID uuid.UUID
+	Type         entity.MovementType
+	Quantity     float64 // для прихода и списания - положительное число, для корректировки - со знаком
+	Unit         string  // единица количества; пусто - единица ингредиента
+	CreatedBy    uuid.UUID
+	Comment      string
+}
+
+// StockObserver получает уведомления об изменении остатков ингредиентов.
+type StockObserver interface {
+	StockChanged(ctx context.Context, ingredientIDs []uuid.UUID) error
+}
+
+// InventoryUsecase реализует бизнес-логику складского учета.
+type InventoryUsecase struct {
+	stockRepo repository.StockRepository
+	observer  StockObserver
+}
+
+// NewInventoryUsecase создает новый экземпляр InventoryUsecase.
+// observer может быть nil, если реагировать на изменение остатков не нужно.
+func NewInventoryUsecase(stockRepo repository.StockRepository, observer StockObserver) *InventoryUsecase {
+	return &InventoryUsecase{
+		stockRepo: stockRepo,
+		observer:  observer,
+	}
+}
+
+// RecordMovement записывает приход, списание или корректировку.
+// Расход по заказам записывается только через ConsumeForOrder.
+func (u *InventoryUsecase) RecordMovement(ctx context.Context, req MovementRequest) (*entity.StockMovement, error) {
+	if req.IngredientID == uuid.Nil {
+		return nil, fmt.Errorf("%w: ID ингредиента не может быть пустым", ErrInvalidMovement)
+	}
+
+	quantity := req.Quantity
+	switch req.Type {
+	case entity.MovementReceipt:
+		if quantity <= 0 {
+			return nil, fmt.Errorf("%w: количество прихода должно быть больше нуля", ErrInvalidMovement)
+		}
+	case entity.MovementWaste:
+		if quantity <= 0 {
+			return nil, fmt.Errorf("%w: количество списания должно быть больше нуля", ErrInvalidMovement)
+		}
+		quantity = -quantity
+	case entity.MovementAdjustment:
+		if quantity == 0 {
+			return nil, fmt.Errorf("%w: корректировка не может быть нулевой", ErrInvalidMovement)
+		}
+	default:
+		return nil, fmt.Errorf("%w: тип %q нельзя записать вручную", ErrInvalidMovement, req.Type)
+	}
+
+	level, err := u.stockRepo.GetStockLevel(ctx, req.IngredientID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: ингредиент не найден", ErrInvalidMovement)
+	}
+	if req.Unit != "" {
+		// например, приход в килограммах для ингредиента, учитываемого в граммах
+		quantity, err = units.Convert(quantity, req.Unit, level.Unit, level.Density)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidMovement, err)
+		}
+	}
+
+	movement := &entity.StockMovement{
+		ID:           uuid.New(),
+		IngredientID: req.IngredientID,
+		Type:         req.Type,
+		Quantity:     quantity,
+		Comment:      req.Comment,
+	}
+	if req.CreatedBy != uuid.Nil {
+		movement.CreatedBy = &req.CreatedBy
+	}
+
+	if err := u.stockRepo.AddMovements(ctx, movement); err != nil {
+		return nil, err
+	}
+	if err := u.notify(ctx, []uuid.UUID{req.IngredientID}); err != nil {
+		return nil, err
+	}
+	return movement, nil
+}
+
+// ConsumeForOrder списывает ингредиенты по составу продуктов заказа.
+// Вызывается внутри транзакции смены статуса: остатки блокируются до ее завершения.
+// При нехватке хотя бы одного ингредиента ничего не списывается и возвращается *entity.ShortageError.
+func (u *InventoryUsecase) ConsumeForOrder(ctx context.Context, orderID uuid.UUID, lines []entity.OrderLine) error {
+	required, err := u.requirements(ctx, lines)
+	if err != nil {
+		return err
+	}
+	if len(required) == 0 {
+		return nil
+	}
+
+	ingredientIDs := make([]uuid.UUID, 0, len(required))
+	for id := range required {
+		ingredientIDs = append(ingredientIDs, id)
+	}
+	sort.Slice(ingredientIDs, func(i, j int) bool { return ingredientIDs[i].String() < ingredientIDs[j].String() })
+
+	levels, err := u.stockRepo.LockStockLevels(ctx, ingredientIDs)
+	if err != nil {
+		return err
+	}
+
+	var shortages []entity.Shortage
+	movements := make([]*entity.StockMovement, 0, len(ingredientIDs))
+	for _, id := range ingredientIDs {
+		need := required[id]
+		level, ok := levels[id]
+		if !ok {
+			return fmt.Errorf("ингредиент %s не найден", id)
+		}
+		if level.Quantity < need {
+			shortages = append(shortages, entity.Shortage{
+				IngredientID: id,
+				Name:         level.Name,
+				Unit:         level.Unit,
+				Required:     need,
+				Available:    level.Quantity,
+			})
+			continue
+		}
+		movements = append(movements, &entity.StockMovement{
+			ID:           uuid.New(),
+			IngredientID: id,
+			Type:         entity.MovementConsumption,
+			Quantity:     -need,
+			OrderID:      &orderID,
+			Comment:      "расход по заказу",
+		})
+	}
+
+	if len(shortages) > 0 {
+		return &entity.ShortageError{Shortages: shortages}
+	}
+	if err := u.stockRepo.AddMovements(ctx, movements...); err != nil {
+		return err
+	}
+	return u.notify(ctx, ingredientIDs)
+}
+
+// RestockForOrder возвращает на склад ингредиенты возвращенных позиций заказа. Вернуть
+// можно не больше, чем было списано по заказу, поэтому для заказа, отмененного
+// до подтверждения, остатки не меняются.
+func (u *InventoryUsecase) RestockForOrder(ctx context.Context, orderID uuid.UUID, lines []entity.OrderLine) error {
+	movements, err := u.stockRepo.GetMovementsByOrder(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	// списано по заказу за вычетом уже возвращенного
+	consumed := make(map[uuid.UUID]float64)
+	for _, movement := range movements {
+		if movement.Type == entity.MovementConsumption || movement.Type == entity.MovementReturn {
+			consumed[movement.IngredientID] -= movement.Quantity
+		}
+	}
+
+	required, err := u.requirements(ctx, lines)
+	if err != nil {
+		return err
+	}
+
+	ingredientIDs := make([]uuid.UUID, 0, len(required))
+	for id := range required {
+		ingredientIDs = append(ingredientIDs, id)
+	}
+	sort.Slice(ingredientIDs, func(i, j int) bool { return ingredientIDs[i].String() < ingredientIDs[j].String() })
+
+	returns := make([]*entity.StockMovement, 0, len(ingredientIDs))
+	changed := make([]uuid.UUID, 0, len(ingredientIDs))
+	for _, id := range ingredientIDs {
+		quantity := math.Min(required[id], consumed[id])
+		if quantity <= 0 {
+			continue
+		}
+		returns = append(returns, &entity.StockMovement{
+			ID:           uuid.New(),
+			IngredientID: id,
+			Type:         entity.MovementReturn,
+			Quantity:     quantity,
+			OrderID:      &orderID,
+			Comment:      "возврат по заказу",
+		})
+		changed = append(changed, id)
+	}
+
+	if len(returns) == 0 {
+		return nil
+	}
+	if err := u.stockRepo.AddMovements(ctx, returns...); err != nil {
+		return err
+	}
+	return u.notify(ctx, changed)
+}
+
+// notify сообщает наблюдателю об изменении остатков.
+func (u *InventoryUsecase) notify(ctx context.Context, ingredientIDs []uuid.UUID) error {
+	if u.observer == nil {
+		return nil
+	}
+	return u.observer.StockChanged(ctx, ingredientIDs)
+}
+
+// requirements суммирует потребность в ингредиентах по всем позициям заказа.
+func (u *InventoryUsecase) requirements(ctx context.Context, lines []entity.OrderLine) (map[uuid.UUID]float64, error) {
+	recipes, err := u.lineRecipes(ctx, lines)
+	if err != nil {
+		return nil, err
+	}
+
+	required := make(map[uuid.UUID]float64)
+	for i, line := range lines {
+		for _, recipe := range recipes[i] {
+			perUnit, err := recipeQuantity(recipe)
+			if err != nil {
+				return nil, err
+			}
+			required[recipe.IngredientID] += perUnit * float64(line.Quantity)
+		}
+	}
+	return required, nil
+}
+
+// lineRecipes возвращает состав одной порции для каждой позиции с учетом выбранного варианта
+// и модификаторов.
+func (u *InventoryUsecase) lineRecipes(ctx context.Context, lines []entity.OrderLine) ([][]*menuentity.ProductIngredient, error) {
+	var productIDs, variantIDs, modifierIDs []uuid.UUID
+	seenProducts := make(map[uuid.UUID]bool, len(lines))
+	seenVariants := make(map[uuid.UUID]bool)
+	seenModifiers := make(map[uuid.UUID]bool)
+	for _, line := range lines {
+		for _, id := range line.ModifierIDs {
+			if !seenModifiers[id] {
+				seenModifiers[id] = true
+				modifierIDs = append(modifierIDs, id)
+			}
+		}
+		if !seenProducts[line.ProductID] {
+			seenProducts[line.ProductID] = true
+			productIDs = append(productIDs, line.ProductID)
+		}
+		if line.VariantID != nil && !seenVariants[*line.VariantID] {
+			seenVariants[*line.VariantID] = true
+			variantIDs = append(variantIDs, *line.VariantID)
+		}
+	}
+
+	recipes, err := u.stockRepo.GetRecipes(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	byProduct := make(map[uuid.UUID][]*menuentity.ProductIngredient, len(productIDs))
+	for _, recipe := range recipes {
+		byProduct[recipe.ProductID] = append(byProduct[recipe.ProductID], recipe)
+	}
+
+	variants := make(map[uuid.UUID]*menuentity.ProductVariant, len(variantIDs))
+	if len(variantIDs) > 0 {
+		found, err := u.stockRepo.GetVariants(ctx, variantIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, variant := range found {
+			variants[variant.ID] = variant
+		}
+	}
+
+	modifiers := make(map[uuid.UUID]*menuentity.Modifier, len(modifierIDs))
+	if len(modifierIDs) > 0 {
+		found, err := u.stockRepo.GetModifiers(ctx, modifierIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, modifier := range found {
+			modifiers[modifier.ID] = modifier
+		}
+	}
+
+	result := make([][]*menuentity.ProductIngredient, len(lines))
+	for i, line := range lines {
+		recipe := byProduct[line.ProductID]
+		if line.VariantID != nil {
+			variant, ok := variants[*line.VariantID]
+			if !ok {
+				return nil, fmt.Errorf("вариант продукта %s не найден", *line.VariantID)
+			}
+			recipe = variant.Recipe(recipe)
+		}
+		for _, id := range line.ModifierIDs {
+			modifier, ok := modifiers[id]
+			if !ok {
+				return nil, fmt.Errorf("модификатор %s не найден", id)
+			}
+			recipe = modifier.Apply(recipe)
+		}
+		result[i] = recipe
+	}
+	return result, nil
+}
+
+// recipeQuantity возвращает расход ингредиента на одну порцию продукта в единицах ингредиента.
+func recipeQuantity(recipe *menuentity.ProductIngredient) (float64, error) {
+	if recipe.Ingredient == nil || recipe.Unit == "" || recipe.Unit == recipe.Ingredient.Unit {
+		return recipe.Quantity, nil
+	}
+	quantity, err := units.Convert(recipe.Quantity, recipe.Unit, recipe.Ingredient.Unit, recipe.Ingredient.Density)
+	if err != nil {
+		return 0, fmt.Errorf("состав продукта, ингредиент %s: %w", recipe.Ingredient.Name, err)
+	}
+	return quantity, nil
+}
+
+// GetStockLevels возвращает текущие остатки всех ингредиентов.
+func (u *InventoryUsecase) GetStockLevels(ctx context.Context) ([]*entity.StockLevel, error) {
+	return u.stockRepo.GetStockLevels(ctx)
+}
+
+// GetMovements возвращает журнал операций по ингредиенту.
+func (u *InventoryUsecase) GetMovements(ctx context.Context, ingredientID uuid.UUID) ([]*entity.StockMovement, error) {
+	if ingredientID == uuid.Nil {
+		return nil, errors.New("ID ингредиента не может быть пустым")
+	}
+	return u.stockRepo.GetMovementsByIngredient(ctx, ingredientID)
+}
@@ -0,0 +1,221 @@
+package usecase_test
+
+import (
+	"coffe/internal/inventory/entity"
+	"coffe/internal/inventory/usecase"
+	"coffe/internal/inventory/usecase/mocks"
+	menuentity "coffe/internal/menu/entity"
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+)
+
+func TestInventoryUsecase_ConsumeForOrder_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStockRepo := mocks.NewMockStockRepository(ctrl)
+	inventory := usecase.NewInventoryUsecase(mockStockRepo, nil)
+
+	ctx := context.Background()
+	orderID := uuid.New()
+	latte, milk := uuid.New(), uuid.New()
+
+	mockStockRepo.EXPECT().GetRecipes(ctx, []uuid.UUID{latte}).Return([]*menuentity.ProductIngredient{
+		{ProductID: latte, IngredientID: milk, Quantity: 200, Unit: "ml", Ingredient: &menuentity.Ingredient{ID: milk, Name: "Молоко", Unit: "ml"}},
+	}, nil)
+	mockStockRepo.EXPECT().LockStockLevels(ctx, []uuid.UUID{milk}).Return(map[uuid.UUID]*entity.StockLevel{
+		milk: {IngredientID: milk, Name: "Молоко", Unit: "ml", Quantity: 1000},
+	}, nil)
+	mockStockRepo.EXPECT().AddMovements(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, movements ...*entity.StockMovement) error {
+			if len(movements) != 1 {
+				t.Fatalf("ожидали одну операцию, получили %d", len(movements))
+			}
+			m := movements[0]
+			if m.Type != entity.MovementConsumption || m.Quantity != -600 || m.OrderID == nil || *m.OrderID != orderID {
+				t.Errorf("неверная операция расхода: %+v", m)
+			}
+			return nil
+		})
+
+	// две позиции одного продукта суммируются: 2 + 1 латте по 200 мл
+	lines := []entity.OrderLine{{ProductID: latte, Quantity: 2}, {ProductID: latte, Quantity: 1}}
+	if err := inventory.ConsumeForOrder(ctx, orderID, lines); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+}
+
+func TestInventoryUsecase_ConsumeForOrder_Shortage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStockRepo := mocks.NewMockStockRepository(ctrl)
+	inventory := usecase.NewInventoryUsecase(mockStockRepo, nil)
+
+	ctx := context.Background()
+	latte, milk := uuid.New(), uuid.New()
+
+	mockStockRepo.EXPECT().GetRecipes(ctx, gomock.Any()).Return([]*menuentity.ProductIngredient{
+		{ProductID: latte, IngredientID: milk, Quantity: 200},
+	}, nil)
+	mockStockRepo.EXPECT().LockStockLevels(ctx, gomock.Any()).Return(map[uuid.UUID]*entity.StockLevel{
+		milk: {IngredientID: milk, Name: "Молоко", Unit: "ml", Quantity: 300},
+	}, nil)
+	mockStockRepo.EXPECT().AddMovements(gomock.Any(), gomock.Any()).Times(0)
+
+	err := inventory.ConsumeForOrder(ctx, uuid.New(), []entity.OrderLine{{ProductID: latte, Quantity: 2}})
+
+	var shortageErr *entity.ShortageError
+	if !errors.As(err, &shortageErr) {
+		t.Fatalf("ожидали ShortageError, получили %v", err)
+	}
+	if !errors.Is(err, entity.ErrInsufficientStock) {
+		t.Error("ShortageError должна оборачивать ErrInsufficientStock")
+	}
+	if len(shortageErr.Shortages) != 1 || shortageErr.Shortages[0].Required != 400 || shortageErr.Shortages[0].Available != 300 {
+		t.Errorf("неверный отчет о нехватке: %+v", shortageErr.Shortages)
+	}
+}
+
+func TestInventoryUsecase_RecordMovement_Validation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	inventory := usecase.NewInventoryUsecase(mocks.NewMockStockRepository(ctrl), nil)
+
+	tests := []usecase.MovementRequest{
+		{IngredientID: uuid.New(), Type: entity.MovementReceipt, Quantity: -1},
+		{IngredientID: uuid.New(), Type: entity.MovementWaste, Quantity: 0},
+		{IngredientID: uuid.New(), Type: entity.MovementAdjustment, Quantity: 0},
+		{IngredientID: uuid.New(), Type: entity.MovementConsumption, Quantity: 5},
+		{Type: entity.MovementReceipt, Quantity: 5},
+	}
+	for _, req := range tests {
+		if _, err := inventory.RecordMovement(context.Background(), req); !errors.Is(err, usecase.ErrInvalidMovement) {
+			t.Errorf("%+v: ожидали ErrInvalidMovement, получили %v", req, err)
+		}
+	}
+}
+
+func TestInventoryUsecase_ConsumeForOrder_ConvertsUnits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStockRepo := mocks.NewMockStockRepository(ctrl)
+	inventory := usecase.NewInventoryUsecase(mockStockRepo, nil)
+
+	ctx := context.Background()
+	espresso, beans := uuid.New(), uuid.New()
+
+	// рецепт в граммах, зерно учитывается на складе в килограммах
+	mockStockRepo.EXPECT().GetRecipes(ctx, gomock.Any()).Return([]*menuentity.ProductIngredient{
+		{ProductID: espresso, IngredientID: beans, Quantity: 18, Unit: "g", Ingredient: &menuentity.Ingredient{ID: beans, Name: "Зерно", Unit: "kg"}},
+	}, nil)
+	mockStockRepo.EXPECT().LockStockLevels(ctx, gomock.Any()).Return(map[uuid.UUID]*entity.StockLevel{
+		beans: {IngredientID: beans, Name: "Зерно", Unit: "kg", Quantity: 1},
+	}, nil)
+	mockStockRepo.EXPECT().AddMovements(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, movements ...*entity.StockMovement) error {
+			if got := movements[0].Quantity; math.Abs(got+0.036) > 1e-9 {
+				t.Errorf("ожидали расход -0.036 кг, получили %v", got)
+			}
+			return nil
+		})
+
+	if err := inventory.ConsumeForOrder(ctx, uuid.New(), []entity.OrderLine{{ProductID: espresso, Quantity: 2}}); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+}
+
+func TestInventoryUsecase_ConsumeForOrder_Variant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStockRepo := mocks.NewMockStockRepository(ctrl)
+	inventory := usecase.NewInventoryUsecase(mockStockRepo, nil)
+
+	ctx := context.Background()
+	latte, milk := uuid.New(), uuid.New()
+	large := &menuentity.ProductVariant{ID: uuid.New(), ProductID: latte, Name: "L", RecipeMultiplier: 1.5}
+	milkIngredient := &menuentity.Ingredient{ID: milk, Name: "Молоко", Unit: "ml"}
+
+	mockStockRepo.EXPECT().GetRecipes(ctx, []uuid.UUID{latte}).Return([]*menuentity.ProductIngredient{
+		{ProductID: latte, IngredientID: milk, Quantity: 200, Unit: "ml", Ingredient: milkIngredient},
+	}, nil)
+	mockStockRepo.EXPECT().GetVariants(ctx, []uuid.UUID{large.ID}).Return([]*menuentity.ProductVariant{large}, nil)
+	mockStockRepo.EXPECT().LockStockLevels(ctx, []uuid.UUID{milk}).Return(map[uuid.UUID]*entity.StockLevel{
+		milk: {IngredientID: milk, Name: "Молоко", Unit: "ml", Quantity: 1000},
+	}, nil)
+	mockStockRepo.EXPECT().AddMovements(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, movements ...*entity.StockMovement) error {
+			// латте L (300 мл) + обычный латте (200 мл)
+			if got := movements[0].Quantity; got != -500 {
+				t.Errorf("ожидали расход -500 мл, получили %v", got)
+			}
+			return nil
+		})
+
+	lines := []entity.OrderLine{{ProductID: latte, VariantID: &large.ID, Quantity: 1}, {ProductID: latte, Quantity: 1}}
+	if err := inventory.ConsumeForOrder(ctx, uuid.New(), lines); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+}
+
+func TestInventoryUsecase_ConsumeForOrder_Modifiers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStockRepo := mocks.NewMockStockRepository(ctrl)
+	inventory := usecase.NewInventoryUsecase(mockStockRepo, nil)
+
+	ctx := context.Background()
+	latte, milk, oatMilk := uuid.New(), uuid.New(), uuid.New()
+	oat := &menuentity.Modifier{ID: uuid.New(), Name: "Овсяное молоко", ReplacesIngredientID: &milk, IngredientID: &oatMilk,
+		Ingredient: &menuentity.Ingredient{ID: oatMilk, Name: "Овсяное молоко", Unit: "l"}}
+
+	mockStockRepo.EXPECT().GetRecipes(ctx, []uuid.UUID{latte}).Return([]*menuentity.ProductIngredient{
+		{ProductID: latte, IngredientID: milk, Quantity: 200, Unit: "ml", Ingredient: &menuentity.Ingredient{ID: milk, Name: "Молоко", Unit: "ml"}},
+	}, nil)
+	mockStockRepo.EXPECT().GetModifiers(ctx, []uuid.UUID{oat.ID}).Return([]*menuentity.Modifier{oat}, nil)
+	mockStockRepo.EXPECT().LockStockLevels(ctx, []uuid.UUID{oatMilk}).Return(map[uuid.UUID]*entity.StockLevel{
+		oatMilk: {IngredientID: oatMilk, Name: "Овсяное молоко", Unit: "l", Quantity: 1},
+	}, nil)
+	mockStockRepo.EXPECT().AddMovements(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, movements ...*entity.StockMovement) error {
+			// обычное молоко не списывается, овсяное - 2 × 200 мл в литрах
+			if len(movements) != 1 || movements[0].IngredientID != oatMilk || math.Abs(movements[0].Quantity+0.4) > 1e-9 {
+				t.Errorf("неверный расход: %+v", movements[0])
+			}
+			return nil
+		})
+
+	lines := []entity.OrderLine{{ProductID: latte, ModifierIDs: []uuid.UUID{oat.ID}, Quantity: 2}}
+	if err := inventory.ConsumeForOrder(ctx, uuid.New(), lines); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+}
+
+func TestInventoryUsecase_RestockForOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStockRepo := mocks.NewMockStockRepository(ctrl)
+	inventory := usecase.NewInventoryUsecase(mockStockRepo, nil)
+
+	ctx := context.Background()
+	orderID := uuid.New()
+	latte, milk, beans := uuid.New(), uuid.New(), uuid.New()
+
+	// по заказу списано 400 мл молока, 100 мл уже возвращено; зерно не списывалось
+	mockStockRepo.EXPECT().GetMovementsByOrder(ctx, orderID).Return([]*entity.StockMovement{
+		{IngredientID: milk, Type: entity.MovementConsumption, Quantity: -400},
+		{IngredientID: milk, Type: entity.MovementReturn, Quantity: 100},
+	}, nil)
+	mockStockRepo.EXPECT().GetRecipes(ctx, []uuid.UUID{latte}).Return([]*menuentity.ProductIngredient{
+		{ProductID: latte, IngredientID: milk, Quantity: 200},
+		{ProductID: latte, IngredientID: beans, Quantity: 18},
+	}, nil)
+	mockStockRepo.EXPECT().AddMovements(ctx, gomock.Any()).DoAndReturn(
+		func(_ context.Context, movements ...*entity.StockMovement) error {
+			// два латте требуют 400 мл, но вернуть можно только оставшиеся 300 мл
+			if len(movements) != 1 || movements[0].IngredientID != milk || movements[0].Type != entity.MovementReturn || movements[0].Quantity != 300 {
+				t.Errorf("неверный возврат на склад: %+v", movements)
+			}
+			return nil
+		})
+
+	if err := inventory.RestockForOrder(ctx, orderID, []entity.OrderLine{{ProductID: latte, Quantity: 2}}); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+}
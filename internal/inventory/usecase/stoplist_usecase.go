@@ -0,0 +1,195 @@
+package usecase
+
+import (
+	"coffe/internal/inventory/entity"
+	"coffe/internal/inventory/repository"
+	menuentity "coffe/internal/menu/entity"
+	menurepository "coffe/internal/menu/repository"
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// StopListUsecase пересчитывает автоматический стоп-лист: позиции меню, для одной
+// порции которых на складе не хватает хотя бы одного ингредиента, помечаются
+// как закончившиеся. Ручной флаг IsActive и решение персонала при этом не меняются.
+type StopListUsecase struct {
+	stockRepo repository.StockRepository
+	menuRepo  menurepository.MenuRepository
+}
+
+// NewStopListUsecase создает новый экземпляр StopListUsecase.
+func NewStopListUsecase(stockRepo repository.StockRepository, menuRepo menurepository.MenuRepository) *StopListUsecase {
+	return &StopListUsecase{
+		stockRepo: stockRepo,
+		menuRepo:  menuRepo,
+	}
+}
+
+// StockChanged пересчитывает доступность продуктов, в которые входят изменившиеся ингредиенты.
+func (u *StopListUsecase) StockChanged(ctx context.Context, ingredientIDs []uuid.UUID) error {
+	productIDs, err := u.stockRepo.GetProductIDsByIngredients(ctx, ingredientIDs)
+	if err != nil {
+		return err
+	}
+	return u.Recompute(ctx, productIDs)
+}
+
+// RecomputeAll пересчитывает доступность всех продуктов, у которых задан состав.
+func (u *StopListUsecase) RecomputeAll(ctx context.Context) error {
+	levels, err := u.stockRepo.GetStockLevels(ctx)
+	if err != nil {
+		return err
+	}
+	ingredientIDs := make([]uuid.UUID, 0, len(levels))
+	for _, level := range levels {
+		ingredientIDs = append(ingredientIDs, level.IngredientID)
+	}
+	return u.StockChanged(ctx, ingredientIDs)
+}
+
+// Recompute пересчитывает доступность указанных продуктов по текущим остаткам. Продукт
+// с вариантами доступен, пока можно приготовить хотя бы один активный вариант; для
+// обязательной группы модификаторов должно хватать ингредиентов на нужное число вариантов
+// выбора. Необязательные модификаторы на доступность продукта не влияют.
+func (u *StopListUsecase) Recompute(ctx context.Context, productIDs []uuid.UUID) error {
+	if len(productIDs) == 0 {
+		return nil
+	}
+
+	recipes, err := u.stockRepo.GetRecipes(ctx, productIDs)
+	if err != nil {
+		return err
+	}
+	variants, err := u.stockRepo.GetProductVariants(ctx, productIDs)
+	if err != nil {
+		return err
+	}
+	groups, err := u.stockRepo.GetModifierGroups(ctx, productIDs)
+	if err != nil {
+		return err
+	}
+
+	ingredientIDs := make([]uuid.UUID, 0, len(recipes))
+	byProduct := make(map[uuid.UUID][]*menuentity.ProductIngredient, len(productIDs))
+	for _, recipe := range recipes {
+		ingredientIDs = append(ingredientIDs, recipe.IngredientID)
+		byProduct[recipe.ProductID] = append(byProduct[recipe.ProductID], recipe)
+	}
+	variantsByProduct := make(map[uuid.UUID][]*menuentity.ProductVariant)
+	for _, variant := range variants {
+		for _, ingredient := range variant.Ingredients {
+			ingredientIDs = append(ingredientIDs, ingredient.IngredientID)
+		}
+		variantsByProduct[variant.ProductID] = append(variantsByProduct[variant.ProductID], variant)
+	}
+	groupsByProduct := make(map[uuid.UUID][]*menuentity.ModifierGroup)
+	for _, group := range groups {
+		for _, modifier := range group.Modifiers {
+			if modifier.IngredientID != nil {
+				ingredientIDs = append(ingredientIDs, *modifier.IngredientID)
+			}
+		}
+		groupsByProduct[group.ProductID] = append(groupsByProduct[group.ProductID], group)
+	}
+
+	levels, err := u.stockRepo.GetStockLevelsByIDs(ctx, ingredientIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, productID := range productIDs {
+		options := [][]*menuentity.ProductIngredient{byProduct[productID]}
+		if productVariants := variantsByProduct[productID]; len(productVariants) > 0 {
+			options = options[:0]
+			for _, variant := range productVariants {
+				options = append(options, variant.Recipe(byProduct[productID]))
+			}
+		}
+
+		// продукт в стоп-листе, только если не хватает ингредиентов на все варианты
+		available := false
+		var names []string
+		for _, recipe := range options {
+			missing, err := missingForOption(recipe, groupsByProduct[productID], levels)
+			if err != nil {
+				return err
+			}
+			if len(missing) == 0 {
+				available = true
+				break
+			}
+			names = appendUnique(names, missing...)
+		}
+		if available {
+			names = nil
+		}
+		if err := u.menuRepo.SetOutOfStock(ctx, productID, !available, strings.Join(names, ", ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// missingForOption возвращает ингредиенты, которых не хватает на порцию с составом recipe
+// и обязательными модификаторами групп groups.
+func missingForOption(recipe []*menuentity.ProductIngredient, groups []*menuentity.ModifierGroup, levels map[uuid.UUID]*entity.StockLevel) ([]string, error) {
+	missing, err := shortages(recipe, levels)
+	if err != nil || len(missing) > 0 {
+		return missing, err
+	}
+
+	for _, group := range groups {
+		if group.MinChoices == 0 {
+			continue
+		}
+		available := 0
+		var groupMissing []string
+		for _, modifier := range group.Modifiers {
+			names, err := shortages(modifier.Apply(recipe), levels)
+			if err != nil {
+				return nil, err
+			}
+			if len(names) == 0 {
+				available++
+			}
+			groupMissing = appendUnique(groupMissing, names...)
+		}
+		if available < group.MinChoices {
+			missing = appendUnique(missing, groupMissing...)
+		}
+	}
+	return missing, nil
+}
+
+// shortages возвращает ингредиенты состава, остатка которых не хватает на одну порцию.
+func shortages(recipe []*menuentity.ProductIngredient, levels map[uuid.UUID]*entity.StockLevel) ([]string, error) {
+	var names []string
+	for _, ingredient := range recipe {
+		perUnit, err := recipeQuantity(ingredient)
+		if err != nil {
+			return nil, err
+		}
+		level, ok := levels[ingredient.IngredientID]
+		if !ok || level.Quantity < perUnit {
+			name := ingredient.IngredientID.String()
+			if ok {
+				name = level.Name
+			}
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// appendUnique добавляет в names отсутствующие в нем значения.
+func appendUnique(names []string, values ...string) []string {
+	for _, value := range values {
+		if !slices.Contains(names, value) {
+			names = append(names, value)
+		}
+	}
+	return names
+}
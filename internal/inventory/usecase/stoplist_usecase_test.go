@@ -0,0 +1,87 @@
+package usecase_test
+
+import (
+	"coffe/internal/inventory/entity"
+	"coffe/internal/inventory/usecase"
+	"coffe/internal/inventory/usecase/mocks"
+	menuentity "coffe/internal/menu/entity"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+)
+
+func TestStopListUsecase_StockChanged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStockRepo := mocks.NewMockStockRepository(ctrl)
+	mockMenuRepo := mocks.NewMockMenuRepository(ctrl)
+	stopList := usecase.NewStopListUsecase(mockStockRepo, mockMenuRepo)
+
+	ctx := context.Background()
+	latte, espresso := uuid.New(), uuid.New()
+	milk, beans := uuid.New(), uuid.New()
+
+	mockStockRepo.EXPECT().GetProductIDsByIngredients(ctx, []uuid.UUID{milk}).Return([]uuid.UUID{latte, espresso}, nil)
+	mockStockRepo.EXPECT().GetRecipes(ctx, []uuid.UUID{latte, espresso}).Return([]*menuentity.ProductIngredient{
+		{ProductID: latte, IngredientID: milk, Quantity: 200},
+		{ProductID: latte, IngredientID: beans, Quantity: 18},
+		{ProductID: espresso, IngredientID: beans, Quantity: 18},
+	}, nil)
+	mockStockRepo.EXPECT().GetProductVariants(ctx, []uuid.UUID{latte, espresso}).Return(nil, nil)
+	mockStockRepo.EXPECT().GetModifierGroups(ctx, []uuid.UUID{latte, espresso}).Return(nil, nil)
+	mockStockRepo.EXPECT().GetStockLevelsByIDs(ctx, gomock.Any()).Return(map[uuid.UUID]*entity.StockLevel{
+		milk:  {IngredientID: milk, Name: "Молоко", Quantity: 150},
+		beans: {IngredientID: beans, Name: "Зерно", Quantity: 500},
+	}, nil)
+
+	// латте уходит в стоп-лист из-за молока, эспрессо остается в продаже
+	mockMenuRepo.EXPECT().SetOutOfStock(ctx, latte, true, "Молоко").Return(nil)
+	mockMenuRepo.EXPECT().SetOutOfStock(ctx, espresso, false, "").Return(nil)
+
+	if err := stopList.StockChanged(ctx, []uuid.UUID{milk}); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+}
+
+func TestStopListUsecase_Recompute_Variants(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStockRepo := mocks.NewMockStockRepository(ctrl)
+	mockMenuRepo := mocks.NewMockMenuRepository(ctrl)
+	stopList := usecase.NewStopListUsecase(mockStockRepo, mockMenuRepo)
+
+	ctx := context.Background()
+	latte, tea, flatWhite := uuid.New(), uuid.New(), uuid.New()
+	milk, beans, leaves, oatMilk := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	products := []uuid.UUID{latte, tea, flatWhite}
+
+	// у латте основной состав с молоком, но размер S задан явным составом без молока
+	mockStockRepo.EXPECT().GetRecipes(ctx, products).Return([]*menuentity.ProductIngredient{
+		{ProductID: latte, IngredientID: milk, Quantity: 200},
+		{ProductID: flatWhite, IngredientID: beans, Quantity: 18},
+	}, nil)
+	mockStockRepo.EXPECT().GetProductVariants(ctx, products).Return([]*menuentity.ProductVariant{
+		{ID: uuid.New(), ProductID: latte, Name: "S", Ingredients: []*menuentity.VariantIngredient{{IngredientID: beans, Quantity: 18}}},
+		{ID: uuid.New(), ProductID: latte, Name: "L", RecipeMultiplier: 2},
+		// состав чая задан только в варианте
+		{ID: uuid.New(), ProductID: tea, Name: "0.3", Ingredients: []*menuentity.VariantIngredient{{IngredientID: leaves, Quantity: 5}}},
+	}, nil)
+	// флэт уайт требует выбрать молоко, а овсяного не осталось
+	mockStockRepo.EXPECT().GetModifierGroups(ctx, products).Return([]*menuentity.ModifierGroup{
+		{ProductID: flatWhite, MinChoices: 1, Modifiers: []*menuentity.Modifier{{IngredientID: &oatMilk, Quantity: 150}}},
+	}, nil)
+	mockStockRepo.EXPECT().GetStockLevelsByIDs(ctx, gomock.Any()).Return(map[uuid.UUID]*entity.StockLevel{
+		milk:    {IngredientID: milk, Name: "Молоко", Quantity: 0},
+		beans:   {IngredientID: beans, Name: "Зерно", Quantity: 500},
+		leaves:  {IngredientID: leaves, Name: "Чай листовой", Quantity: 2},
+		oatMilk: {IngredientID: oatMilk, Name: "Овсяное молоко", Quantity: 100},
+	}, nil)
+
+	mockMenuRepo.EXPECT().SetOutOfStock(ctx, latte, false, "").Return(nil)
+	mockMenuRepo.EXPECT().SetOutOfStock(ctx, tea, true, "Чай листовой").Return(nil)
+	mockMenuRepo.EXPECT().SetOutOfStock(ctx, flatWhite, true, "Овсяное молоко").Return(nil)
+
+	if err := stopList.Recompute(ctx, products); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+}
@@ -0,0 +1,211 @@
+package http
+
+import (
+	"coffe/internal/common"
+	"coffe/internal/kitchen/entity"
+	"coffe/internal/kitchen/usecase"
+	orderentity "coffe/internal/order/entity"
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// StationRequest описывает станцию кухни и категории продуктов, которые она готовит
+type StationRequest struct {
+	Name          string   `json:"name" binding:"required"`
+	Code          string   `json:"code" binding:"required"` // "bar", "kitchen", "pastry"
+	Categories    []string `json:"categories"`              // категории продуктов: "coffee", "dessert"
+	TargetMinutes int      `json:"target_minutes"`          // норматив приготовления, 0 - без контроля
+	IsDefault     bool     `json:"is_default"`
+	SortOrder     int      `json:"sort_order"`
+}
+
+func (r StationRequest) toStation() *entity.Station {
+	categories := make([]entity.StationCategory, len(r.Categories))
+	for i, category := range r.Categories {
+		categories[i] = entity.StationCategory{Category: category}
+	}
+	return &entity.Station{
+		Name:          r.Name,
+		Code:          r.Code,
+		Categories:    categories,
+		TargetMinutes: r.TargetMinutes,
+		IsDefault:     r.IsDefault,
+		SortOrder:     r.SortOrder,
+	}
+}
+
+type KitchenHandler struct {
+	kitchenUsecase *usecase.KitchenUsecase
+}
+
+func NewKitchenHandler(kitchenUsecase *usecase.KitchenUsecase) *KitchenHandler {
+	return &KitchenHandler{kitchenUsecase: kitchenUsecase}
+}
+
+// список станций кухни
+func (h *KitchenHandler) GetStations(ctx *gin.Context) {
+	stations, err := h.kitchenUsecase.GetStations(ctx.Request.Context())
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения станций"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"stations": stations, "total": len(stations)})
+}
+
+// создание станции
+func (h *KitchenHandler) CreateStation(ctx *gin.Context) {
+	var request StationRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат данных: " + err.Error()})
+		return
+	}
+
+	station := request.toStation()
+	if err := h.kitchenUsecase.CreateStation(ctx.Request.Context(), station); err != nil {
+		ctx.JSON(stationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, station)
+}
+
+// обновление станции
+func (h *KitchenHandler) UpdateStation(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный ID станции"})
+		return
+	}
+
+	var request StationRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат данных: " + err.Error()})
+		return
+	}
+
+	station := request.toStation()
+	station.ID = id
+	if err := h.kitchenUsecase.UpdateStation(ctx.Request.Context(), station); err != nil {
+		ctx.JSON(stationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, station)
+}
+
+// удаление станции
+func (h *KitchenHandler) DeleteStation(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный ID станции"})
+		return
+	}
+
+	if err := h.kitchenUsecase.DeleteStation(ctx.Request.Context(), id); err != nil {
+		ctx.JSON(stationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Станция удалена"})
+}
+
+// экран станции: тикеты по открытым заказам (?status=в работе|готов), старые первыми
+func (h *KitchenHandler) GetTickets(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный ID станции"})
+		return
+	}
+	status := entity.TicketStatus(ctx.DefaultQuery("status", string(entity.TicketActive)))
+
+	station, tickets, err := h.kitchenUsecase.GetTickets(ctx.Request.Context(), id, status)
+	if err != nil {
+		if errors.Is(err, usecase.ErrStationNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения тикетов"})
+		return
+	}
+
+	late := 0
+	for _, ticket := range tickets {
+		if ticket.Late {
+			late++
+		}
+	}
+	ctx.JSON(http.StatusOK, gin.H{
+		"station": station,
+		"tickets": tickets,
+		"total":   len(tickets),
+		"late":    late,
+	})
+}
+
+// отметка готовности тикета станцией
+func (h *KitchenHandler) BumpTicket(ctx *gin.Context) {
+	h.changeTicket(ctx, h.kitchenUsecase.Bump)
+}
+
+// возврат готового тикета на станцию
+func (h *KitchenHandler) RecallTicket(ctx *gin.Context) {
+	h.changeTicket(ctx, h.kitchenUsecase.Recall)
+}
+
+func (h *KitchenHandler) changeTicket(ctx *gin.Context, change func(ctx context.Context, ticketID, userID uuid.UUID) (*entity.Ticket, error)) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный ID тикета"})
+		return
+	}
+	user, ok := currentUser(ctx)
+	if !ok {
+		return
+	}
+
+	ticket, err := change(ctx.Request.Context(), id, user.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrTicketNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, entity.ErrTicketNotActive), errors.Is(err, entity.ErrTicketNotBumped),
+			errors.Is(err, usecase.ErrOrderClosed), errors.Is(err, orderentity.ErrStatusConflict):
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка изменения тикета"})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ticket)
+}
+
+// stationErrorStatus подбирает HTTP-статус для ошибки станции
+func stationErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, usecase.ErrStationNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, usecase.ErrInvalidStation):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+func currentUser(ctx *gin.Context) (*common.User, bool) {
+	userInterface, exists := ctx.Get("user")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Пользователь не авторизован"})
+		return nil, false
+	}
+	user, ok := userInterface.(*common.User)
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения пользователя"})
+		return nil, false
+	}
+	return user, true
+}
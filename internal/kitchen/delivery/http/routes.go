@@ -0,0 +1,31 @@
+package http
+
+import (
+	"coffe/internal/middleware"
+	userentity "coffe/internal/user/entity"
+	"coffe/internal/user/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupKitchenRoutes настраивает маршруты экранов станций кухни и управления станциями
+func SetupKitchenRoutes(router *gin.RouterGroup, handler *KitchenHandler, jwtMiddleware *middleware.JWTMiddleware, permissionUC usecase.PermissionUsecase) {
+	kitchen := router.Group("/kitchen")
+	kitchen.Use(jwtMiddleware.Authenticate())
+	kitchen.Use(jwtMiddleware.RequireRole(userentity.RoleAdmin, userentity.RoleManager))
+	{
+		kitchen.GET("/stations", middleware.PermissionMiddleware(permissionUC, "read_order"), handler.GetStations)
+		kitchen.GET("/stations/:id/tickets", middleware.PermissionMiddleware(permissionUC, "read_order"), handler.GetTickets)
+		kitchen.POST("/tickets/:id/bump", middleware.PermissionMiddleware(permissionUC, "update_order"), handler.BumpTicket)
+		kitchen.POST("/tickets/:id/recall", middleware.PermissionMiddleware(permissionUC, "update_order"), handler.RecallTicket)
+	}
+
+	admin := router.Group("/admin/kitchen")
+	admin.Use(jwtMiddleware.Authenticate())
+	admin.Use(jwtMiddleware.RequireRole(userentity.RoleAdmin, userentity.RoleManager))
+	{
+		admin.POST("/stations", middleware.PermissionMiddleware(permissionUC, "update_order"), handler.CreateStation)
+		admin.PUT("/stations/:id", middleware.PermissionMiddleware(permissionUC, "update_order"), handler.UpdateStation)
+		admin.DELETE("/stations/:id", middleware.PermissionMiddleware(permissionUC, "update_order"), handler.DeleteStation)
+	}
+}
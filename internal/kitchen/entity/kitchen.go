@@ -0,0 +1,220 @@
+package entity
+
+import (
+	orderentity "coffe/internal/order/entity"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrTicketNotActive возвращается при отметке готовности тикета, который уже отдан.
+var ErrTicketNotActive = errors.New("тикет уже отмечен готовым")
+
+// ErrTicketNotBumped возвращается при возврате тикета, который еще в работе.
+var ErrTicketNotBumped = errors.New("вернуть можно только готовый тикет")
+
+// Station представляет станцию приготовления: бар, кухню, кондитерскую витрину.
+type Station struct {
+	ID            uuid.UUID         `json:"id" db:"id"`
+	Name          string            `json:"name" db:"name"`                                         // "Бар", "Кухня"
+	Code          string            `json:"code" db:"code" gorm:"uniqueIndex"`                      // "bar", "kitchen", "pastry"
+	Categories    []StationCategory `json:"categories" db:"categories" gorm:"foreignKey:StationID"` // категории продуктов станции
+	TargetMinutes int               `json:"target_minutes" db:"target_minutes"`                     // норматив приготовления, 0 - без контроля
+	IsDefault     bool              `json:"is_default" db:"is_default"`                             // принимает позиции категорий без станции
+	SortOrder     int               `json:"sort_order" db:"sort_order"`
+	CreatedAt     time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at" db:"updated_at"`
+}
+
+// StationCategory связывает категорию продуктов со станцией. Категория
+// готовится только на одной станции.
+type StationCategory struct {
+	Category  string    `json:"category" db:"category" gorm:"primaryKey"` // категория продукта: "coffee", "dessert"
+	StationID uuid.UUID `json:"station_id" db:"station_id" gorm:"index"`
+}
+
+// Target возвращает норматив приготовления станции.
+func (s *Station) Target() time.Duration {
+	return time.Duration(s.TargetMinutes) * time.Minute
+}
+
+// Route выбирает станцию для категории продукта: станцию категории, иначе станцию
+// по умолчанию, иначе первую по порядку. Возвращает nil, если станций нет.
+func Route(stations []*Station, category string) *Station {
+	var fallback *Station
+	for _, station := range stations {
+		for _, c := range station.Categories {
+			if c.Category == category {
+				return station
+			}
+		}
+		if station.IsDefault && fallback == nil {
+			fallback = station
+		}
+	}
+	if fallback == nil && len(stations) > 0 {
+		fallback = stations[0]
+	}
+	return fallback
+}
+
+// TicketStatus определяет статус тикета станции.
+type TicketStatus string
+
+const (
+	TicketActive TicketStatus = "в работе" // позиции готовятся
+	TicketBumped TicketStatus = "готов"    // станция отдала позиции
+)
+
+// Ticket - позиции заказа, которые готовит одна станция.
+type Ticket struct {
+	ID          uuid.UUID    `json:"id" db:"id"`
+	OrderID     uuid.UUID    `json:"order_id" db:"order_id" gorm:"index"`
+	OrderNumber int          `json:"order_number" db:"order_number"`
+	StationID   uuid.UUID    `json:"station_id" db:"station_id" gorm:"index"`
+	Status      TicketStatus `json:"status" db:"status" gorm:"index"`
+	Lines       []TicketLine `json:"lines" db:"lines" gorm:"foreignKey:TicketID"`
+	Notes       string       `json:"notes,omitempty" db:"notes"` // комментарий к заказу
+	Recalls     int          `json:"recalls" db:"recalls"`       // сколько раз тикет возвращали на станцию
+	OpenedAt    time.Time    `json:"opened_at" db:"opened_at"`   // начало отсчета времени, сбрасывается при возврате
+	BumpedAt    *time.Time   `json:"bumped_at,omitempty" db:"bumped_at"`
+	CreatedAt   time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at" db:"updated_at"`
+
+	AgeSeconds int64 `json:"age_seconds" gorm:"-"` // время в работе
+	Late       bool  `json:"late" gorm:"-"`        // норматив станции превышен
+}
+
+// TicketLine - позиция заказа в тикете.
+type TicketLine struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	TicketID  uuid.UUID `json:"ticket_id" db:"ticket_id" gorm:"index"`
+	ItemID    uuid.UUID `json:"item_id" db:"item_id"`
+	Name      string    `json:"name" db:"name"`
+	Modifiers string    `json:"modifiers,omitempty" db:"modifiers"` // модификаторы через запятую
+	Quantity  int       `json:"quantity" db:"quantity"`
+}
+
+// NewTickets раскладывает позиции заказа по станциям: на каждую станцию создается
+// один тикет. categories содержит категории продуктов позиций по ID продукта.
+// Тикеты упорядочены по станциям.
+func NewTickets(order *orderentity.Order, stations []*Station, categories map[uuid.UUID]string, now time.Time) []*Ticket {
+	byStation := make(map[uuid.UUID]*Ticket)
+	position := make(map[uuid.UUID]int)
+	for i, station := range stations {
+		position[station.ID] = i
+	}
+
+	var tickets []*Ticket
+	for _, item := range order.Items {
+		station := Route(stations, categories[item.ProductID])
+		if station == nil {
+			continue
+		}
+		ticket, ok := byStation[station.ID]
+		if !ok {
+			ticket = &Ticket{
+				ID:          uuid.New(),
+				OrderID:     order.Id,
+				OrderNumber: order.Number,
+				StationID:   station.ID,
+				Status:      TicketActive,
+				Notes:       order.Notes,
+				OpenedAt:    now,
+			}
+			byStation[station.ID] = ticket
+			tickets = append(tickets, ticket)
+		}
+		ticket.Lines = append(ticket.Lines, TicketLine{
+			ID:        uuid.New(),
+			TicketID:  ticket.ID,
+			ItemID:    item.ID,
+			Name:      item.DisplayName(),
+			Modifiers: modifierNames(item.Modifiers),
+			Quantity:  item.Quantity,
+		})
+	}
+	sort.SliceStable(tickets, func(i, j int) bool {
+		return position[tickets[i].StationID] < position[tickets[j].StationID]
+	})
+	return tickets
+}
+
+func modifierNames(modifiers []orderentity.ItemModifier) string {
+	names := make([]string, len(modifiers))
+	for i, modifier := range modifiers {
+		names[i] = modifier.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// Bump отмечает, что станция приготовила позиции тикета.
+func (t *Ticket) Bump(now time.Time) error {
+	if t.Status != TicketActive {
+		return ErrTicketNotActive
+	}
+	t.Status = TicketBumped
+	t.BumpedAt = &now
+	return nil
+}
+
+// Recall возвращает готовый тикет на станцию, например для переделки.
+// Время в работе отсчитывается заново.
+func (t *Ticket) Recall(now time.Time) error {
+	if t.Status != TicketBumped {
+		return ErrTicketNotBumped
+	}
+	t.Status = TicketActive
+	t.BumpedAt = nil
+	t.OpenedAt = now
+	t.Recalls++
+	return nil
+}
+
+// Track рассчитывает время тикета в работе и признак опоздания относительно
+// норматива target. Для готового тикета время считается до отметки готовности.
+func (t *Ticket) Track(now time.Time, target time.Duration) {
+	end := now
+	if t.BumpedAt != nil {
+		end = *t.BumpedAt
+	}
+	age := end.Sub(t.OpenedAt)
+	if age < 0 {
+		age = 0
+	}
+	t.AgeSeconds = int64(age / time.Second)
+	t.Late = target > 0 && age > target
+}
+
+// AllBumped сообщает, что все тикеты заказа отданы.
+func AllBumped(tickets []*Ticket) bool {
+	for _, ticket := range tickets {
+		if ticket.Status != TicketBumped {
+			return false
+		}
+	}
+	return len(tickets) > 0
+}
+
+// TableName задает имя таблицы станций.
+func (Station) TableName() string {
+	return "kitchen_stations"
+}
+
+// TableName задает имя таблицы категорий станций.
+func (StationCategory) TableName() string {
+	return "kitchen_station_categories"
+}
+
+// TableName задает имя таблицы тикетов.
+func (Ticket) TableName() string {
+	return "kitchen_tickets"
+}
+
+// TableName задает имя таблицы позиций тикетов.
+func (TicketLine) TableName() string {
+	return "kitchen_ticket_lines"
+}
@@ -0,0 +1,85 @@
+package entity_test
+
+import (
+	"coffe/internal/kitchen/entity"
+	orderentity "coffe/internal/order/entity"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewTickets_RoutesByCategory(t *testing.T) {
+	bar := &entity.Station{ID: uuid.New(), Name: "Бар", Categories: []entity.StationCategory{{Category: "coffee"}}}
+	pastry := &entity.Station{ID: uuid.New(), Name: "Витрина", Categories: []entity.StationCategory{{Category: "dessert"}}, IsDefault: true}
+	stations := []*entity.Station{bar, pastry}
+
+	latte, croissant, sandwich := uuid.New(), uuid.New(), uuid.New()
+	categories := map[uuid.UUID]string{latte: "coffee", croissant: "dessert", sandwich: "food"}
+	order := &orderentity.Order{
+		Id:     uuid.New(),
+		Number: 42,
+		Items: []orderentity.ItemsOrders{
+			{ID: uuid.New(), ProductID: croissant, Name: "Круассан", Quantity: 1},
+			{ID: uuid.New(), ProductID: latte, Name: "Латте", Quantity: 2, Modifiers: []orderentity.ItemModifier{{Name: "Овсяное молоко"}}},
+			{ID: uuid.New(), ProductID: sandwich, Name: "Сэндвич", Quantity: 1},
+		},
+	}
+
+	tickets := entity.NewTickets(order, stations, categories, time.Now())
+	if len(tickets) != 2 {
+		t.Fatalf("ожидали по тикету на станцию, получили %d", len(tickets))
+	}
+	if tickets[0].StationID != bar.ID || len(tickets[0].Lines) != 1 || tickets[0].Lines[0].Modifiers != "Овсяное молоко" {
+		t.Errorf("неверный тикет бара: %+v", tickets[0])
+	}
+	// категория без станции уходит на станцию по умолчанию
+	if tickets[1].StationID != pastry.ID || len(tickets[1].Lines) != 2 {
+		t.Errorf("неверный тикет витрины: %+v", tickets[1])
+	}
+	for _, ticket := range tickets {
+		if ticket.OrderNumber != 42 || ticket.Status != entity.TicketActive {
+			t.Errorf("неверный тикет: %+v", ticket)
+		}
+	}
+}
+
+func TestTicket_BumpRecallTrack(t *testing.T) {
+	opened := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	ticket := &entity.Ticket{Status: entity.TicketActive, OpenedAt: opened}
+
+	ticket.Track(opened.Add(6*time.Minute), 5*time.Minute)
+	if ticket.AgeSeconds != 360 || !ticket.Late {
+		t.Errorf("тикет старше норматива должен опаздывать: %+v", ticket)
+	}
+	ticket.Track(opened.Add(6*time.Minute), 0)
+	if ticket.Late {
+		t.Error("без норматива тикет не опаздывает")
+	}
+
+	if err := ticket.Bump(opened.Add(3 * time.Minute)); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if err := ticket.Bump(opened.Add(4 * time.Minute)); err != entity.ErrTicketNotActive {
+		t.Errorf("ожидали ErrTicketNotActive, получили %v", err)
+	}
+	// время готового тикета считается до отметки готовности
+	ticket.Track(opened.Add(time.Hour), 5*time.Minute)
+	if ticket.AgeSeconds != 180 || ticket.Late {
+		t.Errorf("неверное время готового тикета: %+v", ticket)
+	}
+	if !entity.AllBumped([]*entity.Ticket{ticket}) {
+		t.Error("все тикеты готовы")
+	}
+
+	recalled := opened.Add(10 * time.Minute)
+	if err := ticket.Recall(recalled); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if ticket.Status != entity.TicketActive || ticket.Recalls != 1 || !ticket.OpenedAt.Equal(recalled) {
+		t.Errorf("неверный возвращенный тикет: %+v", ticket)
+	}
+	if err := ticket.Recall(recalled); err != entity.ErrTicketNotBumped {
+		t.Errorf("ожидали ErrTicketNotBumped, получили %v", err)
+	}
+}
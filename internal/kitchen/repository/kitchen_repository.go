@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"coffe/internal/kitchen/entity"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// KitchenRepository определяет методы для работы со станциями и тикетами кухни.
+type KitchenRepository interface {
+	// Станции
+	CreateStation(ctx context.Context, station *entity.Station) error          // создание станции с категориями
+	UpdateStation(ctx context.Context, station *entity.Station) error          // обновление станции, категории заменяются
+	DeleteStation(ctx context.Context, id uuid.UUID) error                     // удаление станции
+	GetStationByID(ctx context.Context, id uuid.UUID) (*entity.Station, error) // станция по id
+	GetStations(ctx context.Context) ([]*entity.Station, error)                // все станции по порядку
+
+	// Тикеты
+	CreateTickets(ctx context.Context, tickets []*entity.Ticket) error                                           // создание тикетов заказа
+	UpdateTicket(ctx context.Context, ticket *entity.Ticket) error                                               // обновление тикета
+	GetTicketByID(ctx context.Context, id uuid.UUID) (*entity.Ticket, error)                                     // тикет по id
+	LockByOrder(ctx context.Context, orderID uuid.UUID) ([]*entity.Ticket, error)                                // тикеты заказа с блокировкой
+	GetByStation(ctx context.Context, stationID uuid.UUID, status entity.TicketStatus) ([]*entity.Ticket, error) // тикеты станции по открытым заказам
+}
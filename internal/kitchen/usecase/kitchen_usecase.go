@@ -0,0 +1,273 @@
+package usecase
+
+import (
+	commonrepository "coffe/internal/common/repository"
+	eventsentity "coffe/internal/events/entity"
+	"coffe/internal/kitchen/entity"
+	"coffe/internal/kitchen/repository"
+	menuentity "coffe/internal/menu/entity"
+	orderentity "coffe/internal/order/entity"
+	orderrepository "coffe/internal/order/repository"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrStationNotFound возвращается, если станция не найдена.
+var ErrStationNotFound = errors.New("станция не найдена")
+
+// ErrInvalidStation возвращается при некорректных данных станции.
+var ErrInvalidStation = errors.New("некорректная станция")
+
+// ErrTicketNotFound возвращается, если тикет не найден.
+var ErrTicketNotFound = errors.New("тикет не найден")
+
+// ErrOrderClosed возвращается при работе с тикетом выполненного или отмененного заказа.
+var ErrOrderClosed = errors.New("заказ уже закрыт")
+
+// Products возвращает продукты меню для определения станции позиции.
+type Products interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*menuentity.Product, error)
+}
+
+// EventPublisher рассылает события заказов подписчикам в реальном времени.
+type EventPublisher interface {
+	Publish(ctx context.Context, event *eventsentity.Event)
+}
+
+// KitchenUsecase распределяет позиции заказов по станциям кухни и ведет их тикеты.
+// Когда все станции отдали свои позиции, заказ автоматически становится готовым.
+type KitchenUsecase struct {
+	kitchenRepo repository.KitchenRepository
+	orderRepo   orderrepository.OrderRepository
+	products    Products
+	txManager   commonrepository.TransactionManager
+	events      EventPublisher
+}
+
+// NewKitchenUsecase создает новый экземпляр KitchenUsecase.
+func NewKitchenUsecase(
+	kitchenRepo repository.KitchenRepository,
+	orderRepo orderrepository.OrderRepository,
+	products Products,
+	txManager commonrepository.TransactionManager,
+	events EventPublisher,
+) *KitchenUsecase {
+	return &KitchenUsecase{
+		kitchenRepo: kitchenRepo,
+		orderRepo:   orderRepo,
+		products:    products,
+		txManager:   txManager,
+		events:      events,
+	}
+}
+
+// CreateStation создает станцию и закрепляет за ней категории продуктов.
+func (u *KitchenUsecase) CreateStation(ctx context.Context, station *entity.Station) error {
+	if err := validateStation(station); err != nil {
+		return err
+	}
+	station.ID = uuid.New()
+	return u.kitchenRepo.CreateStation(ctx, station)
+}
+
+// UpdateStation обновляет станцию и заменяет ее категории.
+func (u *KitchenUsecase) UpdateStation(ctx context.Context, station *entity.Station) error {
+	existing, err := u.kitchenRepo.GetStationByID(ctx, station.ID)
+	if err != nil {
+		return ErrStationNotFound
+	}
+	if err := validateStation(station); err != nil {
+		return err
+	}
+	station.CreatedAt = existing.CreatedAt
+	return u.kitchenRepo.UpdateStation(ctx, station)
+}
+
+// DeleteStation удаляет станцию. Позиции ее категорий уходят на станцию по умолчанию.
+func (u *KitchenUsecase) DeleteStation(ctx context.Context, id uuid.UUID) error {
+	if err := u.kitchenRepo.DeleteStation(ctx, id); err != nil {
+		return ErrStationNotFound
+	}
+	return nil
+}
+
+// GetStations возвращает станции в порядке отображения.
+func (u *KitchenUsecase) GetStations(ctx context.Context) ([]*entity.Station, error) {
+	return u.kitchenRepo.GetStations(ctx)
+}
+
+func validateStation(station *entity.Station) error {
+	station.Name = strings.TrimSpace(station.Name)
+	station.Code = strings.TrimSpace(station.Code)
+	if station.Name == "" || station.Code == "" {
+		return fmt.Errorf("%w: название и код обязательны", ErrInvalidStation)
+	}
+	if station.TargetMinutes < 0 {
+		return fmt.Errorf("%w: норматив не может быть отрицательным", ErrInvalidStation)
+	}
+	seen := make(map[string]bool, len(station.Categories))
+	for i := range station.Categories {
+		category := strings.TrimSpace(station.Categories[i].Category)
+		if category == "" || seen[category] {
+			return fmt.Errorf("%w: категории должны быть непустыми и не повторяться", ErrInvalidStation)
+		}
+		seen[category] = true
+		station.Categories[i].Category = category
+	}
+	return nil
+}
+
+// OpenForOrder создает тикеты станций для заказа, переданного в приготовление.
+// Вызывается в транзакции смены статуса; если тикеты заказа уже есть или станции
+// не настроены, ничего не делает.
+func (u *KitchenUsecase) OpenForOrder(ctx context.Context, order *orderentity.Order) error {
+	existing, err := u.kitchenRepo.LockByOrder(ctx, order.Id)
+	if err != nil {
+		return err
+	}
+	if len(existing) > 0 {
+		return nil
+	}
+	stations, err := u.kitchenRepo.GetStations(ctx)
+	if err != nil || len(stations) == 0 {
+		return err
+	}
+
+	categories := make(map[uuid.UUID]string, len(order.Items))
+	for _, item := range order.Items {
+		if _, ok := categories[item.ProductID]; ok {
+			continue
+		}
+		product, err := u.products.GetByID(ctx, item.ProductID)
+		if err != nil {
+			return fmt.Errorf("продукт %s: %w", item.ProductID, err)
+		}
+		categories[item.ProductID] = product.Category
+	}
+	return u.kitchenRepo.CreateTickets(ctx, entity.NewTickets(order, stations, categories, time.Now()))
+}
+
+// GetTickets возвращает тикеты станции по открытым заказам с временем в работе
+// и признаком опоздания; пустой статус - все тикеты.
+func (u *KitchenUsecase) GetTickets(ctx context.Context, stationID uuid.UUID, status entity.TicketStatus) (*entity.Station, []*entity.Ticket, error) {
+	station, err := u.kitchenRepo.GetStationByID(ctx, stationID)
+	if err != nil {
+		return nil, nil, ErrStationNotFound
+	}
+	tickets, err := u.kitchenRepo.GetByStation(ctx, stationID, status)
+	if err != nil {
+		return nil, nil, err
+	}
+	now := time.Now()
+	for _, ticket := range tickets {
+		ticket.Track(now, station.Target())
+	}
+	return station, tickets, nil
+}
+
+// Bump отмечает тикет готовым. Когда готовы все тикеты заказа, заказ переводится
+// из статуса "готовится" в "готов" от имени сотрудника userID.
+func (u *KitchenUsecase) Bump(ctx context.Context, ticketID, userID uuid.UUID) (*entity.Ticket, error) {
+	return u.changeTicket(ctx, ticketID, userID, func(ticket *entity.Ticket, tickets []*entity.Ticket, now time.Time) (*orderentity.OrderStatusHistory, error) {
+		if err := ticket.Bump(now); err != nil {
+			return nil, err
+		}
+		if !entity.AllBumped(tickets) {
+			return nil, nil
+		}
+		return &orderentity.OrderStatusHistory{
+			FromStatus: orderentity.OrderStatusPreparing,
+			ToStatus:   orderentity.OrderStatusReady,
+			Comment:    "все станции отдали позиции",
+		}, nil
+	})
+}
+
+// Recall возвращает готовый тикет на станцию. Если заказ уже готов, он снова
+// переводится в статус "готовится".
+func (u *KitchenUsecase) Recall(ctx context.Context, ticketID, userID uuid.UUID) (*entity.Ticket, error) {
+	return u.changeTicket(ctx, ticketID, userID, func(ticket *entity.Ticket, _ []*entity.Ticket, now time.Time) (*orderentity.OrderStatusHistory, error) {
+		if err := ticket.Recall(now); err != nil {
+			return nil, err
+		}
+		return &orderentity.OrderStatusHistory{
+			FromStatus: orderentity.OrderStatusReady,
+			ToStatus:   orderentity.OrderStatusPreparing,
+			Comment:    "тикет возвращен на станцию",
+		}, nil
+	})
+}
+
+// changeTicket меняет тикет под блокировкой тикетов заказа. apply возвращает переход
+// заказа, который нужно выполнить, если заказ находится в его исходном статусе.
+func (u *KitchenUsecase) changeTicket(
+	ctx context.Context,
+	ticketID, userID uuid.UUID,
+	apply func(ticket *entity.Ticket, tickets []*entity.Ticket, now time.Time) (*orderentity.OrderStatusHistory, error),
+) (*entity.Ticket, error) {
+	found, err := u.kitchenRepo.GetTicketByID(ctx, ticketID)
+	if err != nil {
+		return nil, ErrTicketNotFound
+	}
+
+	var (
+		ticket *entity.Ticket
+		order  *orderentity.Order
+		change *orderentity.OrderStatusHistory
+	)
+	err = u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		tickets, err := u.kitchenRepo.LockByOrder(ctx, found.OrderID)
+		if err != nil {
+			return err
+		}
+		for _, t := range tickets {
+			if t.ID == ticketID {
+				ticket = t
+			}
+		}
+		if ticket == nil {
+			return ErrTicketNotFound
+		}
+		order, err = u.orderRepo.GetByID(ctx, ticket.OrderID)
+		if err != nil {
+			return err
+		}
+		if order.Status.IsFinal() {
+			return ErrOrderClosed
+		}
+
+		now := time.Now()
+		change, err = apply(ticket, tickets, now)
+		if err != nil {
+			return err
+		}
+		if err := u.kitchenRepo.UpdateTicket(ctx, ticket); err != nil {
+			return err
+		}
+		if change == nil || order.Status != change.FromStatus {
+			change = nil
+			return nil
+		}
+		change.ID = uuid.New()
+		change.OrderID = order.Id
+		change.ChangedBy = userID
+		return u.orderRepo.UpdateStatus(ctx, change)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if change != nil {
+		order.Status = change.ToStatus
+		event := eventsentity.NewOrderEvent(eventsentity.EventOrderStatusChanged, order)
+		event.PreviousStatus = change.FromStatus
+		u.events.Publish(ctx, event)
+	}
+	ticket.Lines = found.Lines
+	return ticket, nil
+}
@@ -0,0 +1,140 @@
+package usecase_test
+
+import (
+	"coffe/internal/common/repository/repositorytest"
+	eventsentity "coffe/internal/events/entity"
+	"coffe/internal/kitchen/entity"
+	"coffe/internal/kitchen/usecase"
+	"coffe/internal/kitchen/usecase/mocks"
+	menuentity "coffe/internal/menu/entity"
+	orderentity "coffe/internal/order/entity"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+)
+
+type kitchenMocks struct {
+	kitchenRepo *mocks.MockKitchenRepository
+	orderRepo   *mocks.MockOrderRepository
+	products    *mocks.MockProducts
+	events      *mocks.MockEventPublisher
+}
+
+func newKitchenUsecase(t *testing.T) (*usecase.KitchenUsecase, kitchenMocks) {
+	ctrl := gomock.NewController(t)
+	m := kitchenMocks{
+		kitchenRepo: mocks.NewMockKitchenRepository(ctrl),
+		orderRepo:   mocks.NewMockOrderRepository(ctrl),
+		products:    mocks.NewMockProducts(ctrl),
+		events:      mocks.NewMockEventPublisher(ctrl),
+	}
+	return usecase.NewKitchenUsecase(m.kitchenRepo, m.orderRepo, m.products, repositorytest.InlineTx{}, m.events), m
+}
+
+func TestKitchenUsecase_OpenForOrder(t *testing.T) {
+	kitchen, m := newKitchenUsecase(t)
+	ctx := context.Background()
+
+	bar := &entity.Station{ID: uuid.New(), Categories: []entity.StationCategory{{Category: "coffee"}}}
+	productID := uuid.New()
+	order := &orderentity.Order{
+		Id:    uuid.New(),
+		Items: []orderentity.ItemsOrders{{ID: uuid.New(), ProductID: productID, Quantity: 1}, {ID: uuid.New(), ProductID: productID, Quantity: 2}},
+	}
+
+	m.kitchenRepo.EXPECT().LockByOrder(ctx, order.Id).Return(nil, nil)
+	m.kitchenRepo.EXPECT().GetStations(ctx).Return([]*entity.Station{bar}, nil)
+	m.products.EXPECT().GetByID(ctx, productID).Return(&menuentity.Product{ID: productID, Category: "coffee"}, nil)
+	m.kitchenRepo.EXPECT().CreateTickets(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, tickets []*entity.Ticket) error {
+		if len(tickets) != 1 || tickets[0].StationID != bar.ID || len(tickets[0].Lines) != 2 {
+			t.Errorf("неверные тикеты: %+v", tickets)
+		}
+		return nil
+	})
+
+	if err := kitchen.OpenForOrder(ctx, order); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+
+	// повторная передача в приготовление не создает новых тикетов
+	m.kitchenRepo.EXPECT().LockByOrder(ctx, order.Id).Return([]*entity.Ticket{{ID: uuid.New()}}, nil)
+	if err := kitchen.OpenForOrder(ctx, order); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+}
+
+func TestKitchenUsecase_Bump_AdvancesOrder(t *testing.T) {
+	kitchen, m := newKitchenUsecase(t)
+	ctx := context.Background()
+
+	order := &orderentity.Order{Id: uuid.New(), Number: 7, Status: orderentity.OrderStatusPreparing}
+	bar := &entity.Ticket{ID: uuid.New(), OrderID: order.Id, Status: entity.TicketActive}
+	pastry := &entity.Ticket{ID: uuid.New(), OrderID: order.Id, Status: entity.TicketActive}
+	userID := uuid.New()
+
+	// первая станция отдала позиции - заказ еще готовится
+	m.kitchenRepo.EXPECT().GetTicketByID(ctx, bar.ID).Return(bar, nil)
+	m.kitchenRepo.EXPECT().LockByOrder(ctx, order.Id).Return([]*entity.Ticket{bar, pastry}, nil).Times(2)
+	m.orderRepo.EXPECT().GetByID(ctx, order.Id).Return(order, nil).Times(2)
+	m.kitchenRepo.EXPECT().UpdateTicket(ctx, gomock.Any()).Return(nil).Times(2)
+	if _, err := kitchen.Bump(ctx, bar.ID, userID); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+
+	// последняя станция - заказ становится готовым
+	m.kitchenRepo.EXPECT().GetTicketByID(ctx, pastry.ID).Return(pastry, nil)
+	m.orderRepo.EXPECT().UpdateStatus(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, change *orderentity.OrderStatusHistory) error {
+		if change.FromStatus != orderentity.OrderStatusPreparing || change.ToStatus != orderentity.OrderStatusReady || change.ChangedBy != userID {
+			t.Errorf("неверный переход: %+v", change)
+		}
+		return nil
+	})
+	m.events.EXPECT().Publish(ctx, gomock.Any()).Do(func(_ context.Context, event *eventsentity.Event) {
+		if event.Status != orderentity.OrderStatusReady || event.Number != 7 {
+			t.Errorf("неверное событие: %+v", event)
+		}
+	})
+	ticket, err := kitchen.Bump(ctx, pastry.ID, userID)
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if ticket.Status != entity.TicketBumped || ticket.BumpedAt == nil {
+		t.Errorf("тикет не отмечен готовым: %+v", ticket)
+	}
+}
+
+func TestKitchenUsecase_Recall(t *testing.T) {
+	kitchen, m := newKitchenUsecase(t)
+	ctx := context.Background()
+
+	order := &orderentity.Order{Id: uuid.New(), Status: orderentity.OrderStatusReady}
+	ticket := &entity.Ticket{ID: uuid.New(), OrderID: order.Id, Status: entity.TicketBumped}
+
+	m.kitchenRepo.EXPECT().GetTicketByID(ctx, ticket.ID).Return(ticket, nil).Times(2)
+	m.kitchenRepo.EXPECT().LockByOrder(ctx, order.Id).Return([]*entity.Ticket{ticket}, nil).Times(2)
+	m.orderRepo.EXPECT().GetByID(ctx, order.Id).Return(order, nil)
+	m.kitchenRepo.EXPECT().UpdateTicket(ctx, ticket).Return(nil)
+	m.orderRepo.EXPECT().UpdateStatus(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, change *orderentity.OrderStatusHistory) error {
+		if change.ToStatus != orderentity.OrderStatusPreparing {
+			t.Errorf("готовый заказ должен вернуться в приготовление: %+v", change)
+		}
+		return nil
+	})
+	m.events.EXPECT().Publish(ctx, gomock.Any())
+
+	if _, err := kitchen.Recall(ctx, ticket.ID, uuid.New()); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if ticket.Status != entity.TicketActive || ticket.Recalls != 1 {
+		t.Errorf("тикет не возвращен: %+v", ticket)
+	}
+
+	// тикет выполненного заказа вернуть нельзя
+	m.orderRepo.EXPECT().GetByID(ctx, order.Id).Return(&orderentity.Order{Id: order.Id, Status: orderentity.OrderStatusCompleted}, nil)
+	if _, err := kitchen.Recall(ctx, ticket.ID, uuid.New()); !errors.Is(err, usecase.ErrOrderClosed) {
+		t.Errorf("ожидали ErrOrderClosed, получили %v", err)
+	}
+}
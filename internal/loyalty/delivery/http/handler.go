@@ -0,0 +1,140 @@
+package http
+
+import (
+	"coffe/internal/common"
+	"coffe/internal/loyalty/entity"
+	"coffe/internal/loyalty/usecase"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// StampCampaignRequest содержит параметры кампании штамп-карты.
+type StampCampaignRequest struct {
+	Name            string    `json:"name" binding:"required"`
+	Categories      []string  `json:"categories" binding:"required"`
+	StampsRequired  int       `json:"stamps_required" binding:"required"`
+	RewardProductID uuid.UUID `json:"reward_product_id" binding:"required"`
+	IsActive        *bool     `json:"is_active"` // по умолчанию true
+}
+
+// LoyaltyResponse содержит баллы и штамп-карты клиента.
+type LoyaltyResponse struct {
+	*usecase.Summary
+	StampCards []*entity.StampCard `json:"stamp_cards"`
+}
+
+type LoyaltyHandler struct {
+	loyaltyUsecase *usecase.LoyaltyUsecase
+	stampUsecase   *usecase.StampUsecase
+}
+
+func NewLoyaltyHandler(loyaltyUsecase *usecase.LoyaltyUsecase, stampUsecase *usecase.StampUsecase) *LoyaltyHandler {
+	return &LoyaltyHandler{loyaltyUsecase: loyaltyUsecase, stampUsecase: stampUsecase}
+}
+
+// баланс, уровень, история баллов и штамп-карты текущего пользователя
+func (h *LoyaltyHandler) GetLoyalty(ctx *gin.Context) {
+	userInterface, exists := ctx.Get("user")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Пользователь не авторизован"})
+		return
+	}
+	user, ok := userInterface.(*common.User)
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения пользователя"})
+		return
+	}
+
+	summary, err := h.loyaltyUsecase.GetSummary(ctx.Request.Context(), user.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения баллов"})
+		return
+	}
+	cards, err := h.stampUsecase.GetCards(ctx.Request.Context(), user.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения штамп-карт"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, LoyaltyResponse{Summary: summary, StampCards: cards})
+}
+
+// список кампаний штамп-карт
+func (h *LoyaltyHandler) GetStampCampaigns(ctx *gin.Context) {
+	campaigns, err := h.stampUsecase.GetCampaigns(ctx.Request.Context())
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения кампаний"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"campaigns": campaigns,
+		"total":     len(campaigns),
+	})
+}
+
+// создание кампании штамп-карты
+func (h *LoyaltyHandler) CreateStampCampaign(ctx *gin.Context) {
+	var req StampCampaignRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат данных", "details": err.Error()})
+		return
+	}
+
+	campaign := req.toCampaign()
+	if err := h.stampUsecase.CreateCampaign(ctx.Request.Context(), campaign); err != nil {
+		stampCampaignError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, campaign)
+}
+
+// обновление кампании штамп-карты
+func (h *LoyaltyHandler) UpdateStampCampaign(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID кампании"})
+		return
+	}
+
+	var req StampCampaignRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат данных", "details": err.Error()})
+		return
+	}
+
+	campaign := req.toCampaign()
+	campaign.ID = id
+	if err := h.stampUsecase.UpdateCampaign(ctx.Request.Context(), campaign); err != nil {
+		stampCampaignError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, campaign)
+}
+
+func (r StampCampaignRequest) toCampaign() *entity.StampCampaign {
+	campaign := &entity.StampCampaign{
+		Name:            r.Name,
+		Categories:      r.Categories,
+		StampsRequired:  r.StampsRequired,
+		RewardProductID: r.RewardProductID,
+		IsActive:        true,
+	}
+	if r.IsActive != nil {
+		campaign.IsActive = *r.IsActive
+	}
+	return campaign
+}
+
+func stampCampaignError(ctx *gin.Context, err error) {
+	if errors.Is(err, usecase.ErrInvalidStampCampaign) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
@@ -0,0 +1,27 @@
+package http
+
+import (
+	"coffe/internal/middleware"
+	userentity "coffe/internal/user/entity"
+	"coffe/internal/user/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupLoyaltyRoutes настраивает маршруты программы лояльности
+func SetupLoyaltyRoutes(router *gin.RouterGroup, handler *LoyaltyHandler, jwtMiddleware *middleware.JWTMiddleware, permissionUC usecase.PermissionUsecase) {
+	loyalty := router.Group("/users")
+	loyalty.Use(jwtMiddleware.Authenticate())
+	{
+		loyalty.GET("/loyalty", handler.GetLoyalty)
+	}
+
+	campaigns := router.Group("/admin/stamp-campaigns")
+	campaigns.Use(jwtMiddleware.Authenticate())
+	campaigns.Use(jwtMiddleware.RequireRole(userentity.RoleAdmin, userentity.RoleManager))
+	{
+		campaigns.GET("", middleware.PermissionMiddleware(permissionUC, "read_promotion"), handler.GetStampCampaigns)
+		campaigns.POST("", middleware.PermissionMiddleware(permissionUC, "update_promotion"), handler.CreateStampCampaign)
+		campaigns.PUT("/:id", middleware.PermissionMiddleware(permissionUC, "update_promotion"), handler.UpdateStampCampaign)
+	}
+}
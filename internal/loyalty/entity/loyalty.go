@@ -0,0 +1,129 @@
+package entity
+
+import (
+	"coffe/internal/common"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EntryType определяет вид операции с баллами.
+type EntryType string
+
+const (
+	EntryEarn       EntryType = "начисление"    // начисление за выполненный заказ
+	EntryRedeem     EntryType = "списание"      // оплата заказа баллами
+	EntryReversal   EntryType = "отмена"        // возврат баллов при отмене заказа или возврате денег
+	EntryAdjustment EntryType = "корректировка" // ручная корректировка
+)
+
+// Entry представляет операцию в журнале баллов клиента. Баланс - сумма Points всех операций.
+type Entry struct {
+	ID         uuid.UUID  `json:"id" db:"id"`
+	CustomerID uuid.UUID  `json:"customer_id" db:"customer_id" gorm:"index"`
+	OrderID    *uuid.UUID `json:"order_id,omitempty" db:"order_id" gorm:"index"`
+	Type       EntryType  `json:"type" db:"type"`
+	Points     int64      `json:"points" db:"points"` // положительное - начисление, отрицательное - списание
+	Comment    string     `json:"comment,omitempty" db:"comment"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+}
+
+// TableName задает имя таблицы журнала баллов.
+func (Entry) TableName() string {
+	return "loyalty_entries"
+}
+
+// Tier представляет уровень программы лояльности.
+type Tier struct {
+	Name      string  `json:"name"`
+	Threshold int64   `json:"threshold"` // баллов, начисленных за все время, для перехода на уровень
+	EarnRate  float64 `json:"earn_rate"` // процент от оплаченной суммы, начисляемый баллами
+}
+
+// Program содержит настройки программы лояльности.
+type Program struct {
+	Tiers            []Tier       // по возрастанию порога
+	PointValue       common.Money // стоимость одного балла
+	MaxRedeemPercent float64      // максимальная доля заказа, оплачиваемая баллами
+}
+
+// Balance описывает состояние счета клиента.
+type Balance struct {
+	CustomerID       uuid.UUID    `json:"customer_id"`
+	Points           int64        `json:"points"`
+	Value            common.Money `json:"value"`  // стоимость баллов при оплате
+	Earned           int64        `json:"earned"` // баллов начислено за все время
+	Tier             Tier         `json:"tier"`
+	NextTier         *Tier        `json:"next_tier,omitempty"`
+	PointsToNextTier int64        `json:"points_to_next_tier,omitempty"`
+}
+
+// ParseTiers разбирает уровни из строки вида "Базовый:0:3,Серебро:3000:5",
+// где для каждого уровня указаны название, порог начисленных баллов и процент начисления.
+func ParseTiers(s string) ([]Tier, error) {
+	var tiers []Tier
+	for _, part := range strings.Split(s, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ":")
+		if len(fields) != 3 || fields[0] == "" {
+			return nil, fmt.Errorf("неверный формат уровня %q, ожидается название:порог:процент", part)
+		}
+		threshold, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil || threshold < 0 {
+			return nil, fmt.Errorf("неверный порог уровня %q", fields[0])
+		}
+		rate, err := strconv.ParseFloat(fields[2], 64)
+		if err != nil || rate < 0 || rate > 100 {
+			return nil, fmt.Errorf("неверный процент начисления уровня %q", fields[0])
+		}
+		tiers = append(tiers, Tier{Name: fields[0], Threshold: threshold, EarnRate: rate})
+	}
+	if len(tiers) == 0 {
+		return nil, errors.New("не задан ни один уровень")
+	}
+
+	sort.Slice(tiers, func(i, j int) bool { return tiers[i].Threshold < tiers[j].Threshold })
+	if tiers[0].Threshold != 0 {
+		return nil, errors.New("порог первого уровня должен быть равен 0")
+	}
+	return tiers, nil
+}
+
+// TierFor возвращает уровень клиента по числу начисленных баллов и следующий уровень, если он есть.
+func (p Program) TierFor(earned int64) (Tier, *Tier) {
+	current := 0
+	for i, tier := range p.Tiers {
+		if earned >= tier.Threshold {
+			current = i
+		}
+	}
+	if current+1 < len(p.Tiers) {
+		return p.Tiers[current], &p.Tiers[current+1]
+	}
+	return p.Tiers[current], nil
+}
+
+// PointsFor возвращает число баллов за оплаченную сумму. Дробные баллы отбрасываются.
+func (p Program) PointsFor(paid common.Money, tier Tier) int64 {
+	if !paid.IsPositive() || !p.PointValue.IsPositive() {
+		return 0
+	}
+	return int64(float64(paid.Amount) * tier.EarnRate / 100 / float64(p.PointValue.Amount))
+}
+
+// ValueOf возвращает стоимость баллов при оплате.
+func (p Program) ValueOf(points int64) common.Money {
+	return p.PointValue.Mul(points)
+}
+
+// MaxRedeemable возвращает максимальное число баллов, которым можно оплатить заказ на сумму total.
+func (p Program) MaxRedeemable(total common.Money) int64 {
+	if !total.IsPositive() || !p.PointValue.IsPositive() {
+		return 0
+	}
+	return total.Percent(p.MaxRedeemPercent).Amount / p.PointValue.Amount
+}
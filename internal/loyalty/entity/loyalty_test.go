@@ -0,0 +1,49 @@
+package entity_test
+
+import (
+	"coffe/internal/common"
+	"coffe/internal/loyalty/entity"
+	"testing"
+)
+
+func TestParseTiers(t *testing.T) {
+	tiers, err := entity.ParseTiers("Золото:10000:7, Базовый:0:3,Серебро:3000:5")
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if len(tiers) != 3 || tiers[0].Name != "Базовый" || tiers[2].Name != "Золото" || tiers[2].EarnRate != 7 {
+		t.Errorf("уровни должны быть упорядочены по порогу: %+v", tiers)
+	}
+
+	for _, s := range []string{"", "Базовый:0", "Базовый:-1:3", "Базовый:0:150", "Серебро:3000:5"} {
+		if _, err := entity.ParseTiers(s); err == nil {
+			t.Errorf("%q: ожидали ошибку", s)
+		}
+	}
+}
+
+func TestProgram(t *testing.T) {
+	tiers, _ := entity.ParseTiers("Базовый:0:3,Серебро:3000:5")
+	program := entity.Program{Tiers: tiers, PointValue: common.NewMoney(100), MaxRedeemPercent: 50}
+
+	tier, next := program.TierFor(2999)
+	if tier.Name != "Базовый" || next == nil || next.Name != "Серебро" {
+		t.Errorf("неверный уровень для 2999 баллов: %+v, %+v", tier, next)
+	}
+	tier, next = program.TierFor(3000)
+	if tier.Name != "Серебро" || next != nil {
+		t.Errorf("неверный уровень для 3000 баллов: %+v, %+v", tier, next)
+	}
+
+	// 5% от 459.90 ₽ = 22.99 ₽ -> 22 балла по 1 ₽
+	if got := program.PointsFor(common.NewMoney(45990), tier); got != 22 {
+		t.Errorf("ожидали 22 балла, получили %d", got)
+	}
+	// половина от 459.90 ₽ = 229.95 ₽ -> не больше 229 баллов
+	if got := program.MaxRedeemable(common.NewMoney(45990)); got != 229 {
+		t.Errorf("ожидали 229 баллов, получили %d", got)
+	}
+	if got := program.ValueOf(229); got.Amount != 22900 {
+		t.Errorf("ожидали 229 ₽, получили %s", got)
+	}
+}
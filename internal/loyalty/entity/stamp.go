@@ -0,0 +1,109 @@
+package entity
+
+import (
+	"coffe/internal/common"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StampCampaign описывает штамп-карту: каждая купленная единица из указанных категорий
+// дает штамп, а за StampsRequired штампов клиент получает бесплатный продукт.
+type StampCampaign struct {
+	ID              uuid.UUID `json:"id" db:"id"`
+	Name            string    `json:"name" db:"name"`
+	Categories      []string  `json:"categories" db:"categories" gorm:"serializer:json"` // категории продуктов, за которые ставятся штампы
+	StampsRequired  int       `json:"stamps_required" db:"stamps_required"`
+	RewardProductID uuid.UUID `json:"reward_product_id" db:"reward_product_id"`
+	IsActive        bool      `json:"is_active" db:"is_active"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// StampEntryType определяет вид операции со штампами.
+type StampEntryType string
+
+const (
+	StampEarn     StampEntryType = "начисление" // штампы за выполненный заказ
+	StampExchange StampEntryType = "обмен"      // штампы обменены на награду или возвращены при ее отмене
+	StampReversal StampEntryType = "отмена"     // отмена штампов отмененного или возвращенного заказа
+)
+
+// StampEntry представляет операцию в журнале штампов клиента по кампании.
+type StampEntry struct {
+	ID         uuid.UUID      `json:"id" db:"id"`
+	CampaignID uuid.UUID      `json:"campaign_id" db:"campaign_id" gorm:"index"`
+	CustomerID uuid.UUID      `json:"customer_id" db:"customer_id" gorm:"index"`
+	OrderID    *uuid.UUID     `json:"order_id,omitempty" db:"order_id" gorm:"index"`
+	Type       StampEntryType `json:"type" db:"type"`
+	Stamps     int            `json:"stamps" db:"stamps"`
+	CreatedAt  time.Time      `json:"created_at" db:"created_at"`
+}
+
+// RewardStatus определяет состояние награды штамп-карты.
+type RewardStatus string
+
+const (
+	RewardAvailable RewardStatus = "доступна"
+	RewardRedeemed  RewardStatus = "использована"
+	RewardCancelled RewardStatus = "отменена"
+)
+
+// StampReward представляет бесплатный продукт, полученный за заполненную штамп-карту.
+// Награда автоматически применяется к следующему заказу с этим продуктом.
+type StampReward struct {
+	ID              uuid.UUID    `json:"id" db:"id"`
+	CampaignID      uuid.UUID    `json:"campaign_id" db:"campaign_id" gorm:"index"`
+	CustomerID      uuid.UUID    `json:"customer_id" db:"customer_id" gorm:"index"`
+	ProductID       uuid.UUID    `json:"product_id" db:"product_id"`
+	Status          RewardStatus `json:"status" db:"status"`
+	EarnedOrderID   uuid.UUID    `json:"earned_order_id" db:"earned_order_id" gorm:"index"`
+	RedeemedOrderID *uuid.UUID   `json:"redeemed_order_id,omitempty" db:"redeemed_order_id" gorm:"index"`
+	Amount          common.Money `json:"amount" db:"amount"` // стоимость бесплатной единицы в заказе
+	CreatedAt       time.Time    `json:"created_at" db:"created_at"`
+	RedeemedAt      *time.Time   `json:"redeemed_at,omitempty" db:"redeemed_at"`
+}
+
+// StampLine описывает позицию заказа для начисления штампов и применения наград.
+type StampLine struct {
+	ProductID uuid.UUID
+	Category  string
+	Quantity  int
+	UnitPrice common.Money
+}
+
+// StampCard описывает состояние штамп-карты клиента по кампании.
+type StampCard struct {
+	Campaign *StampCampaign `json:"campaign"`
+	Stamps   int            `json:"stamps"`
+	Rewards  []*StampReward `json:"rewards,omitempty"` // доступные награды
+}
+
+// Validate проверяет параметры кампании.
+func (c *StampCampaign) Validate() error {
+	if c.Name == "" {
+		return errors.New("название кампании не может быть пустым")
+	}
+	if len(c.Categories) == 0 {
+		return errors.New("укажите хотя бы одну категорию")
+	}
+	if c.StampsRequired <= 0 {
+		return errors.New("количество штампов должно быть больше нуля")
+	}
+	if c.RewardProductID == uuid.Nil {
+		return errors.New("не указан продукт-награда")
+	}
+	return nil
+}
+
+// Counts проверяет, ставится ли штамп за продукт из категории category.
+func (c *StampCampaign) Counts(category string) bool {
+	for _, counted := range c.Categories {
+		if strings.EqualFold(counted, category) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"coffe/internal/loyalty/entity"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// LoyaltyRepository определяет методы для работы с журналом баллов.
+type LoyaltyRepository interface {
+	AddEntries(ctx context.Context, entries ...*entity.Entry) error                           // записать операции
+	GetHistory(ctx context.Context, customerID uuid.UUID, limit int) ([]*entity.Entry, error) // операции клиента, начиная с последней
+	GetOrderEntries(ctx context.Context, orderID uuid.UUID) ([]*entity.Entry, error)          // операции по заказу
+
+	// GetBalance возвращает текущий баланс клиента и число баллов, начисленных за все время
+	// с учетом отмененных начислений.
+	GetBalance(ctx context.Context, customerID uuid.UUID) (points, earned int64, err error)
+
+	// LockCustomer блокирует клиента до конца транзакции, чтобы операции с его баллами
+	// выполнялись последовательно, и возвращает название его роли.
+	LockCustomer(ctx context.Context, customerID uuid.UUID) (string, error)
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"coffe/internal/loyalty/entity"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// StampRepository определяет методы для работы со штамп-картами.
+type StampRepository interface {
+	CreateCampaign(ctx context.Context, campaign *entity.StampCampaign) error           // создать кампанию
+	UpdateCampaign(ctx context.Context, campaign *entity.StampCampaign) error           // обновить кампанию
+	GetCampaignByID(ctx context.Context, id uuid.UUID) (*entity.StampCampaign, error)   // кампания по id
+	GetCampaigns(ctx context.Context, activeOnly bool) ([]*entity.StampCampaign, error) // все или только активные кампании
+
+	AddEntries(ctx context.Context, entries ...*entity.StampEntry) error                            // записать операции со штампами
+	GetOrderEntries(ctx context.Context, orderID uuid.UUID) ([]*entity.StampEntry, error)           // операции по заказу
+	GetBalances(ctx context.Context, customerID uuid.UUID) (map[uuid.UUID]int, error)               // штампы клиента по кампаниям
+	GetProductCategories(ctx context.Context, productIDs []uuid.UUID) (map[uuid.UUID]string, error) // категории продуктов
+
+	// GetAvailableRewards возвращает доступные награды клиента, начиная с самых старых.
+	GetAvailableRewards(ctx context.Context, customerID uuid.UUID) ([]*entity.StampReward, error)
+	// GetOrderRewards возвращает награды, полученные или использованные в заказе.
+	GetOrderRewards(ctx context.Context, orderID uuid.UUID) ([]*entity.StampReward, error)
+	SaveRewards(ctx context.Context, rewards ...*entity.StampReward) error // создать или обновить награды
+}
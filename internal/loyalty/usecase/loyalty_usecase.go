@@ -0,0 +1,232 @@
+package usecase
+
+import (
+	"coffe/internal/common"
+	"coffe/internal/loyalty/entity"
+	"coffe/internal/loyalty/repository"
+	userentity "coffe/internal/user/entity"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidRedemption возвращается, если баллами нельзя оплатить заказ в запрошенном размере.
+var ErrInvalidRedemption = errors.New("нельзя оплатить заказ баллами")
+
+// historyLimit - число последних операций в сводке клиента.
+const historyLimit = 50
+
+// Summary содержит баланс клиента и историю операций.
+type Summary struct {
+	entity.Balance
+	History []*entity.Entry `json:"history"`
+}
+
+// LoyaltyUsecase ведет счета баллов клиентов: оплату баллами, начисление за выполненные
+// заказы и возврат при отмене.
+type LoyaltyUsecase struct {
+	loyaltyRepo repository.LoyaltyRepository
+	program     entity.Program
+}
+
+// NewLoyaltyUsecase создает новый экземпляр LoyaltyUsecase.
+func NewLoyaltyUsecase(loyaltyRepo repository.LoyaltyRepository, program entity.Program) *LoyaltyUsecase {
+	return &LoyaltyUsecase{loyaltyRepo: loyaltyRepo, program: program}
+}
+
+// Redeem списывает баллы в оплату заказа на сумму total и возвращает оплаченную ими сумму.
+// Баллами могут платить только клиенты, не больше баланса и не больше MaxRedeemPercent заказа.
+// Вызывается в транзакции оформления заказа.
+func (u *LoyaltyUsecase) Redeem(ctx context.Context, customerID, orderID uuid.UUID, points int64, total common.Money) (common.Money, error) {
+	if points <= 0 {
+		return common.NewMoney(0), nil
+	}
+
+	role, err := u.loyaltyRepo.LockCustomer(ctx, customerID)
+	if err != nil {
+		return common.Money{}, err
+	}
+	if role != userentity.RoleClient {
+		return common.Money{}, fmt.Errorf("%w: программа лояльности доступна только клиентам", ErrInvalidRedemption)
+	}
+
+	if limit := u.program.MaxRedeemable(total); points > limit {
+		return common.Money{}, fmt.Errorf("%w: этим заказом можно оплатить не больше %d баллов", ErrInvalidRedemption, limit)
+	}
+	balance, _, err := u.loyaltyRepo.GetBalance(ctx, customerID)
+	if err != nil {
+		return common.Money{}, err
+	}
+	if points > balance {
+		return common.Money{}, fmt.Errorf("%w: на счете %d баллов", ErrInvalidRedemption, balance)
+	}
+
+	if err := u.loyaltyRepo.AddEntries(ctx, &entity.Entry{
+		CustomerID: customerID,
+		OrderID:    &orderID,
+		Type:       entity.EntryRedeem,
+		Points:     -points,
+	}); err != nil {
+		return common.Money{}, err
+	}
+	return u.program.ValueOf(points), nil
+}
+
+// EarnForOrder начисляет баллы за выполненный заказ по ставке текущего уровня клиента.
+// paid - сумма, оплаченная деньгами. Повторный вызов для того же заказа ничего не начисляет.
+func (u *LoyaltyUsecase) EarnForOrder(ctx context.Context, customerID, orderID uuid.UUID, paid common.Money) error {
+	role, err := u.loyaltyRepo.LockCustomer(ctx, customerID)
+	if err != nil {
+		return err
+	}
+	if role != userentity.RoleClient {
+		return nil
+	}
+
+	entries, err := u.loyaltyRepo.GetOrderEntries(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.Type == entity.EntryEarn {
+			return nil
+		}
+	}
+
+	_, earned, err := u.loyaltyRepo.GetBalance(ctx, customerID)
+	if err != nil {
+		return err
+	}
+	tier, _ := u.program.TierFor(earned)
+	points := u.program.PointsFor(paid, tier)
+	if points == 0 {
+		return nil
+	}
+
+	return u.loyaltyRepo.AddEntries(ctx, &entity.Entry{
+		CustomerID: customerID,
+		OrderID:    &orderID,
+		Type:       entity.EntryEarn,
+		Points:     points,
+		Comment:    fmt.Sprintf("уровень %s, %.4g%%", tier.Name, tier.EarnRate),
+	})
+}
+
+// ReverseForOrder возвращает клиенту баллы, потраченные на отмененный заказ, и отменяет
+// начисленные за него баллы. Баланс может стать отрицательным, если начисленные баллы
+// уже потрачены. Повторный вызов ничего не меняет.
+func (u *LoyaltyUsecase) ReverseForOrder(ctx context.Context, customerID, orderID uuid.UUID) error {
+	entries, err := u.loyaltyRepo.GetOrderEntries(ctx, orderID)
+	if err != nil || len(entries) == 0 {
+		return err
+	}
+	if _, err := u.loyaltyRepo.LockCustomer(ctx, customerID); err != nil {
+		return err
+	}
+
+	var earned, redeemed int64
+	for _, entry := range entries {
+		switch {
+		case entry.Type == entity.EntryEarn, entry.Type == entity.EntryReversal && entry.Points < 0:
+			earned += entry.Points
+		case entry.Type == entity.EntryRedeem, entry.Type == entity.EntryReversal && entry.Points > 0:
+			redeemed += entry.Points
+		}
+	}
+
+	var reversals []*entity.Entry
+	if earned > 0 {
+		reversals = append(reversals, &entity.Entry{
+			CustomerID: customerID, OrderID: &orderID, Type: entity.EntryReversal, Points: -earned,
+			Comment: "отмена начисления",
+		})
+	}
+	if redeemed < 0 {
+		reversals = append(reversals, &entity.Entry{
+			CustomerID: customerID, OrderID: &orderID, Type: entity.EntryReversal, Points: -redeemed,
+			Comment: "возврат оплаты баллами",
+		})
+	}
+	return u.loyaltyRepo.AddEntries(ctx, reversals...)
+}
+
+// ReverseForRefund отменяет баллы, начисленные за выполненный заказ, пропорционально
+// возвращенной сумме: refunded - сумма всех возвратов по заказу, paid - сумма заказа,
+// оплаченная деньгами. Уже отмененные баллы учитываются, поэтому следующий возврат
+// отменяет только недостающую часть. Возврат всей суммы отменяет заказ целиком,
+// как ReverseForOrder, вместе с возвратом потраченных баллов.
+func (u *LoyaltyUsecase) ReverseForRefund(ctx context.Context, customerID, orderID uuid.UUID, refunded, paid common.Money) error {
+	if refunded.Cmp(paid) >= 0 {
+		return u.ReverseForOrder(ctx, customerID, orderID)
+	}
+
+	entries, err := u.loyaltyRepo.GetOrderEntries(ctx, orderID)
+	if err != nil || len(entries) == 0 {
+		return err
+	}
+	if _, err := u.loyaltyRepo.LockCustomer(ctx, customerID); err != nil {
+		return err
+	}
+
+	var earned, reversed int64
+	for _, entry := range entries {
+		switch {
+		case entry.Type == entity.EntryEarn:
+			earned += entry.Points
+		case entry.Type == entity.EntryReversal && entry.Points < 0:
+			reversed -= entry.Points
+		}
+	}
+	points := refundShare(earned, refunded, paid) - reversed
+	if points <= 0 {
+		return nil
+	}
+	return u.loyaltyRepo.AddEntries(ctx, &entity.Entry{
+		CustomerID: customerID, OrderID: &orderID, Type: entity.EntryReversal, Points: -points,
+		Comment: fmt.Sprintf("возврат %s из %s", refunded, paid),
+	})
+}
+
+// refundShare возвращает часть total, приходящуюся на возвращенную сумму refunded
+// из paid, с округлением вниз.
+func refundShare(total int64, refunded, paid common.Money) int64 {
+	if !paid.IsPositive() {
+		return total
+	}
+	return total * refunded.Amount / paid.Amount
+}
+
+// GetSummary возвращает баланс, уровень и последние операции клиента.
+func (u *LoyaltyUsecase) GetSummary(ctx context.Context, customerID uuid.UUID) (*Summary, error) {
+	if customerID == uuid.Nil {
+		return nil, errors.New("ID клиента не может быть пустым")
+	}
+
+	points, earned, err := u.loyaltyRepo.GetBalance(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+	history, err := u.loyaltyRepo.GetHistory(ctx, customerID, historyLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	tier, next := u.program.TierFor(earned)
+	summary := &Summary{
+		Balance: entity.Balance{
+			CustomerID: customerID,
+			Points:     points,
+			Value:      u.program.ValueOf(points),
+			Earned:     earned,
+			Tier:       tier,
+			NextTier:   next,
+		},
+		History: history,
+	}
+	if next != nil {
+		summary.PointsToNextTier = next.Threshold - earned
+	}
+	return summary, nil
+}
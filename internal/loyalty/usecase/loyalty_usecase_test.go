@@ -0,0 +1,146 @@
+package usecase_test
+
+import (
+	"coffe/internal/common"
+	"coffe/internal/loyalty/entity"
+	"coffe/internal/loyalty/usecase"
+	"coffe/internal/loyalty/usecase/mocks"
+	userentity "coffe/internal/user/entity"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+)
+
+func testProgram() entity.Program {
+	tiers, _ := entity.ParseTiers("Базовый:0:3,Серебро:3000:5")
+	return entity.Program{Tiers: tiers, PointValue: common.NewMoney(100), MaxRedeemPercent: 50}
+}
+
+func TestLoyaltyUsecase_Redeem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockLoyaltyRepository(ctrl)
+	loyalty := usecase.NewLoyaltyUsecase(mockRepo, testProgram())
+
+	ctx := context.Background()
+	customerID, orderID := uuid.New(), uuid.New()
+	total := common.NewMoney(50000)
+
+	mockRepo.EXPECT().LockCustomer(ctx, customerID).Return(userentity.RoleClient, nil).Times(3)
+	mockRepo.EXPECT().GetBalance(ctx, customerID).Return(int64(150), int64(400), nil).Times(2)
+	mockRepo.EXPECT().AddEntries(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, entries ...*entity.Entry) error {
+		if len(entries) != 1 || entries[0].Type != entity.EntryRedeem || entries[0].Points != -100 {
+			t.Errorf("неверная операция списания: %+v", entries)
+		}
+		return nil
+	})
+
+	paid, err := loyalty.Redeem(ctx, customerID, orderID, 100, total)
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if paid.Amount != 10000 {
+		t.Errorf("ожидали оплату 100 ₽, получили %s", paid)
+	}
+
+	// больше половины заказа
+	if _, err := loyalty.Redeem(ctx, customerID, orderID, 251, total); !errors.Is(err, usecase.ErrInvalidRedemption) {
+		t.Errorf("ожидали ErrInvalidRedemption, получили %v", err)
+	}
+	// больше баланса
+	if _, err := loyalty.Redeem(ctx, customerID, orderID, 200, total); !errors.Is(err, usecase.ErrInvalidRedemption) {
+		t.Errorf("ожидали ErrInvalidRedemption, получили %v", err)
+	}
+}
+
+func TestLoyaltyUsecase_Redeem_StaffNotEligible(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockLoyaltyRepository(ctrl)
+	loyalty := usecase.NewLoyaltyUsecase(mockRepo, testProgram())
+
+	mockRepo.EXPECT().LockCustomer(gomock.Any(), gomock.Any()).Return(userentity.RoleManager, nil)
+
+	_, err := loyalty.Redeem(context.Background(), uuid.New(), uuid.New(), 10, common.NewMoney(50000))
+	if !errors.Is(err, usecase.ErrInvalidRedemption) {
+		t.Errorf("ожидали ErrInvalidRedemption, получили %v", err)
+	}
+}
+
+func TestLoyaltyUsecase_EarnForOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockLoyaltyRepository(ctrl)
+	loyalty := usecase.NewLoyaltyUsecase(mockRepo, testProgram())
+
+	ctx := context.Background()
+	customerID, orderID := uuid.New(), uuid.New()
+
+	mockRepo.EXPECT().LockCustomer(ctx, customerID).Return(userentity.RoleClient, nil)
+	mockRepo.EXPECT().GetOrderEntries(ctx, orderID).Return(nil, nil)
+	mockRepo.EXPECT().GetBalance(ctx, customerID).Return(int64(100), int64(3200), nil)
+	mockRepo.EXPECT().AddEntries(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, entries ...*entity.Entry) error {
+		// уровень "Серебро": 5% от 400 ₽
+		if len(entries) != 1 || entries[0].Type != entity.EntryEarn || entries[0].Points != 20 {
+			t.Errorf("неверное начисление: %+v", entries)
+		}
+		return nil
+	})
+
+	if err := loyalty.EarnForOrder(ctx, customerID, orderID, common.NewMoney(40000)); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+}
+
+func TestLoyaltyUsecase_ReverseForOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockLoyaltyRepository(ctrl)
+	loyalty := usecase.NewLoyaltyUsecase(mockRepo, testProgram())
+
+	ctx := context.Background()
+	customerID, orderID := uuid.New(), uuid.New()
+
+	mockRepo.EXPECT().GetOrderEntries(ctx, orderID).Return([]*entity.Entry{
+		{Type: entity.EntryRedeem, Points: -100},
+		{Type: entity.EntryEarn, Points: 12},
+	}, nil)
+	mockRepo.EXPECT().LockCustomer(ctx, customerID).Return(userentity.RoleClient, nil)
+	mockRepo.EXPECT().AddEntries(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, entries ...*entity.Entry) error {
+		if len(entries) != 2 || entries[0].Points != -12 || entries[1].Points != 100 {
+			t.Errorf("неверные операции отмены: %+v, %+v", entries[0], entries[1])
+		}
+		return nil
+	})
+
+	if err := loyalty.ReverseForOrder(ctx, customerID, orderID); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+}
+
+func TestLoyaltyUsecase_ReverseForRefund(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockLoyaltyRepository(ctrl)
+	loyalty := usecase.NewLoyaltyUsecase(mockRepo, testProgram())
+
+	ctx := context.Background()
+	customerID, orderID := uuid.New(), uuid.New()
+
+	// за заказ на 400 ₽ начислено 12 баллов, 3 уже отменены прошлым возвратом на 100 ₽
+	mockRepo.EXPECT().GetOrderEntries(ctx, orderID).Return([]*entity.Entry{
+		{Type: entity.EntryRedeem, Points: -100},
+		{Type: entity.EntryEarn, Points: 12},
+		{Type: entity.EntryReversal, Points: -3},
+	}, nil)
+	mockRepo.EXPECT().LockCustomer(ctx, customerID).Return(userentity.RoleClient, nil)
+	mockRepo.EXPECT().AddEntries(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, entries ...*entity.Entry) error {
+		// после возвратов на 300 ₽ отменено 9 баллов, потраченные баллы остаются списанными
+		if len(entries) != 1 || entries[0].Type != entity.EntryReversal || entries[0].Points != -6 {
+			t.Errorf("неверные операции отмены: %+v", entries)
+		}
+		return nil
+	})
+
+	if err := loyalty.ReverseForRefund(ctx, customerID, orderID, common.NewMoney(30000), common.NewMoney(40000)); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+}
@@ -0,0 +1,361 @@
+package usecase
+
+import (
+	"coffe/internal/common"
+	"coffe/internal/loyalty/entity"
+	"coffe/internal/loyalty/repository"
+	userentity "coffe/internal/user/entity"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidStampCampaign возвращается при некорректных параметрах кампании штамп-карты.
+var ErrInvalidStampCampaign = errors.New("некорректная кампания штамп-карты")
+
+// StampUsecase ведет штамп-карты клиентов: начисляет штампы за выполненные заказы,
+// выдает награды за заполненные карты и применяет их к следующему заказу.
+type StampUsecase struct {
+	stampRepo   repository.StampRepository
+	loyaltyRepo repository.LoyaltyRepository
+}
+
+// NewStampUsecase создает новый экземпляр StampUsecase.
+func NewStampUsecase(stampRepo repository.StampRepository, loyaltyRepo repository.LoyaltyRepository) *StampUsecase {
+	return &StampUsecase{stampRepo: stampRepo, loyaltyRepo: loyaltyRepo}
+}
+
+// CreateCampaign создает кампанию штамп-карты.
+func (u *StampUsecase) CreateCampaign(ctx context.Context, campaign *entity.StampCampaign) error {
+	if err := u.validateCampaign(ctx, campaign); err != nil {
+		return err
+	}
+	return u.stampRepo.CreateCampaign(ctx, campaign)
+}
+
+// UpdateCampaign обновляет кампанию штамп-карты. Накопленные штампы и награды сохраняются.
+func (u *StampUsecase) UpdateCampaign(ctx context.Context, campaign *entity.StampCampaign) error {
+	existing, err := u.stampRepo.GetCampaignByID(ctx, campaign.ID)
+	if err != nil {
+		return fmt.Errorf("%w: кампания не найдена", ErrInvalidStampCampaign)
+	}
+	if err := u.validateCampaign(ctx, campaign); err != nil {
+		return err
+	}
+	campaign.CreatedAt = existing.CreatedAt
+	return u.stampRepo.UpdateCampaign(ctx, campaign)
+}
+
+// GetCampaigns возвращает все кампании штамп-карт.
+func (u *StampUsecase) GetCampaigns(ctx context.Context) ([]*entity.StampCampaign, error) {
+	return u.stampRepo.GetCampaigns(ctx, false)
+}
+
+// validateCampaign проверяет параметры кампании и существование продукта-награды.
+func (u *StampUsecase) validateCampaign(ctx context.Context, campaign *entity.StampCampaign) error {
+	if err := campaign.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidStampCampaign, err)
+	}
+	categories, err := u.stampRepo.GetProductCategories(ctx, []uuid.UUID{campaign.RewardProductID})
+	if err != nil {
+		return err
+	}
+	if _, ok := categories[campaign.RewardProductID]; !ok {
+		return fmt.Errorf("%w: продукт-награда не найден", ErrInvalidStampCampaign)
+	}
+	return nil
+}
+
+// ApplyRewards применяет доступные награды клиента к оформляемому заказу: каждая награда
+// делает бесплатной одну единицу продукта-награды по самой низкой цене в заказе.
+// Общая скидка не превышает limit. Вызывается в транзакции оформления заказа.
+func (u *StampUsecase) ApplyRewards(ctx context.Context, customerID, orderID uuid.UUID, lines []entity.StampLine, limit common.Money) ([]*entity.StampReward, error) {
+	role, err := u.loyaltyRepo.LockCustomer(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+	if role != userentity.RoleClient {
+		return nil, nil
+	}
+
+	rewards, err := u.stampRepo.GetAvailableRewards(ctx, customerID)
+	if err != nil || len(rewards) == 0 {
+		return nil, err
+	}
+
+	used := make([]int, len(lines))
+	now := time.Now()
+	var applied []*entity.StampReward
+	for _, reward := range rewards {
+		if !limit.IsPositive() {
+			break
+		}
+		cheapest := -1
+		for i, line := range lines {
+			if line.ProductID != reward.ProductID || used[i] >= line.Quantity {
+				continue
+			}
+			if cheapest < 0 || line.UnitPrice.Cmp(lines[cheapest].UnitPrice) < 0 {
+				cheapest = i
+			}
+		}
+		if cheapest < 0 {
+			continue
+		}
+
+		used[cheapest]++
+		reward.Status = entity.RewardRedeemed
+		reward.RedeemedOrderID = &orderID
+		reward.RedeemedAt = &now
+		reward.Amount = lines[cheapest].UnitPrice.Min(limit)
+		limit = limit.Sub(reward.Amount)
+		applied = append(applied, reward)
+	}
+
+	if err := u.stampRepo.SaveRewards(ctx, applied...); err != nil {
+		return nil, err
+	}
+	return applied, nil
+}
+
+// IssueForOrder начисляет штампы за выполненный заказ: по штампу за каждую единицу продукта
+// из категорий кампании, кроме полученных бесплатно по награде. Заполненные карты сразу
+// обмениваются на награды. Повторный вызов для того же заказа ничего не начисляет.
+func (u *StampUsecase) IssueForOrder(ctx context.Context, customerID, orderID uuid.UUID, lines []entity.StampLine) error {
+	role, err := u.loyaltyRepo.LockCustomer(ctx, customerID)
+	if err != nil {
+		return err
+	}
+	if role != userentity.RoleClient {
+		return nil
+	}
+
+	entries, err := u.stampRepo.GetOrderEntries(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.Type == entity.StampEarn {
+			return nil
+		}
+	}
+
+	campaigns, err := u.stampRepo.GetCampaigns(ctx, true)
+	if err != nil || len(campaigns) == 0 {
+		return err
+	}
+	if lines, err = u.withCategories(ctx, lines); err != nil {
+		return err
+	}
+	orderRewards, err := u.stampRepo.GetOrderRewards(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	balances, err := u.stampRepo.GetBalances(ctx, customerID)
+	if err != nil {
+		return err
+	}
+
+	var issued []*entity.StampEntry
+	var rewards []*entity.StampReward
+	for _, campaign := range campaigns {
+		stamps := 0
+		for _, line := range lines {
+			if campaign.Counts(line.Category) {
+				stamps += line.Quantity
+			}
+		}
+		for _, reward := range orderRewards {
+			if reward.CampaignID == campaign.ID && reward.RedeemedOrderID != nil && *reward.RedeemedOrderID == orderID {
+				stamps--
+			}
+		}
+		if stamps <= 0 {
+			continue
+		}
+
+		issued = append(issued, &entity.StampEntry{
+			CampaignID: campaign.ID, CustomerID: customerID, OrderID: &orderID, Type: entity.StampEarn, Stamps: stamps,
+		})
+		for balance := balances[campaign.ID] + stamps; balance >= campaign.StampsRequired; balance -= campaign.StampsRequired {
+			issued = append(issued, &entity.StampEntry{
+				CampaignID: campaign.ID, CustomerID: customerID, OrderID: &orderID, Type: entity.StampExchange, Stamps: -campaign.StampsRequired,
+			})
+			rewards = append(rewards, &entity.StampReward{
+				CampaignID:    campaign.ID,
+				CustomerID:    customerID,
+				ProductID:     campaign.RewardProductID,
+				Status:        entity.RewardAvailable,
+				EarnedOrderID: orderID,
+			})
+		}
+	}
+
+	if err := u.stampRepo.AddEntries(ctx, issued...); err != nil {
+		return err
+	}
+	return u.stampRepo.SaveRewards(ctx, rewards...)
+}
+
+// ReverseForOrder отменяет штампы и еще не использованные награды, полученные за отмененный
+// заказ, и возвращает клиенту награды, примененные к нему. Повторный вызов ничего не меняет.
+func (u *StampUsecase) ReverseForOrder(ctx context.Context, customerID, orderID uuid.UUID) error {
+	if _, err := u.loyaltyRepo.LockCustomer(ctx, customerID); err != nil {
+		return err
+	}
+
+	entries, err := u.stampRepo.GetOrderEntries(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	rewards, err := u.stampRepo.GetOrderRewards(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	issued := make(map[uuid.UUID]int)
+	var campaignIDs []uuid.UUID
+	for _, entry := range entries {
+		if entry.Type != entity.StampEarn && entry.Type != entity.StampReversal {
+			continue
+		}
+		if _, ok := issued[entry.CampaignID]; !ok {
+			campaignIDs = append(campaignIDs, entry.CampaignID)
+		}
+		issued[entry.CampaignID] += entry.Stamps
+	}
+
+	var reversals []*entity.StampEntry
+	var changed []*entity.StampReward
+	for _, reward := range rewards {
+		switch {
+		case reward.RedeemedOrderID != nil && *reward.RedeemedOrderID == orderID && reward.Status == entity.RewardRedeemed:
+			// награда снова доступна для следующего заказа
+			reward.Status = entity.RewardAvailable
+			reward.RedeemedOrderID = nil
+			reward.RedeemedAt = nil
+			reward.Amount = common.NewMoney(0)
+			changed = append(changed, reward)
+		case reward.EarnedOrderID == orderID && reward.Status == entity.RewardAvailable:
+			// неиспользованная награда отменяется, обмененные на нее штампы возвращаются
+			campaign, err := u.stampRepo.GetCampaignByID(ctx, reward.CampaignID)
+			if err != nil {
+				return err
+			}
+			reward.Status = entity.RewardCancelled
+			changed = append(changed, reward)
+			reversals = append(reversals, &entity.StampEntry{
+				CampaignID: reward.CampaignID, CustomerID: customerID, OrderID: &orderID, Type: entity.StampExchange, Stamps: campaign.StampsRequired,
+			})
+		}
+	}
+	for _, campaignID := range campaignIDs {
+		if stamps := issued[campaignID]; stamps > 0 {
+			reversals = append(reversals, &entity.StampEntry{
+				CampaignID: campaignID, CustomerID: customerID, OrderID: &orderID, Type: entity.StampReversal, Stamps: -stamps,
+			})
+		}
+	}
+
+	if err := u.stampRepo.SaveRewards(ctx, changed...); err != nil {
+		return err
+	}
+	return u.stampRepo.AddEntries(ctx, reversals...)
+}
+
+// ReverseForRefund отменяет штампы, начисленные за выполненный заказ, пропорционально
+// возвращенной сумме refunded из оплаченной деньгами paid, с учетом уже отмененных.
+// Полученные за заказ награды сохраняются, пока заказ не возвращен целиком: тогда
+// он отменяется, как ReverseForOrder.
+func (u *StampUsecase) ReverseForRefund(ctx context.Context, customerID, orderID uuid.UUID, refunded, paid common.Money) error {
+	if refunded.Cmp(paid) >= 0 {
+		return u.ReverseForOrder(ctx, customerID, orderID)
+	}
+	if _, err := u.loyaltyRepo.LockCustomer(ctx, customerID); err != nil {
+		return err
+	}
+
+	entries, err := u.stampRepo.GetOrderEntries(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	earned := make(map[uuid.UUID]int)
+	reversed := make(map[uuid.UUID]int)
+	var campaignIDs []uuid.UUID
+	for _, entry := range entries {
+		switch entry.Type {
+		case entity.StampEarn:
+			if _, ok := earned[entry.CampaignID]; !ok {
+				campaignIDs = append(campaignIDs, entry.CampaignID)
+			}
+			earned[entry.CampaignID] += entry.Stamps
+		case entity.StampReversal:
+			reversed[entry.CampaignID] -= entry.Stamps
+		}
+	}
+
+	var reversals []*entity.StampEntry
+	for _, campaignID := range campaignIDs {
+		stamps := int(refundShare(int64(earned[campaignID]), refunded, paid)) - reversed[campaignID]
+		if stamps > 0 {
+			reversals = append(reversals, &entity.StampEntry{
+				CampaignID: campaignID, CustomerID: customerID, OrderID: &orderID, Type: entity.StampReversal, Stamps: -stamps,
+			})
+		}
+	}
+	return u.stampRepo.AddEntries(ctx, reversals...)
+}
+
+// GetCards возвращает штамп-карты клиента по активным кампаниям.
+func (u *StampUsecase) GetCards(ctx context.Context, customerID uuid.UUID) ([]*entity.StampCard, error) {
+	if customerID == uuid.Nil {
+		return nil, errors.New("ID клиента не может быть пустым")
+	}
+
+	campaigns, err := u.stampRepo.GetCampaigns(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	balances, err := u.stampRepo.GetBalances(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+	rewards, err := u.stampRepo.GetAvailableRewards(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	cards := make([]*entity.StampCard, 0, len(campaigns))
+	for _, campaign := range campaigns {
+		card := &entity.StampCard{Campaign: campaign, Stamps: balances[campaign.ID]}
+		for _, reward := range rewards {
+			if reward.CampaignID == campaign.ID {
+				card.Rewards = append(card.Rewards, reward)
+			}
+		}
+		cards = append(cards, card)
+	}
+	return cards, nil
+}
+
+// withCategories заполняет категории продуктов в позициях заказа.
+func (u *StampUsecase) withCategories(ctx context.Context, lines []entity.StampLine) ([]entity.StampLine, error) {
+	productIDs := make([]uuid.UUID, 0, len(lines))
+	for _, line := range lines {
+		productIDs = append(productIDs, line.ProductID)
+	}
+	categories, err := u.stampRepo.GetProductCategories(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]entity.StampLine, len(lines))
+	for i, line := range lines {
+		line.Category = categories[line.ProductID]
+		result[i] = line
+	}
+	return result, nil
+}
@@ -0,0 +1,165 @@
+package usecase_test
+
+import (
+	"coffe/internal/common"
+	"coffe/internal/loyalty/entity"
+	"coffe/internal/loyalty/usecase"
+	"coffe/internal/loyalty/usecase/mocks"
+	userentity "coffe/internal/user/entity"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+)
+
+func TestStampUsecase_IssueForOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStampRepo := mocks.NewMockStampRepository(ctrl)
+	mockLoyaltyRepo := mocks.NewMockLoyaltyRepository(ctrl)
+	stamps := usecase.NewStampUsecase(mockStampRepo, mockLoyaltyRepo)
+
+	ctx := context.Background()
+	customerID, orderID := uuid.New(), uuid.New()
+	latte, croissant := uuid.New(), uuid.New()
+	campaign := &entity.StampCampaign{ID: uuid.New(), Categories: []string{"Кофе"}, StampsRequired: 6, RewardProductID: latte, IsActive: true}
+
+	mockLoyaltyRepo.EXPECT().LockCustomer(ctx, customerID).Return(userentity.RoleClient, nil)
+	mockStampRepo.EXPECT().GetOrderEntries(ctx, orderID).Return(nil, nil)
+	mockStampRepo.EXPECT().GetCampaigns(ctx, true).Return([]*entity.StampCampaign{campaign}, nil)
+	mockStampRepo.EXPECT().GetProductCategories(ctx, []uuid.UUID{latte, croissant}).Return(map[uuid.UUID]string{
+		latte:     "Кофе",
+		croissant: "Выпечка",
+	}, nil)
+	// один латте из трех получен бесплатно по награде
+	mockStampRepo.EXPECT().GetOrderRewards(ctx, orderID).Return([]*entity.StampReward{
+		{CampaignID: campaign.ID, Status: entity.RewardRedeemed, RedeemedOrderID: &orderID},
+	}, nil)
+	mockStampRepo.EXPECT().GetBalances(ctx, customerID).Return(map[uuid.UUID]int{campaign.ID: 5}, nil)
+	mockStampRepo.EXPECT().AddEntries(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, entries ...*entity.StampEntry) error {
+		// 5 + 2 штампа: карта заполнена, остается 1 штамп
+		if len(entries) != 2 || entries[0].Type != entity.StampEarn || entries[0].Stamps != 2 ||
+			entries[1].Type != entity.StampExchange || entries[1].Stamps != -6 {
+			t.Errorf("неверные операции со штампами: %+v", entries)
+		}
+		return nil
+	})
+	mockStampRepo.EXPECT().SaveRewards(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, rewards ...*entity.StampReward) error {
+		if len(rewards) != 1 || rewards[0].ProductID != latte || rewards[0].Status != entity.RewardAvailable || rewards[0].EarnedOrderID != orderID {
+			t.Errorf("неверная награда: %+v", rewards)
+		}
+		return nil
+	})
+
+	lines := []entity.StampLine{{ProductID: latte, Quantity: 3}, {ProductID: croissant, Quantity: 2}}
+	if err := stamps.IssueForOrder(ctx, customerID, orderID, lines); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+}
+
+func TestStampUsecase_ApplyRewards(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStampRepo := mocks.NewMockStampRepository(ctrl)
+	mockLoyaltyRepo := mocks.NewMockLoyaltyRepository(ctrl)
+	stamps := usecase.NewStampUsecase(mockStampRepo, mockLoyaltyRepo)
+
+	ctx := context.Background()
+	customerID, orderID := uuid.New(), uuid.New()
+	latte, tea := uuid.New(), uuid.New()
+
+	mockLoyaltyRepo.EXPECT().LockCustomer(ctx, customerID).Return(userentity.RoleClient, nil)
+	mockStampRepo.EXPECT().GetAvailableRewards(ctx, customerID).Return([]*entity.StampReward{
+		{ID: uuid.New(), ProductID: latte, Status: entity.RewardAvailable},
+		{ID: uuid.New(), ProductID: latte, Status: entity.RewardAvailable},
+		{ID: uuid.New(), ProductID: tea, Status: entity.RewardAvailable},
+	}, nil)
+	mockStampRepo.EXPECT().SaveRewards(ctx, gomock.Any()).Return(nil)
+
+	// латте L и латте S по одной штуке, чая в заказе нет
+	lines := []entity.StampLine{
+		{ProductID: latte, Quantity: 1, UnitPrice: common.NewMoney(30000)},
+		{ProductID: latte, Quantity: 1, UnitPrice: common.NewMoney(22000)},
+	}
+	applied, err := stamps.ApplyRewards(ctx, customerID, orderID, lines, common.NewMoney(40000))
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if len(applied) != 2 {
+		t.Fatalf("ожидали 2 награды, получили %d", len(applied))
+	}
+	// сначала бесплатным становится самый дешевый латте, вторая награда ограничена остатком суммы
+	if applied[0].Amount.Amount != 22000 || applied[1].Amount.Amount != 18000 {
+		t.Errorf("неверные суммы наград: %s, %s", applied[0].Amount, applied[1].Amount)
+	}
+	for _, reward := range applied {
+		if reward.Status != entity.RewardRedeemed || reward.RedeemedOrderID == nil || *reward.RedeemedOrderID != orderID {
+			t.Errorf("награда не отмечена использованной: %+v", reward)
+		}
+	}
+}
+
+func TestStampUsecase_ReverseForOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStampRepo := mocks.NewMockStampRepository(ctrl)
+	mockLoyaltyRepo := mocks.NewMockLoyaltyRepository(ctrl)
+	stamps := usecase.NewStampUsecase(mockStampRepo, mockLoyaltyRepo)
+
+	ctx := context.Background()
+	customerID, orderID := uuid.New(), uuid.New()
+	campaign := &entity.StampCampaign{ID: uuid.New(), StampsRequired: 6}
+	redeemed := &entity.StampReward{ID: uuid.New(), CampaignID: campaign.ID, Status: entity.RewardRedeemed, RedeemedOrderID: &orderID, Amount: common.NewMoney(22000)}
+	earned := &entity.StampReward{ID: uuid.New(), CampaignID: campaign.ID, Status: entity.RewardAvailable, EarnedOrderID: orderID}
+
+	mockLoyaltyRepo.EXPECT().LockCustomer(ctx, customerID).Return(userentity.RoleClient, nil)
+	mockStampRepo.EXPECT().GetOrderEntries(ctx, orderID).Return([]*entity.StampEntry{
+		{CampaignID: campaign.ID, Type: entity.StampEarn, Stamps: 2},
+		{CampaignID: campaign.ID, Type: entity.StampExchange, Stamps: -6},
+	}, nil)
+	mockStampRepo.EXPECT().GetOrderRewards(ctx, orderID).Return([]*entity.StampReward{redeemed, earned}, nil)
+	mockStampRepo.EXPECT().GetCampaignByID(ctx, campaign.ID).Return(campaign, nil)
+	mockStampRepo.EXPECT().SaveRewards(ctx, redeemed, earned).Return(nil)
+	mockStampRepo.EXPECT().AddEntries(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, entries ...*entity.StampEntry) error {
+		// штампы за обмен возвращаются, начисленные за заказ отменяются
+		if len(entries) != 2 || entries[0].Stamps != 6 || entries[1].Type != entity.StampReversal || entries[1].Stamps != -2 {
+			t.Errorf("неверные операции отмены: %+v", entries)
+		}
+		return nil
+	})
+
+	if err := stamps.ReverseForOrder(ctx, customerID, orderID); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if redeemed.Status != entity.RewardAvailable || redeemed.RedeemedOrderID != nil {
+		t.Errorf("примененная награда не возвращена: %+v", redeemed)
+	}
+	if earned.Status != entity.RewardCancelled {
+		t.Errorf("полученная награда не отменена: %+v", earned)
+	}
+}
+
+func TestStampUsecase_ReverseForRefund(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStampRepo := mocks.NewMockStampRepository(ctrl)
+	mockLoyaltyRepo := mocks.NewMockLoyaltyRepository(ctrl)
+	stamps := usecase.NewStampUsecase(mockStampRepo, mockLoyaltyRepo)
+
+	ctx := context.Background()
+	customerID, orderID := uuid.New(), uuid.New()
+	campaignID := uuid.New()
+
+	mockLoyaltyRepo.EXPECT().LockCustomer(ctx, customerID).Return(userentity.RoleClient, nil)
+	mockStampRepo.EXPECT().GetOrderEntries(ctx, orderID).Return([]*entity.StampEntry{
+		{CampaignID: campaignID, Type: entity.StampEarn, Stamps: 4},
+	}, nil)
+	mockStampRepo.EXPECT().AddEntries(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, entries ...*entity.StampEntry) error {
+		// возврат половины заказа отменяет половину штампов
+		if len(entries) != 1 || entries[0].Type != entity.StampReversal || entries[0].Stamps != -2 {
+			t.Errorf("неверные операции отмены: %+v", entries)
+		}
+		return nil
+	})
+
+	if err := stamps.ReverseForRefund(ctx, customerID, orderID, common.NewMoney(20000), common.NewMoney(40000)); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+}
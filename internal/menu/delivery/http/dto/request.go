@@ -1,22 +1,23 @@
 package dto
 
 import (
+	"coffe/internal/common"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type MenuSearchDTO struct {
-	Query       string     `json:"query"`
-	MenuID      uuid.UUID  `json:"menu_id"`
-	CategoryID  uuid.UUID  `json:"category_id"`
-	ProductID   uuid.UUID  `json:"product_id"`
-	IsActive    *bool      `json:"is_active"`
-	PriceRange  [2]float64 `json:"price_range"`
-	ValidAfter  *time.Time `json:"valid_after"`
-	ValidBefore *time.Time `json:"valid_before"`
-	Pagination  Pagination `json:"pagination"`
-	Sorting     Sorting    `json:"sorting"`
+	Query       string          `json:"query"`
+	MenuID      uuid.UUID       `json:"menu_id"`
+	CategoryID  uuid.UUID       `json:"category_id"`
+	ProductID   uuid.UUID       `json:"product_id"`
+	IsActive    *bool           `json:"is_active"`
+	PriceRange  [2]common.Money `json:"price_range"`
+	ValidAfter  *time.Time      `json:"valid_after"`
+	ValidBefore *time.Time      `json:"valid_before"`
+	Pagination  Pagination      `json:"pagination"`
+	Sorting     Sorting         `json:"sorting"`
 }
 
 type Pagination struct {
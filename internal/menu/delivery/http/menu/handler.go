@@ -1,6 +1,7 @@
 package http
 
 import (
+	"coffe/internal/common"
 	"coffe/internal/menu/delivery/http/dto"
 	"coffe/internal/menu/entity"
 	"coffe/internal/menu/usecase"
@@ -16,17 +17,68 @@ import (
 )
 
 type MenuHandler struct {
-	middleware  *middleware.JWTMiddleware
-	menuUsecase *usecase.MenuUsecase
+	middleware     *middleware.JWTMiddleware
+	menuUsecase    *usecase.MenuUsecase
+	productUsecase *usecase.ProductUsecase
+	taxUsecase     *usecase.TaxUsecase
 }
 
-func NewMenuHandler(middleware *middleware.JWTMiddleware, menuUsecase *usecase.MenuUsecase) *MenuHandler {
+func NewMenuHandler(middleware *middleware.JWTMiddleware, menuUsecase *usecase.MenuUsecase, productUsecase *usecase.ProductUsecase, taxUsecase *usecase.TaxUsecase) *MenuHandler {
 	return &MenuHandler{
-		middleware:  middleware,
-		menuUsecase: menuUsecase,
+		middleware:     middleware,
+		menuUsecase:    menuUsecase,
+		productUsecase: productUsecase,
+		taxUsecase:     taxUsecase,
 	}
 }
 
+// VariantRequest описывает вариант (размер) продукта
+type VariantRequest struct {
+	Name             string        `json:"name" binding:"required"`
+	Price            *common.Money `json:"price"`       // абсолютная цена, имеет приоритет над price_delta
+	PriceDelta       common.Money  `json:"price_delta"` // надбавка к цене продукта
+	RecipeMultiplier float64       `json:"recipe_multiplier"`
+	SortOrder        int           `json:"sort_order"`
+	IsDefault        bool          `json:"is_default"`
+	IsActive         *bool         `json:"is_active"` // по умолчанию true
+}
+
+// ModifierGroupRequest описывает группу модификаторов продукта
+type ModifierGroupRequest struct {
+	Name          string               `json:"name" binding:"required"`
+	SelectionType entity.SelectionType `json:"selection_type" binding:"required"` // "один" или "несколько"
+	MinChoices    int                  `json:"min_choices"`
+	MaxChoices    int                  `json:"max_choices"`
+	SortOrder     int                  `json:"sort_order"`
+}
+
+// ModifierRequest описывает модификатор: надбавку к цене и замену или добавку ингредиента
+type ModifierRequest struct {
+	Name                 string       `json:"name" binding:"required"`
+	PriceDelta           common.Money `json:"price_delta"`
+	ReplacesIngredientID *uuid.UUID   `json:"replaces_ingredient_id"`
+	IngredientID         *uuid.UUID   `json:"ingredient_id"`
+	Quantity             float64      `json:"quantity"`
+	Unit                 string       `json:"unit"`
+	SortOrder            int          `json:"sort_order"`
+	IsActive             *bool        `json:"is_active"` // по умолчанию true
+}
+
+// TaxRateRequest описывает ставку налога для категории продуктов
+type TaxRateRequest struct {
+	Category string     `json:"category"` // пусто - все категории
+	MenuID   *uuid.UUID `json:"menu_id"`  // пусто - все меню
+	Rate     *float64   `json:"rate" binding:"required"`
+	Name     string     `json:"name"`
+}
+
+// VariantIngredientRequest описывает ингредиент явного состава варианта
+type VariantIngredientRequest struct {
+	IngredientID uuid.UUID `json:"ingredient_id" binding:"required"`
+	Quantity     float64   `json:"quantity" binding:"required,gt=0"`
+	Unit         string    `json:"unit"`
+}
+
 func (h *MenuHandler) GetAllMenuItems(ctx *gin.Context) {
 
 	menus, err := h.menuUsecase.GetAll(ctx)
@@ -99,19 +151,19 @@ func (h *MenuHandler) GetMenuItemsByCategory(ctx *gin.Context) {
 
 func (h *MenuHandler) SearchMenuItems(ctx *gin.Context) {
 	var params struct {
-		Query       string  `form:"query"`
-		MenuID      string  `form:"menu_id"`
-		CategoryID  string  `form:"category_id"`
-		ProductID   string  `form:"product_id"`
-		IsActive    *bool   `form:"is_active"`
-		MinPrice    float64 `form:"min_price"`
-		MaxPrice    float64 `form:"max_price"`
-		ValidAfter  string  `form:"valid_after"`
-		ValidBefore string  `form:"valid_before"`
-		Page        int     `form:"page" binding:"min=1"`
-		PageSize    int     `form:"page_size" binding:"min=5,max=100"`
-		SortBy      string  `form:"sort_by" binding:"oneof=name price created_at sort_order"`
-		SortOrder   string  `form:"sort_order" binding:"oneof=asc desc"`
+		Query       string `form:"query"`
+		MenuID      string `form:"menu_id"`
+		CategoryID  string `form:"category_id"`
+		ProductID   string `form:"product_id"`
+		IsActive    *bool  `form:"is_active"`
+		MinPrice    string `form:"min_price"`
+		MaxPrice    string `form:"max_price"`
+		ValidAfter  string `form:"valid_after"`
+		ValidBefore string `form:"valid_before"`
+		Page        int    `form:"page" binding:"min=1"`
+		PageSize    int    `form:"page_size" binding:"min=5,max=100"`
+		SortBy      string `form:"sort_by" binding:"oneof=name price created_at sort_order"`
+		SortOrder   string `form:"sort_order" binding:"oneof=asc desc"`
 	}
 
 	if err := ctx.ShouldBindQuery(&params); err != nil {
@@ -159,6 +211,18 @@ func (h *MenuHandler) SearchMenuItems(ctx *gin.Context) {
 		}
 	}
 
+	var priceRange [2]common.Money
+	for i, raw := range []string{params.MinPrice, params.MaxPrice} {
+		if raw == "" {
+			continue
+		}
+		priceRange[i], err = common.ParseMoney(raw)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid price format, use 350.50"})
+			return
+		}
+	}
+
 	var validAfter, validBefore *time.Time
 	if params.ValidAfter != "" {
 		parsed, err := time.Parse("2006-01-02", params.ValidAfter)
@@ -184,7 +248,7 @@ func (h *MenuHandler) SearchMenuItems(ctx *gin.Context) {
 		CategoryID:  categoryID,
 		ProductID:   productID,
 		IsActive:    params.IsActive,
-		PriceRange:  [2]float64{params.MinPrice, params.MaxPrice},
+		PriceRange:  priceRange,
 		ValidAfter:  validAfter,
 		ValidBefore: validBefore,
 		Pagination: dto.Pagination{
@@ -215,7 +279,7 @@ func (h *MenuHandler) SearchMenuItems(ctx *gin.Context) {
 
 func (h *MenuHandler) GetAvailableItems(ctx *gin.Context) {
 
-	items, err := h.menuUsecase.GetActiveItems(ctx)
+	items, err := h.menuUsecase.GetAvailableItems(ctx)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -374,6 +438,55 @@ func (h *MenuHandler) UpdateAvailability(ctx *gin.Context) {
 	})
 }
 
+// позиции в стоп-листе (для персонала)
+func (h *MenuHandler) GetStopList(ctx *gin.Context) {
+	items, err := h.menuUsecase.GetStopList(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"items": items,
+		"total": len(items),
+	})
+}
+
+// ручное решение персонала по позиции: вернуть в продажу, снять с продажи или отдать автоматике
+func (h *MenuHandler) SetStopListOverride(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID позиции"})
+		return
+	}
+
+	var request struct {
+		Override entity.StopListOverride `json:"override"` // пустое значение - автоматический режим
+	}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат данных: " + err.Error()})
+		return
+	}
+	if !request.Override.IsValid() {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Неизвестное значение стоп-листа",
+			"allowed": []entity.StopListOverride{entity.StopListAuto, entity.StopListAvailable, entity.StopListStopped},
+		})
+		return
+	}
+
+	if err := h.menuUsecase.SetStopListOverride(ctx, id, request.Override); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			status = http.StatusNotFound
+		}
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Стоп-лист обновлен", "override": request.Override})
+}
+
 func (h *MenuHandler) GetCategories(ctx *gin.Context) {
 
 	category, err := h.menuUsecase.GetCategories(ctx)
@@ -495,3 +608,426 @@ func (h *MenuHandler) MenuItemActivation(ctx *gin.Context) {
 		"message": "Статус позиции обновлен",
 	})
 }
+
+// варианты продукта
+func (h *MenuHandler) GetProductVariants(ctx *gin.Context) {
+	productID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID продукта"})
+		return
+	}
+
+	variants, err := h.productUsecase.GetVariants(ctx, productID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"variants": variants, "total": len(variants)})
+}
+
+// добавление варианта продукта
+func (h *MenuHandler) CreateProductVariant(ctx *gin.Context) {
+	productID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID продукта"})
+		return
+	}
+
+	var request VariantRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат данных: " + err.Error()})
+		return
+	}
+
+	variant := request.toVariant(productID)
+	if err := h.productUsecase.CreateVariant(ctx, variant); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, variant)
+}
+
+// обновление варианта продукта
+func (h *MenuHandler) UpdateProductVariant(ctx *gin.Context) {
+	productID, variantID, ok := idParams(ctx, "variant_id", "Неверный формат ID варианта")
+	if !ok {
+		return
+	}
+
+	var request VariantRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат данных: " + err.Error()})
+		return
+	}
+
+	variant := request.toVariant(productID)
+	variant.ID = variantID
+	if err := h.productUsecase.UpdateVariant(ctx, variant); err != nil {
+		ctx.JSON(variantErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, variant)
+}
+
+// удаление варианта продукта
+func (h *MenuHandler) DeleteProductVariant(ctx *gin.Context) {
+	productID, variantID, ok := idParams(ctx, "variant_id", "Неверный формат ID варианта")
+	if !ok {
+		return
+	}
+
+	if err := h.productUsecase.DeleteVariant(ctx, productID, variantID); err != nil {
+		ctx.JSON(variantErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Вариант удален"})
+}
+
+// замена явного состава варианта; пустой список возвращает состав продукта с множителем
+func (h *MenuHandler) SetVariantIngredients(ctx *gin.Context) {
+	productID, variantID, ok := idParams(ctx, "variant_id", "Неверный формат ID варианта")
+	if !ok {
+		return
+	}
+
+	var request struct {
+		Ingredients []VariantIngredientRequest `json:"ingredients" binding:"dive"`
+	}
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат данных: " + err.Error()})
+		return
+	}
+
+	ingredients := make([]*entity.VariantIngredient, 0, len(request.Ingredients))
+	for _, item := range request.Ingredients {
+		ingredients = append(ingredients, &entity.VariantIngredient{
+			IngredientID: item.IngredientID,
+			Quantity:     item.Quantity,
+			Unit:         item.Unit,
+		})
+	}
+
+	if err := h.productUsecase.SetVariantIngredients(ctx, productID, variantID, ingredients); err != nil {
+		ctx.JSON(variantErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Состав варианта обновлен", "ingredients": ingredients})
+}
+
+func (r VariantRequest) toVariant(productID uuid.UUID) *entity.ProductVariant {
+	variant := &entity.ProductVariant{
+		ProductID:        productID,
+		Name:             r.Name,
+		Price:            r.Price,
+		PriceDelta:       r.PriceDelta,
+		RecipeMultiplier: r.RecipeMultiplier,
+		SortOrder:        r.SortOrder,
+		IsDefault:        r.IsDefault,
+		IsActive:         true,
+	}
+	if r.IsActive != nil {
+		variant.IsActive = *r.IsActive
+	}
+	return variant
+}
+
+// idParams разбирает ID продукта и ID вложенного объекта из параметра name
+func idParams(ctx *gin.Context, name, invalidMessage string) (uuid.UUID, uuid.UUID, bool) {
+	productID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID продукта"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	childID, err := uuid.Parse(ctx.Param(name))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": invalidMessage})
+		return uuid.Nil, uuid.Nil, false
+	}
+	return productID, childID, true
+}
+
+func variantErrorStatus(err error) int {
+	if errors.Is(err, usecase.ErrVariantNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusBadRequest
+}
+
+// группы модификаторов продукта
+func (h *MenuHandler) GetModifierGroups(ctx *gin.Context) {
+	productID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID продукта"})
+		return
+	}
+
+	groups, err := h.productUsecase.GetModifierGroups(ctx, productID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"modifier_groups": groups, "total": len(groups)})
+}
+
+// добавление группы модификаторов
+func (h *MenuHandler) CreateModifierGroup(ctx *gin.Context) {
+	productID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID продукта"})
+		return
+	}
+
+	var request ModifierGroupRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат данных: " + err.Error()})
+		return
+	}
+
+	group := request.toGroup(productID)
+	if err := h.productUsecase.CreateModifierGroup(ctx, group); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, group)
+}
+
+// обновление группы модификаторов
+func (h *MenuHandler) UpdateModifierGroup(ctx *gin.Context) {
+	productID, groupID, ok := idParams(ctx, "group_id", "Неверный формат ID группы")
+	if !ok {
+		return
+	}
+
+	var request ModifierGroupRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат данных: " + err.Error()})
+		return
+	}
+
+	group := request.toGroup(productID)
+	group.ID = groupID
+	if err := h.productUsecase.UpdateModifierGroup(ctx, group); err != nil {
+		ctx.JSON(modifierErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, group)
+}
+
+// удаление группы модификаторов
+func (h *MenuHandler) DeleteModifierGroup(ctx *gin.Context) {
+	productID, groupID, ok := idParams(ctx, "group_id", "Неверный формат ID группы")
+	if !ok {
+		return
+	}
+
+	if err := h.productUsecase.DeleteModifierGroup(ctx, productID, groupID); err != nil {
+		ctx.JSON(modifierErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Группа модификаторов удалена"})
+}
+
+// добавление модификатора в группу
+func (h *MenuHandler) CreateModifier(ctx *gin.Context) {
+	productID, groupID, ok := idParams(ctx, "group_id", "Неверный формат ID группы")
+	if !ok {
+		return
+	}
+
+	var request ModifierRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат данных: " + err.Error()})
+		return
+	}
+
+	modifier := request.toModifier(groupID)
+	if err := h.productUsecase.CreateModifier(ctx, productID, modifier); err != nil {
+		ctx.JSON(modifierErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, modifier)
+}
+
+// обновление модификатора
+func (h *MenuHandler) UpdateModifier(ctx *gin.Context) {
+	productID, groupID, ok := idParams(ctx, "group_id", "Неверный формат ID группы")
+	if !ok {
+		return
+	}
+	modifierID, err := uuid.Parse(ctx.Param("modifier_id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID модификатора"})
+		return
+	}
+
+	var request ModifierRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат данных: " + err.Error()})
+		return
+	}
+
+	modifier := request.toModifier(groupID)
+	modifier.ID = modifierID
+	if err := h.productUsecase.UpdateModifier(ctx, productID, modifier); err != nil {
+		ctx.JSON(modifierErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, modifier)
+}
+
+// удаление модификатора
+func (h *MenuHandler) DeleteModifier(ctx *gin.Context) {
+	productID, groupID, ok := idParams(ctx, "group_id", "Неверный формат ID группы")
+	if !ok {
+		return
+	}
+	modifierID, err := uuid.Parse(ctx.Param("modifier_id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID модификатора"})
+		return
+	}
+
+	if err := h.productUsecase.DeleteModifier(ctx, productID, groupID, modifierID); err != nil {
+		ctx.JSON(modifierErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Модификатор удален"})
+}
+
+// список ставок налога
+func (h *MenuHandler) GetTaxRates(ctx *gin.Context) {
+	rates, err := h.taxUsecase.GetRates(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения ставок налога"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"rates": rates,
+		"total": len(rates),
+	})
+}
+
+// создание ставки налога для категории и меню
+func (h *MenuHandler) CreateTaxRate(ctx *gin.Context) {
+	var request TaxRateRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат данных: " + err.Error()})
+		return
+	}
+
+	rate := request.toTaxRate()
+	if err := h.taxUsecase.CreateRate(ctx, rate); err != nil {
+		ctx.JSON(taxErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, rate)
+}
+
+// обновление ставки налога
+func (h *MenuHandler) UpdateTaxRate(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID ставки"})
+		return
+	}
+
+	var request TaxRateRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат данных: " + err.Error()})
+		return
+	}
+
+	rate := request.toTaxRate()
+	rate.ID = id
+	if err := h.taxUsecase.UpdateRate(ctx, rate); err != nil {
+		ctx.JSON(taxErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rate)
+}
+
+// удаление ставки налога
+func (h *MenuHandler) DeleteTaxRate(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID ставки"})
+		return
+	}
+
+	if err := h.taxUsecase.DeleteRate(ctx, id); err != nil {
+		ctx.JSON(taxErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Ставка налога удалена"})
+}
+
+func (r TaxRateRequest) toTaxRate() *entity.TaxRate {
+	return &entity.TaxRate{
+		Category: r.Category,
+		MenuID:   r.MenuID,
+		Rate:     *r.Rate,
+		Name:     r.Name,
+	}
+}
+
+func taxErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, usecase.ErrTaxRateNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, entity.ErrInvalidTaxRate):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+func (r ModifierGroupRequest) toGroup(productID uuid.UUID) *entity.ModifierGroup {
+	return &entity.ModifierGroup{
+		ProductID:     productID,
+		Name:          r.Name,
+		SelectionType: r.SelectionType,
+		MinChoices:    r.MinChoices,
+		MaxChoices:    r.MaxChoices,
+		SortOrder:     r.SortOrder,
+	}
+}
+
+func (r ModifierRequest) toModifier(groupID uuid.UUID) *entity.Modifier {
+	modifier := &entity.Modifier{
+		GroupID:              groupID,
+		Name:                 r.Name,
+		PriceDelta:           r.PriceDelta,
+		ReplacesIngredientID: r.ReplacesIngredientID,
+		IngredientID:         r.IngredientID,
+		Quantity:             r.Quantity,
+		Unit:                 r.Unit,
+		SortOrder:            r.SortOrder,
+		IsActive:             true,
+	}
+	if r.IsActive != nil {
+		modifier.IsActive = *r.IsActive
+	}
+	return modifier
+}
+
+func modifierErrorStatus(err error) int {
+	if errors.Is(err, usecase.ErrModifierNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusBadRequest
+}
@@ -11,6 +11,9 @@ func SetupMenuRoutes(router *gin.RouterGroup, handler *MenuHandler, middleware *
 	// Публичные маршруты меню
 	setupPublicMenuRoutes(router, handler)
 
+	// Маршруты персонала
+	setupStaffMenuRoutes(router, handler, middleware)
+
 	// Админские маршруты
 	setupAdminMenuRoutes(router, handler, middleware)
 }
@@ -30,6 +33,17 @@ func setupPublicMenuRoutes(router *gin.RouterGroup, handler *MenuHandler) {
 	}
 }
 
+// настраивает маршруты персонала для работы со стоп-листом
+func setupStaffMenuRoutes(router *gin.RouterGroup, handler *MenuHandler, middleware *middleware.JWTMiddleware) {
+	stopList := router.Group("/menu/stop-list")
+	stopList.Use(middleware.Authenticate())
+	stopList.Use(middleware.RequireRole("admin", "manager"))
+	{
+		stopList.GET("", handler.GetStopList)
+		stopList.PATCH("/:id", handler.SetStopListOverride)
+	}
+}
+
 // настраивает админские маршруты для управления меню
 func setupAdminMenuRoutes(router *gin.RouterGroup, handler *MenuHandler, middleware *middleware.JWTMiddleware) {
 	admin := router.Group("/admin/menu")
@@ -49,4 +63,41 @@ func setupAdminMenuRoutes(router *gin.RouterGroup, handler *MenuHandler, middlew
 		admin.GET("/categories", handler.GetCategories)
 		admin.POST("/categories", handler.CreateCategory)
 	}
+
+	// Варианты (размеры) продуктов
+	variants := router.Group("/admin/products/:id/variants")
+	variants.Use(middleware.Authenticate())
+	variants.Use(middleware.RequireRole("admin"))
+	{
+		variants.GET("", handler.GetProductVariants)
+		variants.POST("", handler.CreateProductVariant)
+		variants.PUT("/:variant_id", handler.UpdateProductVariant)
+		variants.DELETE("/:variant_id", handler.DeleteProductVariant)
+		variants.PUT("/:variant_id/ingredients", handler.SetVariantIngredients)
+	}
+
+	// Ставки налога по категориям и меню
+	taxRates := router.Group("/admin/tax-rates")
+	taxRates.Use(middleware.Authenticate())
+	taxRates.Use(middleware.RequireRole("admin"))
+	{
+		taxRates.GET("", handler.GetTaxRates)
+		taxRates.POST("", handler.CreateTaxRate)
+		taxRates.PUT("/:id", handler.UpdateTaxRate)
+		taxRates.DELETE("/:id", handler.DeleteTaxRate)
+	}
+
+	// Группы модификаторов продуктов
+	modifiers := router.Group("/admin/products/:id/modifier-groups")
+	modifiers.Use(middleware.Authenticate())
+	modifiers.Use(middleware.RequireRole("admin"))
+	{
+		modifiers.GET("", handler.GetModifierGroups)
+		modifiers.POST("", handler.CreateModifierGroup)
+		modifiers.PUT("/:group_id", handler.UpdateModifierGroup)
+		modifiers.DELETE("/:group_id", handler.DeleteModifierGroup)
+		modifiers.POST("/:group_id/modifiers", handler.CreateModifier)
+		modifiers.PUT("/:group_id/modifiers/:modifier_id", handler.UpdateModifier)
+		modifiers.DELETE("/:group_id/modifiers/:modifier_id", handler.DeleteModifier)
+	}
 }
@@ -20,26 +20,61 @@ type MenuCategory struct {
 // Menu представляет меню кофейни.
 type Menu struct {
 	ID          uuid.UUID      `json:"id" db:"id"`
-	Name        string         `json:"name" db:"name"`               // "Основное меню", "Сезонное меню"
-	Description string         `json:"description" db:"description"` // описание меню
-	Categories  []MenuCategory `json:"categories" db:"categories"`   // категории в меню
-	IsActive    bool           `json:"is_active" db:"is_active"`     // активно ли меню
-	ValidFrom   time.Time      `json:"valid_from" db:"valid_from"`   // с какого времени действует
-	ValidTo     *time.Time     `json:"valid_to" db:"valid_to"`       // до какого времени действует (nil = бессрочно)
+	Name        string         `json:"name" db:"name"`                      // "Основное меню", "Сезонное меню"
+	Description string         `json:"description" db:"description"`        // описание меню
+	Categories  []MenuCategory `json:"categories" db:"categories" gorm:"-"` // категории в меню
+	IsActive    bool           `json:"is_active" db:"is_active"`            // активно ли меню
+	ValidFrom   time.Time      `json:"valid_from" db:"valid_from"`          // с какого времени действует
+	ValidTo     *time.Time     `json:"valid_to" db:"valid_to"`              // до какого времени действует (nil = бессрочно)
 	CreatedAt   time.Time      `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at" db:"updated_at"`
 }
 
+// StopListOverride определяет ручное решение персонала поверх автоматического стоп-листа.
+type StopListOverride string
+
+const (
+	StopListAuto      StopListOverride = ""             // доступность определяется остатками на складе
+	StopListAvailable StopListOverride = "доступно"     // продавать, даже если по остаткам не хватает
+	StopListStopped   StopListOverride = "в стоп-листе" // не продавать, даже если ингредиенты есть
+)
+
+// IsValid проверяет, что значение входит в список известных.
+func (o StopListOverride) IsValid() bool {
+	switch o {
+	case StopListAuto, StopListAvailable, StopListStopped:
+		return true
+	}
+	return false
+}
+
 // MenuItem представляет позицию в меню.
 type MenuItem struct {
-	ID         uuid.UUID     `json:"id" db:"id"`
-	MenuID     uuid.UUID     `json:"menu_id" db:"menu_id"`
-	ProductID  uuid.UUID     `json:"product_id" db:"product_id"`
-	Product    *Product      `json:"product,omitempty" db:"product"`
-	CategoryID uuid.UUID     `json:"category_id" db:"category_id"`
-	Category   *MenuCategory `json:"category,omitempty" db:"category"`
-	SortOrder  int           `json:"sort_order" db:"sort_order"` // порядок в категории
-	IsActive   bool          `json:"is_active" db:"is_active"`   // активна ли позиция
-	CreatedAt  time.Time     `json:"created_at" db:"created_at"`
-	UpdatedAt  time.Time     `json:"updated_at" db:"updated_at"`
+	ID               uuid.UUID        `json:"id" db:"id"`
+	MenuID           uuid.UUID        `json:"menu_id" db:"menu_id"`
+	ProductID        uuid.UUID        `json:"product_id" db:"product_id"`
+	Product          *Product         `json:"product,omitempty" db:"product"`
+	CategoryID       uuid.UUID        `json:"category_id" db:"category_id"`
+	Category         *MenuCategory    `json:"category,omitempty" db:"category"`
+	SortOrder        int              `json:"sort_order" db:"sort_order"`                                                        // порядок в категории
+	IsActive         bool             `json:"is_active" db:"is_active"`                                                          // активна ли позиция
+	OutOfStock       bool             `json:"out_of_stock" db:"out_of_stock" gorm:"not null;default:false"`                      // автоматический стоп-лист: не хватает ингредиентов
+	OutOfStockReason string           `json:"out_of_stock_reason,omitempty" db:"out_of_stock_reason" gorm:"not null;default:''"` // каких ингредиентов не хватает
+	StopListOverride StopListOverride `json:"stop_list_override" db:"stop_list_override" gorm:"not null;default:''"`             // ручное решение персонала
+	CreatedAt        time.Time        `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time        `json:"updated_at" db:"updated_at"`
+}
+
+// IsAvailable сообщает, можно ли сейчас продавать позицию с учетом стоп-листа.
+func (i *MenuItem) IsAvailable() bool {
+	if !i.IsActive {
+		return false
+	}
+	switch i.StopListOverride {
+	case StopListAvailable:
+		return true
+	case StopListStopped:
+		return false
+	}
+	return !i.OutOfStock
 }
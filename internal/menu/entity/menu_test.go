@@ -0,0 +1,24 @@
+package entity_test
+
+import (
+	"coffe/internal/menu/entity"
+	"testing"
+)
+
+func TestMenuItem_IsAvailable(t *testing.T) {
+	tests := []struct {
+		item entity.MenuItem
+		want bool
+	}{
+		{entity.MenuItem{IsActive: true}, true},
+		{entity.MenuItem{IsActive: true, OutOfStock: true}, false},
+		{entity.MenuItem{IsActive: true, OutOfStock: true, StopListOverride: entity.StopListAvailable}, true},
+		{entity.MenuItem{IsActive: true, StopListOverride: entity.StopListStopped}, false},
+		{entity.MenuItem{IsActive: false, StopListOverride: entity.StopListAvailable}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.item.IsAvailable(); got != tt.want {
+			t.Errorf("%+v: IsAvailable() = %v, ожидали %v", tt.item, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package entity
+
+import (
+	"coffe/internal/common"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidModifiers возвращается, если выбор модификаторов не соответствует группам продукта.
+var ErrInvalidModifiers = errors.New("некорректный выбор модификаторов")
+
+// SelectionType определяет, сколько модификаторов группы можно выбрать.
+type SelectionType string
+
+const (
+	SelectionSingle SelectionType = "один"
+	SelectionMulti  SelectionType = "несколько"
+)
+
+// IsValid проверяет, что тип выбора входит в список известных значений.
+func (t SelectionType) IsValid() bool {
+	return t == SelectionSingle || t == SelectionMulti
+}
+
+// ModifierGroup представляет группу модификаторов продукта, например "Молоко" или "Сиропы".
+type ModifierGroup struct {
+	ID            uuid.UUID     `json:"id" db:"id"`
+	ProductID     uuid.UUID     `json:"product_id" db:"product_id" gorm:"index"`
+	Name          string        `json:"name" db:"name"`
+	SelectionType SelectionType `json:"selection_type" db:"selection_type"`
+	MinChoices    int           `json:"min_choices" db:"min_choices"` // 0 - группа необязательна
+	MaxChoices    int           `json:"max_choices" db:"max_choices"` // 0 - без ограничения
+	SortOrder     int           `json:"sort_order" db:"sort_order"`
+	Modifiers     []*Modifier   `json:"modifiers,omitempty" db:"modifiers" gorm:"foreignKey:GroupID"`
+	CreatedAt     time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time     `json:"updated_at" db:"updated_at"`
+}
+
+// Modifier представляет модификатор позиции: замену ингредиента ("овсяное молоко"),
+// добавку ("ванильный сироп", "доп. шот") или исключение ингредиента ("без сахара").
+type Modifier struct {
+	ID                   uuid.UUID    `json:"id" db:"id"`
+	GroupID              uuid.UUID    `json:"group_id" db:"group_id" gorm:"index"`
+	Name                 string       `json:"name" db:"name"`
+	PriceDelta           common.Money `json:"price_delta" db:"price_delta"`                                 // надбавка к цене позиции
+	ReplacesIngredientID *uuid.UUID   `json:"replaces_ingredient_id,omitempty" db:"replaces_ingredient_id"` // ингредиент состава, который убирается
+	IngredientID         *uuid.UUID   `json:"ingredient_id,omitempty" db:"ingredient_id"`                   // добавляемый ингредиент
+	Quantity             float64      `json:"quantity" db:"quantity"`                                       // количество на порцию; 0 при замене - как у заменяемого
+	Unit                 string       `json:"unit" db:"unit"`
+	Ingredient           *Ingredient  `json:"ingredient,omitempty" db:"ingredient"`
+	SortOrder            int          `json:"sort_order" db:"sort_order"`
+	IsActive             bool         `json:"is_active" db:"is_active"`
+	CreatedAt            time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt            time.Time    `json:"updated_at" db:"updated_at"`
+}
+
+// Validate проверяет ограничения группы модификаторов.
+func (g *ModifierGroup) Validate() error {
+	if g.Name == "" {
+		return errors.New("название группы не может быть пустым")
+	}
+	if !g.SelectionType.IsValid() {
+		return fmt.Errorf("неизвестный тип выбора %q", g.SelectionType)
+	}
+	if g.MinChoices < 0 || g.MaxChoices < 0 {
+		return errors.New("ограничения выбора не могут быть отрицательными")
+	}
+	if g.MaxChoices > 0 && g.MinChoices > g.MaxChoices {
+		return errors.New("минимум выбора больше максимума")
+	}
+	if g.SelectionType == SelectionSingle && g.MinChoices > 1 {
+		return errors.New("в группе с одиночным выбором минимум не может быть больше 1")
+	}
+	return nil
+}
+
+// Apply применяет модификатор к составу одной порции: убирает заменяемый ингредиент
+// и добавляет новый. Исходный состав не изменяется.
+func (m *Modifier) Apply(recipe []*ProductIngredient) []*ProductIngredient {
+	result := make([]*ProductIngredient, 0, len(recipe)+1)
+	var replaced *ProductIngredient
+	for _, ingredient := range recipe {
+		if m.ReplacesIngredientID != nil && ingredient.IngredientID == *m.ReplacesIngredientID {
+			replaced = ingredient
+			continue
+		}
+		result = append(result, ingredient)
+	}
+
+	if m.IngredientID == nil {
+		return result
+	}
+	added := &ProductIngredient{
+		IngredientID: *m.IngredientID,
+		Quantity:     m.Quantity,
+		Unit:         m.Unit,
+		Ingredient:   m.Ingredient,
+	}
+	if replaced != nil {
+		added.ProductID = replaced.ProductID
+		if added.Quantity == 0 {
+			added.Quantity, added.Unit = replaced.Quantity, replaced.Unit
+		}
+	}
+	if added.Quantity <= 0 {
+		return result
+	}
+	return append(result, added)
+}
+
+// SelectModifiers находит выбранные модификаторы среди активных модификаторов продукта
+// и проверяет ограничения групп. Возвращает ошибку, оборачивающую ErrInvalidModifiers.
+func (p *Product) SelectModifiers(ids []uuid.UUID) ([]*Modifier, error) {
+	chosen := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		if chosen[id] {
+			return nil, fmt.Errorf("%w: модификатор %s выбран дважды", ErrInvalidModifiers, id)
+		}
+		chosen[id] = true
+	}
+
+	selected := make([]*Modifier, 0, len(ids))
+	for _, group := range p.ModifierGroups {
+		count := 0
+		for _, modifier := range group.Modifiers {
+			if !chosen[modifier.ID] || !modifier.IsActive {
+				continue
+			}
+			delete(chosen, modifier.ID)
+			selected = append(selected, modifier)
+			count++
+		}
+
+		switch {
+		case count < group.MinChoices:
+			return nil, fmt.Errorf("%w: в группе %q нужно выбрать не меньше %d", ErrInvalidModifiers, group.Name, group.MinChoices)
+		case group.SelectionType == SelectionSingle && count > 1:
+			return nil, fmt.Errorf("%w: в группе %q можно выбрать только один вариант", ErrInvalidModifiers, group.Name)
+		case group.MaxChoices > 0 && count > group.MaxChoices:
+			return nil, fmt.Errorf("%w: в группе %q можно выбрать не больше %d", ErrInvalidModifiers, group.Name, group.MaxChoices)
+		}
+	}
+
+	for id := range chosen {
+		return nil, fmt.Errorf("%w: у продукта %s нет модификатора %s", ErrInvalidModifiers, p.Name, id)
+	}
+	return selected, nil
+}
@@ -0,0 +1,76 @@
+package entity_test
+
+import (
+	"coffe/internal/menu/entity"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestProduct_SelectModifiers(t *testing.T) {
+	oat := &entity.Modifier{ID: uuid.New(), Name: "Овсяное", IsActive: true}
+	soy := &entity.Modifier{ID: uuid.New(), Name: "Соевое", IsActive: true}
+	vanilla := &entity.Modifier{ID: uuid.New(), Name: "Ваниль", IsActive: true}
+	caramel := &entity.Modifier{ID: uuid.New(), Name: "Карамель", IsActive: true}
+	hidden := &entity.Modifier{ID: uuid.New(), Name: "Кокос", IsActive: false}
+
+	product := &entity.Product{Name: "Латте", ModifierGroups: []*entity.ModifierGroup{
+		{Name: "Молоко", SelectionType: entity.SelectionSingle, Modifiers: []*entity.Modifier{oat, soy, hidden}},
+		{Name: "Сиропы", SelectionType: entity.SelectionMulti, MaxChoices: 1, Modifiers: []*entity.Modifier{vanilla, caramel}},
+	}}
+
+	selected, err := product.SelectModifiers([]uuid.UUID{vanilla.ID, oat.ID})
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if len(selected) != 2 || selected[0] != oat || selected[1] != vanilla {
+		t.Errorf("модификаторы должны возвращаться в порядке групп, получили %+v", selected)
+	}
+
+	invalid := map[string][]uuid.UUID{
+		"два молока в одиночной группе": {oat.ID, soy.ID},
+		"больше максимума":              {vanilla.ID, caramel.ID},
+		"неактивный модификатор":        {hidden.ID},
+		"чужой модификатор":             {uuid.New()},
+		"повтор":                        {oat.ID, oat.ID},
+	}
+	for name, ids := range invalid {
+		if _, err := product.SelectModifiers(ids); !errors.Is(err, entity.ErrInvalidModifiers) {
+			t.Errorf("%s: ожидали ErrInvalidModifiers, получили %v", name, err)
+		}
+	}
+
+	product.ModifierGroups[0].MinChoices = 1
+	if _, err := product.SelectModifiers(nil); !errors.Is(err, entity.ErrInvalidModifiers) {
+		t.Errorf("обязательная группа: ожидали ErrInvalidModifiers, получили %v", err)
+	}
+}
+
+func TestModifier_Apply(t *testing.T) {
+	milk, oatMilk, syrup, beans := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	recipe := []*entity.ProductIngredient{
+		{IngredientID: beans, Quantity: 18, Unit: "g"},
+		{IngredientID: milk, Quantity: 200, Unit: "ml"},
+	}
+
+	// замена без количества берет количество заменяемого ингредиента
+	replaced := (&entity.Modifier{ReplacesIngredientID: &milk, IngredientID: &oatMilk}).Apply(recipe)
+	if len(replaced) != 2 || replaced[1].IngredientID != oatMilk || replaced[1].Quantity != 200 || replaced[1].Unit != "ml" {
+		t.Errorf("неверная замена молока: %+v", replaced)
+	}
+
+	added := (&entity.Modifier{IngredientID: &syrup, Quantity: 10, Unit: "ml"}).Apply(recipe)
+	if len(added) != 3 || added[2].IngredientID != syrup {
+		t.Errorf("сироп должен добавиться к составу: %+v", added)
+	}
+
+	removed := (&entity.Modifier{ReplacesIngredientID: &milk}).Apply(recipe)
+	if len(removed) != 1 || removed[0].IngredientID != beans {
+		t.Errorf("молоко должно быть исключено: %+v", removed)
+	}
+
+	if len(recipe) != 2 || recipe[1].IngredientID != milk {
+		t.Error("исходный состав не должен меняться")
+	}
+}
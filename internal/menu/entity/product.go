@@ -1,31 +1,40 @@
 package entity
 
 import (
+	"coffe/internal/common"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// ErrIngredientInUse возвращается при смене единицы или плотности ингредиента,
+// который уже есть в складском журнале, рецептах или закупочных ценах.
+var ErrIngredientInUse = errors.New("единицу и плотность используемого ингредиента менять нельзя")
+
 // Product представляет продукт меню.
 type Product struct {
-	ID          uuid.UUID     `json:"id" db:"id"`
-	Name        string        `json:"name" db:"name"`
-	Category    string        `json:"category" db:"category"` // "coffee", "dessert"
-	Price       float64       `json:"price" db:"price"`
-	Description string        `json:"description" db:"description"`
-	IsActive    bool          `json:"is_active" db:"is_active"`
-	ImageURL    string        `json:"image_url" db:"image_url"`
-	CreatedAt   time.Time     `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time     `json:"updated_at" db:"updated_at"`
-	Ingredients []*Ingredient `json:"ingredients,omitempty" db:"ingredients"` // ингредиенты продукта
+	ID             uuid.UUID         `json:"id" db:"id"`
+	Name           string            `json:"name" db:"name"`
+	Category       string            `json:"category" db:"category"` // "coffee", "dessert"
+	Price          common.Money      `json:"price" db:"price"`
+	Description    string            `json:"description" db:"description"`
+	IsActive       bool              `json:"is_active" db:"is_active"`
+	ImageURL       string            `json:"image_url" db:"image_url"`
+	CreatedAt      time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at" db:"updated_at"`
+	Ingredients    []*Ingredient     `json:"ingredients,omitempty" db:"ingredients" gorm:"many2many:product_ingredients"` // ингредиенты продукта
+	Variants       []*ProductVariant `json:"variants,omitempty" db:"variants" gorm:"foreignKey:ProductID"`                // варианты (размеры) продукта
+	ModifierGroups []*ModifierGroup  `json:"modifier_groups,omitempty" db:"modifier_groups" gorm:"foreignKey:ProductID"`  // группы модификаторов продукта
 }
 
 // Ingredient представляет ингредиент продукта.
 type Ingredient struct {
 	ID       uuid.UUID `json:"id" db:"id"`
 	Name     string    `json:"name" db:"name"`
-	Quantity float64   `json:"quantity" db:"quantity"`
-	Unit     string    `json:"unit" db:"unit"` // "ml", "g", "шт"
+	Quantity float64   `json:"quantity" db:"quantity" gorm:"->;-:migration"` // текущий остаток, вычисляется по складскому журналу
+	Unit     string    `json:"unit" db:"unit"`                               // "ml", "g", "шт"
+	Density  float64   `json:"density,omitempty" db:"density"`               // плотность в г/мл для пересчета массы и объема, 0 - не задана
 }
 
 // ProductIngredient представляет связь между продуктом и ингредиентом.
@@ -0,0 +1,101 @@
+package entity
+
+import (
+	"coffe/internal/common"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidTaxRate возвращается при некорректной ставке налога.
+var ErrInvalidTaxRate = errors.New("некорректная ставка налога")
+
+// TaxRate задает ставку НДС для категории продуктов. Ставка с MenuID действует только
+// в заказах из этого меню (например, навынос или в зале) и приоритетнее общей.
+// Пустая категория означает ставку для всех категорий.
+type TaxRate struct {
+	ID        uuid.UUID  `json:"id" db:"id"`
+	Category  string     `json:"category" db:"category" gorm:"index"`         // категория продукта, пусто - все категории
+	MenuID    *uuid.UUID `json:"menu_id,omitempty" db:"menu_id" gorm:"index"` // меню, nil - все меню
+	Rate      float64    `json:"rate" db:"rate"`                              // ставка в процентах: 0, 5, 7, 10, 20, 22
+	Name      string     `json:"name" db:"name"`                              // "НДС 20%"
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// Validate проверяет ставку налога: допускаются только ставки НДС, которые принимает
+// фискальный регистратор, иначе по заказу нельзя будет пробить чек.
+func (r *TaxRate) Validate() error {
+	switch r.Rate {
+	case 0, 5, 7, 10, 20, 22:
+		return nil
+	}
+	return fmt.Errorf("%w: %g%%, допустимы 0, 5, 7, 10, 20 и 22", ErrInvalidTaxRate, r.Rate)
+}
+
+// sameScope сообщает, что ставки заданы для одной категории и одного меню.
+func (r *TaxRate) sameScope(o *TaxRate) bool {
+	if r.Category != o.Category || (r.MenuID == nil) != (o.MenuID == nil) {
+		return false
+	}
+	return r.MenuID == nil || *r.MenuID == *o.MenuID
+}
+
+// Conflicts сообщает, что среди rates уже есть другая ставка для той же категории и меню.
+func (r *TaxRate) Conflicts(rates []*TaxRate) bool {
+	for _, rate := range rates {
+		if rate.ID != r.ID && r.sameScope(rate) {
+			return true
+		}
+	}
+	return false
+}
+
+// TaxPolicy содержит правила налогообложения заказа из одного меню.
+type TaxPolicy struct {
+	MenuID      *uuid.UUID // меню заказа, nil - общие ставки
+	Inclusive   bool       // цены меню уже включают налог
+	DefaultRate float64    // ставка, если для категории ничего не задано
+	Rates       []*TaxRate // ставки меню и общие ставки
+}
+
+// Rate возвращает ставку для категории. Приоритет: ставка меню для категории,
+// ставка меню для всех категорий, общая ставка категории, общая ставка, ставка по умолчанию.
+func (p *TaxPolicy) Rate(category string) float64 {
+	best, bestRank := p.DefaultRate, 0
+	for _, rate := range p.Rates {
+		var rank int
+		switch {
+		case rate.MenuID != nil && (p.MenuID == nil || *rate.MenuID != *p.MenuID):
+			continue
+		case rate.Category != "" && rate.Category != category:
+			continue
+		case rate.MenuID != nil && rate.Category != "":
+			rank = 4
+		case rate.MenuID != nil:
+			rank = 3
+		case rate.Category != "":
+			rank = 2
+		default:
+			rank = 1
+		}
+		if rank > bestRank {
+			best, bestRank = rate.Rate, rank
+		}
+	}
+	return best
+}
+
+// Split делит сумму позиции на сумму без налога, налог и сумму с налогом. При цене
+// с налогом он выделяется из суммы, иначе начисляется сверху.
+func (p *TaxPolicy) Split(amount common.Money, rate float64) (net, tax, gross common.Money) {
+	if p.Inclusive {
+		tax = common.Money{Amount: int64(math.Round(float64(amount.Amount) * rate / (100 + rate))), Currency: amount.Currency}
+		return amount.Sub(tax), tax, amount
+	}
+	tax = amount.Percent(rate)
+	return amount, tax, amount.Add(tax)
+}
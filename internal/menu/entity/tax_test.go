@@ -0,0 +1,88 @@
+package entity_test
+
+import (
+	"coffe/internal/common"
+	"coffe/internal/menu/entity"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestTaxPolicy_Rate(t *testing.T) {
+	takeaway, dineIn := uuid.New(), uuid.New()
+	rates := []*entity.TaxRate{
+		{Rate: 20},
+		{Category: "Десерты", Rate: 10},
+		{MenuID: &takeaway, Rate: 10},
+		{Category: "Кофе", MenuID: &dineIn, Rate: 0},
+	}
+
+	tests := []struct {
+		name     string
+		menuID   *uuid.UUID
+		category string
+		want     float64
+	}{
+		{"общая ставка", nil, "Кофе", 20},
+		{"общая ставка категории", nil, "Десерты", 10},
+		{"ставка меню для всех категорий", &takeaway, "Кофе", 10},
+		{"ставка меню для категории", &dineIn, "Кофе", 0},
+		{"в другом меню ставка категории меню не действует", &dineIn, "Чай", 20},
+	}
+	for _, tt := range tests {
+		policy := &entity.TaxPolicy{MenuID: tt.menuID, DefaultRate: 5, Rates: rates}
+		if got := policy.Rate(tt.category); got != tt.want {
+			t.Errorf("%s: ожидали %g, получили %g", tt.name, tt.want, got)
+		}
+	}
+
+	if got := (&entity.TaxPolicy{DefaultRate: 5}).Rate("Кофе"); got != 5 {
+		t.Errorf("без ставок ожидали ставку по умолчанию, получили %g", got)
+	}
+}
+
+func TestTaxPolicy_Split(t *testing.T) {
+	// 120 ₽ с НДС 20%: налог выделяется из цены
+	inclusive := &entity.TaxPolicy{Inclusive: true}
+	net, tax, gross := inclusive.Split(common.NewMoney(12000), 20)
+	if net.Amount != 10000 || tax.Amount != 2000 || gross.Amount != 12000 {
+		t.Errorf("цена с налогом: нетто %d, налог %d, брутто %d", net.Amount, tax.Amount, gross.Amount)
+	}
+
+	// 100 ₽ без НДС: налог начисляется сверху
+	exclusive := &entity.TaxPolicy{}
+	net, tax, gross = exclusive.Split(common.NewMoney(10000), 20)
+	if net.Amount != 10000 || tax.Amount != 2000 || gross.Amount != 12000 {
+		t.Errorf("цена без налога: нетто %d, налог %d, брутто %d", net.Amount, tax.Amount, gross.Amount)
+	}
+}
+
+func TestTaxRate_Conflicts(t *testing.T) {
+	menuID := uuid.New()
+	existing := []*entity.TaxRate{{ID: uuid.New(), Category: "Кофе", MenuID: &menuID, Rate: 10}}
+
+	sameMenu := menuID
+	if !(&entity.TaxRate{ID: uuid.New(), Category: "Кофе", MenuID: &sameMenu}).Conflicts(existing) {
+		t.Error("вторая ставка для той же категории и меню должна конфликтовать")
+	}
+	if (&entity.TaxRate{ID: uuid.New(), Category: "Кофе"}).Conflicts(existing) {
+		t.Error("общая ставка категории не должна конфликтовать со ставкой меню")
+	}
+	if existing[0].Conflicts(existing) {
+		t.Error("ставка не должна конфликтовать сама с собой")
+	}
+}
+
+func TestTaxRate_Validate(t *testing.T) {
+	for _, rate := range []float64{0, 5, 7, 10, 20, 22} {
+		if err := (&entity.TaxRate{Rate: rate}).Validate(); err != nil {
+			t.Errorf("ставка %g%% должна быть допустимой: %v", rate, err)
+		}
+	}
+	for _, rate := range []float64{-1, 12.5, 18, 100} {
+		if err := (&entity.TaxRate{Rate: rate}).Validate(); !errors.Is(err, entity.ErrInvalidTaxRate) {
+			t.Errorf("ставка %g%%: ожидали ErrInvalidTaxRate, получили %v", rate, err)
+		}
+	}
+}
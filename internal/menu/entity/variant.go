@@ -0,0 +1,93 @@
+package entity
+
+import (
+	"coffe/internal/common"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProductVariant представляет вариант продукта, например размер "S", "M" или "L".
+// Цена варианта задается абсолютно (Price) или надбавкой к цене продукта (PriceDelta).
+// Состав варианта задается явно (Ingredients) или множителем к составу продукта.
+type ProductVariant struct {
+	ID               uuid.UUID            `json:"id" db:"id"`
+	ProductID        uuid.UUID            `json:"product_id" db:"product_id" gorm:"index"`
+	Name             string               `json:"name" db:"name"`                                                     // "S", "M", "L", "0.3 л"
+	Price            *common.Money        `json:"price,omitempty" db:"price"`                                         // абсолютная цена, nil - цена продукта + PriceDelta
+	PriceDelta       common.Money         `json:"price_delta" db:"price_delta"`                                       // надбавка к цене продукта
+	RecipeMultiplier float64              `json:"recipe_multiplier" db:"recipe_multiplier"`                           // множитель состава продукта, 0 - как у продукта
+	Ingredients      []*VariantIngredient `json:"ingredients,omitempty" db:"ingredients" gorm:"foreignKey:VariantID"` // явный состав, заменяет состав продукта
+	SortOrder        int                  `json:"sort_order" db:"sort_order"`
+	IsDefault        bool                 `json:"is_default" db:"is_default"` // выбирается, если клиент не указал вариант
+	IsActive         bool                 `json:"is_active" db:"is_active"`
+	CreatedAt        time.Time            `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time            `json:"updated_at" db:"updated_at"`
+}
+
+// VariantIngredient представляет ингредиент в явном составе варианта продукта.
+type VariantIngredient struct {
+	VariantID    uuid.UUID   `json:"variant_id" db:"variant_id" gorm:"primaryKey"`
+	IngredientID uuid.UUID   `json:"ingredient_id" db:"ingredient_id" gorm:"primaryKey"`
+	Quantity     float64     `json:"quantity" db:"quantity"`
+	Unit         string      `json:"unit" db:"unit"`
+	Ingredient   *Ingredient `json:"ingredient,omitempty" db:"ingredient"`
+}
+
+// PriceFor возвращает цену варианта для продукта с базовой ценой base.
+func (v *ProductVariant) PriceFor(base common.Money) common.Money {
+	if v.Price != nil {
+		return *v.Price
+	}
+	return base.Add(v.PriceDelta)
+}
+
+// Recipe возвращает состав варианта на одну порцию: явный состав, если он задан,
+// иначе состав продукта base, умноженный на RecipeMultiplier.
+func (v *ProductVariant) Recipe(base []*ProductIngredient) []*ProductIngredient {
+	if len(v.Ingredients) > 0 {
+		recipe := make([]*ProductIngredient, 0, len(v.Ingredients))
+		for _, ingredient := range v.Ingredients {
+			recipe = append(recipe, &ProductIngredient{
+				ProductID:    v.ProductID,
+				IngredientID: ingredient.IngredientID,
+				Quantity:     ingredient.Quantity,
+				Unit:         ingredient.Unit,
+				Ingredient:   ingredient.Ingredient,
+			})
+		}
+		return recipe
+	}
+
+	multiplier := v.RecipeMultiplier
+	if multiplier <= 0 {
+		multiplier = 1
+	}
+	recipe := make([]*ProductIngredient, 0, len(base))
+	for _, ingredient := range base {
+		scaled := *ingredient
+		scaled.Quantity *= multiplier
+		recipe = append(recipe, &scaled)
+	}
+	return recipe
+}
+
+// DefaultVariant возвращает активный вариант по умолчанию или nil, если его нет.
+func (p *Product) DefaultVariant() *ProductVariant {
+	for _, variant := range p.Variants {
+		if variant.IsDefault && variant.IsActive {
+			return variant
+		}
+	}
+	return nil
+}
+
+// Variant возвращает вариант продукта по ID или nil, если такого варианта нет.
+func (p *Product) Variant(id uuid.UUID) *ProductVariant {
+	for _, variant := range p.Variants {
+		if variant.ID == id {
+			return variant
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package entity_test
+
+import (
+	"coffe/internal/common"
+	"coffe/internal/menu/entity"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestProductVariant_PriceFor(t *testing.T) {
+	base := common.NewMoney(20000)
+	absolute := common.NewMoney(30000)
+
+	tests := []struct {
+		name    string
+		variant entity.ProductVariant
+		want    int64
+	}{
+		{"надбавка", entity.ProductVariant{PriceDelta: common.NewMoney(5000)}, 25000},
+		{"абсолютная цена", entity.ProductVariant{Price: &absolute, PriceDelta: common.NewMoney(5000)}, 30000},
+		{"без надбавки", entity.ProductVariant{}, 20000},
+	}
+	for _, tt := range tests {
+		if got := tt.variant.PriceFor(base); got.Amount != tt.want {
+			t.Errorf("%s: ожидали %d, получили %d", tt.name, tt.want, got.Amount)
+		}
+	}
+}
+
+func TestProductVariant_Recipe(t *testing.T) {
+	milk, syrup := uuid.New(), uuid.New()
+	base := []*entity.ProductIngredient{{IngredientID: milk, Quantity: 200, Unit: "ml"}}
+
+	scaled := (&entity.ProductVariant{RecipeMultiplier: 1.5}).Recipe(base)
+	if len(scaled) != 1 || scaled[0].Quantity != 300 {
+		t.Errorf("ожидали 300 мл молока, получили %+v", scaled)
+	}
+	if base[0].Quantity != 200 {
+		t.Error("множитель не должен менять состав продукта")
+	}
+
+	explicit := (&entity.ProductVariant{
+		RecipeMultiplier: 2,
+		Ingredients:      []*entity.VariantIngredient{{IngredientID: syrup, Quantity: 10, Unit: "ml"}},
+	}).Recipe(base)
+	if len(explicit) != 1 || explicit[0].IngredientID != syrup || explicit[0].Quantity != 10 {
+		t.Errorf("явный состав должен заменять состав продукта, получили %+v", explicit)
+	}
+}
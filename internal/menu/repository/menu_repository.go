@@ -10,15 +10,15 @@ import (
 
 type MenuRepository interface {
 	// Методы для работы с меню
-	Create(ctx context.Context, menu *entity.Menu) error                                        // создание меню
-	GetByID(ctx context.Context, id uuid.UUID) (*entity.Menu, error)                            // меню по id
-	GetActive(ctx context.Context) ([]*entity.Menu, error)                                      // активные меню
-	GetAll(ctx context.Context) ([]*entity.Menu, error)                                         // все меню
-	Update(ctx context.Context, menu *entity.Menu) error                                        // обновление меню
-	Delete(ctx context.Context, id uuid.UUID) error                                             // удаление меню
-	Activate(ctx context.Context, id uuid.UUID) error                                           // активировать меню
-	Deactivate(ctx context.Context, id uuid.UUID) error                                         // деактивировать меню
-	SearchMenuItems(ctx context.Context, search *dto.MenuSearchDTO) ([]*entity.MenuItem, error) // поиск позиций меню
+	Create(ctx context.Context, menu *entity.Menu) error                                               // создание меню
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Menu, error)                                   // меню по id
+	GetActive(ctx context.Context) ([]*entity.Menu, error)                                             // активные меню
+	GetAll(ctx context.Context) ([]*entity.Menu, error)                                                // все меню
+	Update(ctx context.Context, menu *entity.Menu) error                                               // обновление меню
+	Delete(ctx context.Context, id uuid.UUID) error                                                    // удаление меню
+	Activate(ctx context.Context, id uuid.UUID) error                                                  // активировать меню
+	Deactivate(ctx context.Context, id uuid.UUID) error                                                // деактивировать меню
+	SearchMenuItems(ctx context.Context, search *dto.MenuSearchDTO) ([]*entity.MenuItem, int64, error) // поиск позиций меню и общее количество
 
 	// Методы для работы с категориями
 	CreateCategory(ctx context.Context, category *entity.MenuCategory) error                   // создание категории
@@ -38,6 +38,13 @@ type MenuRepository interface {
 	ActivateMenuItem(ctx context.Context, id uuid.UUID) error                                 // активировать позицию
 	DeactivateMenuItem(ctx context.Context, id uuid.UUID) error                               // деактивировать позицию
 	GetMenuItemsByMenu(ctx context.Context, menuID uuid.UUID) ([]*entity.MenuItem, error)     // позиции меню
+	IsProductInActiveMenu(ctx context.Context, productID uuid.UUID) (bool, error)             // продается ли продукт в действующем меню
+
+	// Стоп-лист
+	GetAvailableItems(ctx context.Context) ([]*entity.MenuItem, error)                                 // активные позиции вне стоп-листа
+	GetStopList(ctx context.Context) ([]*entity.MenuItem, error)                                       // позиции, снятые с продажи стоп-листом
+	SetOutOfStock(ctx context.Context, productID uuid.UUID, outOfStock bool, reason string) error      // автоматическая отметка позиций продукта
+	SetStopListOverride(ctx context.Context, itemID uuid.UUID, override entity.StopListOverride) error // ручное решение персонала
 
 	// Утилиты
 	Count(ctx context.Context) (int64, error)               // количество меню
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"coffe/internal/menu/entity"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// TaxRepository определяет методы для работы со ставками налога.
+type TaxRepository interface {
+	CreateRate(ctx context.Context, rate *entity.TaxRate) error                        // создание ставки
+	UpdateRate(ctx context.Context, rate *entity.TaxRate) error                        // обновление ставки
+	DeleteRate(ctx context.Context, id uuid.UUID) error                                // удаление ставки
+	GetRateByID(ctx context.Context, id uuid.UUID) (*entity.TaxRate, error)            // ставка по id
+	GetRates(ctx context.Context) ([]*entity.TaxRate, error)                           // все ставки
+	GetRatesForMenu(ctx context.Context, menuID *uuid.UUID) ([]*entity.TaxRate, error) // общие ставки и ставки меню
+}
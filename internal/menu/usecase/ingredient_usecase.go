@@ -3,6 +3,7 @@ package usecase
 import (
 	"coffe/internal/menu/entity"
 	"coffe/internal/menu/repository"
+	"coffe/internal/units"
 	"context"
 	"errors"
 
@@ -188,11 +189,14 @@ func (uc *IngredientUsecase) validateIngredient(ingredient *entity.Ingredient) e
 	if ingredient.Name == "" {
 		return errors.New("название ингредиента не может быть пустым")
 	}
-	if ingredient.Quantity <= 0 {
-		return errors.New("количество ингредиента должно быть больше нуля")
+	if ingredient.Quantity < 0 {
+		return errors.New("начальный остаток не может быть отрицательным")
 	}
-	if ingredient.Unit == "" {
-		return errors.New("единица измерения не может быть пустой")
+	if _, err := units.Parse(ingredient.Unit); err != nil {
+		return err
+	}
+	if ingredient.Density < 0 {
+		return errors.New("плотность не может быть отрицательной")
 	}
 
 	return nil
@@ -243,6 +243,37 @@ func (u *MenuUsecase) GetActiveItems(ctx context.Context) ([]*entity.MenuItem, e
 	return items, nil
 }
 
+// GetAvailableItems получает позиции, которые можно заказать прямо сейчас:
+// активные и не попавшие в стоп-лист
+func (u *MenuUsecase) GetAvailableItems(ctx context.Context) ([]*entity.MenuItem, error) {
+	items, err := u.menuRepo.GetAvailableItems(ctx)
+	if err != nil {
+		return nil, errors.New("ошибка при получении доступных позиций")
+	}
+	return items, nil
+}
+
+// GetStopList получает позиции в стоп-листе
+func (u *MenuUsecase) GetStopList(ctx context.Context) ([]*entity.MenuItem, error) {
+	items, err := u.menuRepo.GetStopList(ctx)
+	if err != nil {
+		return nil, errors.New("ошибка при получении стоп-листа")
+	}
+	return items, nil
+}
+
+// SetStopListOverride задает ручное решение персонала по позиции поверх автоматического стоп-листа
+func (u *MenuUsecase) SetStopListOverride(ctx context.Context, id uuid.UUID, override entity.StopListOverride) error {
+	if id == uuid.Nil {
+		return errors.New("ID не может быть пустым")
+	}
+	if !override.IsValid() {
+		return errors.New("неизвестное значение стоп-листа")
+	}
+
+	return u.menuRepo.SetStopListOverride(ctx, id, override)
+}
+
 // GetMenuItemByID получает позицию меню по ID
 func (u *MenuUsecase) GetMenuItemByID(ctx context.Context, id uuid.UUID) (*entity.MenuItem, error) {
 	if id == uuid.Nil {
@@ -344,14 +375,10 @@ func (u *MenuUsecase) SearchMenuItems(ctx context.Context, dto dto.MenuSearchDTO
 	if dto.Pagination.Page < 1 || dto.Pagination.PageSize < 1 {
 		return nil, 0, errors.New("неверные параметры пагинации")
 	}
-	items, err := u.menuRepo.SearchMenuItems(ctx, &dto)
+	items, count, err := u.menuRepo.SearchMenuItems(ctx, &dto)
 	if err != nil {
 		return nil, 0, errors.New("ошибка при поиске позиций меню")
 	}
-	count, err := u.menuRepo.Count(ctx)
-	if err != nil {
-		return nil, 0, errors.New("ошибка при подсчете количества позиций меню")
-	}
 	return items, count, nil
 }
 
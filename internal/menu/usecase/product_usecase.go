@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"coffe/internal/common"
 	"coffe/internal/menu/entity"
 	"coffe/internal/menu/repository"
 	"context"
@@ -9,6 +10,12 @@ import (
 	"github.com/google/uuid"
 )
 
+// ErrVariantNotFound возвращается, если у продукта нет указанного варианта.
+var ErrVariantNotFound = errors.New("вариант продукта не найден")
+
+// ErrModifierNotFound возвращается, если у продукта нет указанной группы или модификатора.
+var ErrModifierNotFound = errors.New("модификатор продукта не найден")
+
 // ProductUsecase реализует бизнес-логику для работы с продуктами.
 type ProductUsecase struct {
 	productRepo repository.ProductRepository
@@ -27,7 +34,7 @@ func (u *ProductUsecase) Create(ctx context.Context, product *entity.Product) er
 	if product.Category == "" {
 		return errors.New("вы не задали категорию")
 	}
-	if product.Price <= 0 {
+	if !product.Price.IsPositive() {
 		return errors.New("цена не может быть отрицательной")
 	}
 	if product.Description == "" {
@@ -119,11 +126,11 @@ func (u *ProductUsecase) Search(ctx context.Context, query string) ([]*entity.Pr
 }
 
 // GetByPriceRange возвращает продукты в заданном диапазоне цен.
-func (u *ProductUsecase) GetByPriceRange(ctx context.Context, minPrice, maxPrice float64) ([]*entity.Product, error) {
-	if minPrice < 0 || maxPrice < 0 {
+func (u *ProductUsecase) GetByPriceRange(ctx context.Context, minPrice, maxPrice common.Money) ([]*entity.Product, error) {
+	if minPrice.IsNegative() || maxPrice.IsNegative() {
 		return nil, errors.New("цена не может быть отрицательной")
 	}
-	if minPrice > maxPrice {
+	if minPrice.Cmp(maxPrice) > 0 {
 		return nil, errors.New("минимальная цена не может быть больше максимальной")
 	}
 
@@ -186,7 +193,7 @@ func (u *ProductUsecase) validateProduct(product *entity.Product) error {
 	if product.Category == "" {
 		return errors.New("категория продукта не может быть пустой")
 	}
-	if product.Price <= 0 {
+	if !product.Price.IsPositive() {
 		return errors.New("цена продукта должна быть больше нуля")
 	}
 	if product.Description == "" {
@@ -263,3 +270,156 @@ func (u *ProductUsecase) UpdateProductIngredient(ctx context.Context, productID,
 
 	return u.productRepo.UpdateProductIngredient(ctx, productID, ingredientID, quantity, unit)
 }
+
+// GetVariants возвращает варианты продукта
+func (u *ProductUsecase) GetVariants(ctx context.Context, productID uuid.UUID) ([]*entity.ProductVariant, error) {
+	if productID == uuid.Nil {
+		return nil, errors.New("ID продукта не может быть пустым")
+	}
+	return u.productRepo.GetVariantsByProduct(ctx, productID)
+}
+
+// CreateVariant добавляет вариант (размер) продукта
+func (u *ProductUsecase) CreateVariant(ctx context.Context, variant *entity.ProductVariant) error {
+	if variant.ProductID == uuid.Nil {
+		return errors.New("ID продукта не может быть пустым")
+	}
+	if variant.Name == "" {
+		return errors.New("название варианта не может быть пустым")
+	}
+	return u.productRepo.CreateVariant(ctx, variant)
+}
+
+// UpdateVariant обновляет вариант продукта. Вариант должен принадлежать продукту
+func (u *ProductUsecase) UpdateVariant(ctx context.Context, variant *entity.ProductVariant) error {
+	existing, err := u.variantOfProduct(ctx, variant.ProductID, variant.ID)
+	if err != nil {
+		return err
+	}
+	variant.CreatedAt = existing.CreatedAt
+	return u.productRepo.UpdateVariant(ctx, variant)
+}
+
+// DeleteVariant удаляет вариант продукта
+func (u *ProductUsecase) DeleteVariant(ctx context.Context, productID, variantID uuid.UUID) error {
+	if _, err := u.variantOfProduct(ctx, productID, variantID); err != nil {
+		return err
+	}
+	return u.productRepo.DeleteVariant(ctx, variantID)
+}
+
+// SetVariantIngredients задает явный состав варианта. Пустой список возвращает
+// вариант к составу продукта с множителем
+func (u *ProductUsecase) SetVariantIngredients(ctx context.Context, productID, variantID uuid.UUID, ingredients []*entity.VariantIngredient) error {
+	if _, err := u.variantOfProduct(ctx, productID, variantID); err != nil {
+		return err
+	}
+	for _, ingredient := range ingredients {
+		if ingredient.IngredientID == uuid.Nil {
+			return errors.New("ID ингредиента не может быть пустым")
+		}
+		if ingredient.Quantity <= 0 {
+			return errors.New("количество должно быть больше нуля")
+		}
+	}
+	return u.productRepo.SetVariantIngredients(ctx, variantID, ingredients)
+}
+
+// variantOfProduct получает вариант и проверяет, что он принадлежит продукту
+func (u *ProductUsecase) variantOfProduct(ctx context.Context, productID, variantID uuid.UUID) (*entity.ProductVariant, error) {
+	if productID == uuid.Nil || variantID == uuid.Nil {
+		return nil, errors.New("ID продукта и варианта не могут быть пустыми")
+	}
+	variant, err := u.productRepo.GetVariantByID(ctx, variantID)
+	if err != nil || variant.ProductID != productID {
+		return nil, ErrVariantNotFound
+	}
+	return variant, nil
+}
+
+// GetModifierGroups возвращает группы модификаторов продукта
+func (u *ProductUsecase) GetModifierGroups(ctx context.Context, productID uuid.UUID) ([]*entity.ModifierGroup, error) {
+	if productID == uuid.Nil {
+		return nil, errors.New("ID продукта не может быть пустым")
+	}
+	return u.productRepo.GetModifierGroups(ctx, productID)
+}
+
+// CreateModifierGroup добавляет группу модификаторов к продукту
+func (u *ProductUsecase) CreateModifierGroup(ctx context.Context, group *entity.ModifierGroup) error {
+	if group.ProductID == uuid.Nil {
+		return errors.New("ID продукта не может быть пустым")
+	}
+	if err := group.Validate(); err != nil {
+		return err
+	}
+	return u.productRepo.CreateModifierGroup(ctx, group)
+}
+
+// UpdateModifierGroup обновляет группу модификаторов. Группа должна принадлежать продукту
+func (u *ProductUsecase) UpdateModifierGroup(ctx context.Context, group *entity.ModifierGroup) error {
+	existing, err := u.groupOfProduct(ctx, group.ProductID, group.ID)
+	if err != nil {
+		return err
+	}
+	group.CreatedAt = existing.CreatedAt
+	return u.productRepo.UpdateModifierGroup(ctx, group)
+}
+
+// DeleteModifierGroup удаляет группу модификаторов продукта
+func (u *ProductUsecase) DeleteModifierGroup(ctx context.Context, productID, groupID uuid.UUID) error {
+	if _, err := u.groupOfProduct(ctx, productID, groupID); err != nil {
+		return err
+	}
+	return u.productRepo.DeleteModifierGroup(ctx, groupID)
+}
+
+// CreateModifier добавляет модификатор в группу продукта
+func (u *ProductUsecase) CreateModifier(ctx context.Context, productID uuid.UUID, modifier *entity.Modifier) error {
+	if _, err := u.groupOfProduct(ctx, productID, modifier.GroupID); err != nil {
+		return err
+	}
+	return u.productRepo.CreateModifier(ctx, modifier)
+}
+
+// UpdateModifier обновляет модификатор в группе продукта
+func (u *ProductUsecase) UpdateModifier(ctx context.Context, productID uuid.UUID, modifier *entity.Modifier) error {
+	existing, err := u.modifierOfProduct(ctx, productID, modifier.GroupID, modifier.ID)
+	if err != nil {
+		return err
+	}
+	modifier.CreatedAt = existing.CreatedAt
+	return u.productRepo.UpdateModifier(ctx, modifier)
+}
+
+// DeleteModifier удаляет модификатор из группы продукта
+func (u *ProductUsecase) DeleteModifier(ctx context.Context, productID, groupID, modifierID uuid.UUID) error {
+	if _, err := u.modifierOfProduct(ctx, productID, groupID, modifierID); err != nil {
+		return err
+	}
+	return u.productRepo.DeleteModifier(ctx, modifierID)
+}
+
+// groupOfProduct получает группу модификаторов и проверяет, что она принадлежит продукту
+func (u *ProductUsecase) groupOfProduct(ctx context.Context, productID, groupID uuid.UUID) (*entity.ModifierGroup, error) {
+	if productID == uuid.Nil || groupID == uuid.Nil {
+		return nil, errors.New("ID продукта и группы не могут быть пустыми")
+	}
+	group, err := u.productRepo.GetModifierGroupByID(ctx, groupID)
+	if err != nil || group.ProductID != productID {
+		return nil, ErrModifierNotFound
+	}
+	return group, nil
+}
+
+// modifierOfProduct получает модификатор и проверяет, что он входит в группу продукта
+func (u *ProductUsecase) modifierOfProduct(ctx context.Context, productID, groupID, modifierID uuid.UUID) (*entity.Modifier, error) {
+	if _, err := u.groupOfProduct(ctx, productID, groupID); err != nil {
+		return nil, err
+	}
+	modifier, err := u.productRepo.GetModifierByID(ctx, modifierID)
+	if err != nil || modifier.GroupID != groupID {
+		return nil, ErrModifierNotFound
+	}
+	return modifier, nil
+}
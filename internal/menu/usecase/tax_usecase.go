@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"coffe/internal/menu/entity"
+	"coffe/internal/menu/repository"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// ErrTaxRateNotFound возвращается, если ставка налога не найдена.
+var ErrTaxRateNotFound = errors.New("ставка налога не найдена")
+
+// ErrMenuNotFound возвращается, если меню заказа не найдено или не действует.
+var ErrMenuNotFound = errors.New("меню не найдено")
+
+// TaxUsecase управляет ставками налога по категориям и меню и формирует
+// правила налогообложения заказа.
+type TaxUsecase struct {
+	taxRepo     repository.TaxRepository
+	menuRepo    repository.MenuRepository
+	inclusive   bool    // цены в меню включают налог
+	defaultRate float64 // ставка, если для категории ничего не задано
+}
+
+// NewTaxUsecase создает новый экземпляр TaxUsecase.
+func NewTaxUsecase(taxRepo repository.TaxRepository, menuRepo repository.MenuRepository, inclusive bool, defaultRate float64) *TaxUsecase {
+	return &TaxUsecase{
+		taxRepo:     taxRepo,
+		menuRepo:    menuRepo,
+		inclusive:   inclusive,
+		defaultRate: defaultRate,
+	}
+}
+
+// CreateRate создает ставку налога. Для одной категории и одного меню может быть
+// задана только одна ставка.
+func (u *TaxUsecase) CreateRate(ctx context.Context, rate *entity.TaxRate) error {
+	rate.ID = uuid.New()
+	if err := u.validateRate(ctx, rate); err != nil {
+		return err
+	}
+	return u.taxRepo.CreateRate(ctx, rate)
+}
+
+// UpdateRate обновляет ставку налога.
+func (u *TaxUsecase) UpdateRate(ctx context.Context, rate *entity.TaxRate) error {
+	existing, err := u.taxRepo.GetRateByID(ctx, rate.ID)
+	if err != nil {
+		return ErrTaxRateNotFound
+	}
+	rate.CreatedAt = existing.CreatedAt
+	if err := u.validateRate(ctx, rate); err != nil {
+		return err
+	}
+	return u.taxRepo.UpdateRate(ctx, rate)
+}
+
+// DeleteRate удаляет ставку налога.
+func (u *TaxUsecase) DeleteRate(ctx context.Context, id uuid.UUID) error {
+	if _, err := u.taxRepo.GetRateByID(ctx, id); err != nil {
+		return ErrTaxRateNotFound
+	}
+	return u.taxRepo.DeleteRate(ctx, id)
+}
+
+// GetRates возвращает все ставки налога.
+func (u *TaxUsecase) GetRates(ctx context.Context) ([]*entity.TaxRate, error) {
+	return u.taxRepo.GetRates(ctx)
+}
+
+// Policy возвращает правила налогообложения заказа из меню menuID;
+// nil - заказ без указания меню, действуют только общие ставки.
+func (u *TaxUsecase) Policy(ctx context.Context, menuID *uuid.UUID) (*entity.TaxPolicy, error) {
+	if menuID != nil {
+		menu, err := u.menuRepo.GetByID(ctx, *menuID)
+		if err != nil || !menu.IsActive {
+			return nil, ErrMenuNotFound
+		}
+	}
+
+	rates, err := u.taxRepo.GetRatesForMenu(ctx, menuID)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.TaxPolicy{
+		MenuID:      menuID,
+		Inclusive:   u.inclusive,
+		DefaultRate: u.defaultRate,
+		Rates:       rates,
+	}, nil
+}
+
+// validateRate проверяет ставку, ее меню и отсутствие другой ставки с той же областью действия.
+func (u *TaxUsecase) validateRate(ctx context.Context, rate *entity.TaxRate) error {
+	if err := rate.Validate(); err != nil {
+		return err
+	}
+	if rate.MenuID != nil {
+		if _, err := u.menuRepo.GetByID(ctx, *rate.MenuID); err != nil {
+			return fmt.Errorf("%w: меню %s не найдено", entity.ErrInvalidTaxRate, *rate.MenuID)
+		}
+	}
+	if rate.Name == "" {
+		rate.Name = fmt.Sprintf("НДС %g%%", rate.Rate)
+	}
+
+	rates, err := u.taxRepo.GetRates(ctx)
+	if err != nil {
+		return err
+	}
+	if rate.Conflicts(rates) {
+		return fmt.Errorf("%w: ставка для этой категории и меню уже задана", entity.ErrInvalidTaxRate)
+	}
+	return nil
+}
@@ -77,9 +77,15 @@ func (m *JWTMiddleware) Authenticate() gin.HandlerFunc {
 			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Пользователь не найден"})
 			return
 		}
+		roleID, err := uuid.Parse(claims.RoleID)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Неверный идентификатор роли в токене"})
+			return
+		}
+
 		ctx.Set("user", user)
 		ctx.Set("user_id", user.ID)
-		ctx.Set("role_id", claims.RoleID)
+		ctx.Set("role_id", roleID)
 		ctx.Next()
 	}
 }
@@ -147,3 +153,15 @@ func RequireRole(roles ...string) gin.HandlerFunc {
 		ctx.AbortWithStatusJSON(403, gin.H{"error": "Недостаточно прав"})
 	}
 }
+
+// TokenFromQuery подставляет токен из параметра запроса в заголовок Authorization,
+// если заголовка нет. Нужен для EventSource и WebSocket в браузере, которые
+// не могут передать заголовок.
+func TokenFromQuery(param string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if token := ctx.Query(param); token != "" && ctx.GetHeader("Authorization") == "" {
+			ctx.Request.Header.Set("Authorization", "Bearer "+token)
+		}
+		ctx.Next()
+	}
+}
@@ -0,0 +1,372 @@
+package http
+
+import (
+	"coffe/internal/common"
+	fiscalentity "coffe/internal/fiscal/entity"
+	inventoryentity "coffe/internal/inventory/entity"
+	loyaltyusecase "coffe/internal/loyalty/usecase"
+	menuusecase "coffe/internal/menu/usecase"
+	"coffe/internal/order/entity"
+	"coffe/internal/order/usecase"
+	promotionusecase "coffe/internal/promotion/usecase"
+	userentity "coffe/internal/user/entity"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CreateOrderRequest содержит данные для оформления заказа клиентом.
+type CreateOrderRequest struct {
+	Items         []CreateOrderItemRequest `json:"items" binding:"required,min=1,dive"`
+	Notes         string                   `json:"notes"`
+	PromoCode     string                   `json:"promo_code"`
+	RedeemPoints  int64                    `json:"redeem_points" binding:"min=0"` // баллы для оплаты части заказа
+	PaymentMethod entity.PaymentMethod     `json:"payment_method" binding:"required"`
+	MenuID        *uuid.UUID               `json:"menu_id"`                             // меню заказа (в зале, навынос); определяет ставки налога
+	Tip           common.Money             `json:"tip"`                                 // чаевые суммой
+	TipPercent    float64                  `json:"tip_percent" binding:"min=0,max=100"` // или процентом от суммы заказа
+	PickupAt      *time.Time               `json:"pickup_at"`                           // время получения предзаказа, пусто - как можно скорее
+}
+
+// CreateOrderItemRequest содержит данные позиции заказа.
+type CreateOrderItemRequest struct {
+	ProductID uuid.UUID   `json:"product_id" binding:"required"`
+	VariantID *uuid.UUID  `json:"variant_id"` // необязательно, по умолчанию вариант продукта по умолчанию
+	Modifiers []uuid.UUID `json:"modifiers"`  // ID выбранных модификаторов
+	Quantity  int         `json:"quantity" binding:"required,min=1"`
+}
+
+// UpdateStatusRequest содержит новый статус заказа.
+type UpdateStatusRequest struct {
+	Status   entity.OrderStatus `json:"status" binding:"required"`
+	Override bool               `json:"override"` // подтверждение менеджера
+	Comment  string             `json:"comment"`
+}
+
+type OrderHandler struct {
+	orderUsecase *usecase.OrderUsecase
+}
+
+func NewOrderHandler(orderUsecase *usecase.OrderUsecase) *OrderHandler {
+	return &OrderHandler{orderUsecase: orderUsecase}
+}
+
+// оформление заказа текущим пользователем
+func (h *OrderHandler) CreateOrder(ctx *gin.Context) {
+	user, ok := currentUser(ctx)
+	if !ok {
+		return
+	}
+
+	var req CreateOrderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат данных", "details": err.Error()})
+		return
+	}
+
+	order := &entity.Order{
+		CustomerID:     user.ID,
+		Notes:          req.Notes,
+		PromoCode:      req.PromoCode,
+		PointsRedeemed: req.RedeemPoints,
+		PaymentMethod:  req.PaymentMethod,
+		MenuID:         req.MenuID,
+		Tip:            req.Tip,
+		TipPercent:     req.TipPercent,
+		PickupAt:       req.PickupAt,
+	}
+	for _, item := range req.Items {
+		line := entity.ItemsOrders{
+			ProductID: item.ProductID,
+			VariantID: item.VariantID,
+			Quantity:  item.Quantity,
+		}
+		for _, modifierID := range item.Modifiers {
+			line.Modifiers = append(line.Modifiers, entity.ItemModifier{ModifierID: modifierID})
+		}
+		order.Items = append(order.Items, line)
+	}
+
+	if err := h.orderUsecase.Create(ctx.Request.Context(), order); err != nil {
+		if errors.Is(err, entity.ErrSlotFull) {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, usecase.ErrProductUnavailable) || errors.Is(err, promotionusecase.ErrInvalidPromoCode) ||
+			errors.Is(err, loyaltyusecase.ErrInvalidRedemption) || errors.Is(err, entity.ErrInvalidTip) ||
+			errors.Is(err, menuusecase.ErrMenuNotFound) || errors.Is(err, entity.ErrInvalidPickup) {
+			ctx.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"message": "Заказ успешно оформлен", "order": order})
+}
+
+// список заказов текущего пользователя
+func (h *OrderHandler) GetMyOrders(ctx *gin.Context) {
+	user, ok := currentUser(ctx)
+	if !ok {
+		return
+	}
+
+	orders, err := h.orderUsecase.GetByCustomer(ctx.Request.Context(), user.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения заказов"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"orders": orders,
+		"total":  len(orders),
+	})
+}
+
+// получение заказа по ID: клиент видит только свои заказы, персонал - любые
+func (h *OrderHandler) GetOrderByID(ctx *gin.Context) {
+	user, ok := currentUser(ctx)
+	if !ok {
+		return
+	}
+
+	id, ok := orderIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	order, err := h.orderUsecase.GetByID(ctx.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, usecase.ErrOrderNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Заказ не найден"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения заказа"})
+		return
+	}
+
+	if order.CustomerID != user.ID && !isStaff(user) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Заказ не найден"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"order": order})
+}
+
+// список заказов по статусу (для персонала)
+func (h *OrderHandler) GetOrdersByStatus(ctx *gin.Context) {
+	status := entity.OrderStatus(ctx.Param("status"))
+
+	orders, err := h.orderUsecase.GetByStatus(ctx.Request.Context(), status)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidStatus) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения заказов"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"orders": orders,
+		"status": status,
+		"total":  len(orders),
+	})
+}
+
+// табло выдачи: номера готовящихся и готовых заказов за сегодня
+func (h *OrderHandler) GetBoard(ctx *gin.Context) {
+	board, err := h.orderUsecase.Board(ctx.Request.Context())
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения табло"})
+		return
+	}
+
+	ctx.Header("Cache-Control", "no-store")
+	ctx.JSON(http.StatusOK, board)
+}
+
+// слоты получения предзаказов на день (?date=2024-03-01, по умолчанию сегодня)
+func (h *OrderHandler) GetPickupSlots(ctx *gin.Context) {
+	day := time.Now()
+	if date := ctx.Query("date"); date != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", date, time.Local)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат даты, ожидается ГГГГ-ММ-ДД"})
+			return
+		}
+		day = parsed
+	}
+
+	slots, err := h.orderUsecase.PickupSlots(ctx.Request.Context(), day)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения слотов"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"slots": slots,
+		"total": len(slots),
+	})
+}
+
+// изменение статуса заказа (для персонала)
+func (h *OrderHandler) UpdateOrderStatus(ctx *gin.Context) {
+	user, ok := currentUser(ctx)
+	if !ok {
+		return
+	}
+
+	id, ok := orderIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	var req UpdateStatusRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат данных", "details": err.Error()})
+		return
+	}
+
+	change := usecase.StatusChangeRequest{
+		OrderID:   id,
+		Status:    req.Status,
+		ChangedBy: user.ID,
+		Override:  req.Override,
+		Comment:   req.Comment,
+	}
+	if user.Role != nil {
+		change.Role = user.Role.Name
+	}
+
+	if err := h.orderUsecase.UpdateStatus(ctx.Request.Context(), change); err != nil {
+		var transitionErr *entity.TransitionError
+		var shortageErr *inventoryentity.ShortageError
+		switch {
+		case errors.As(err, &shortageErr):
+			ctx.JSON(http.StatusConflict, gin.H{
+				"error":     "Недостаточно ингредиентов для заказа",
+				"shortages": shortageErr.Shortages,
+			})
+		case errors.As(err, &transitionErr):
+			ctx.JSON(http.StatusConflict, gin.H{
+				"error":   err.Error(),
+				"from":    transitionErr.From,
+				"to":      transitionErr.To,
+				"allowed": transitionErr.From.NextStatuses(),
+			})
+		case errors.Is(err, usecase.ErrPaymentRequired):
+			ctx.JSON(http.StatusPaymentRequired, gin.H{"error": err.Error()})
+		case errors.Is(err, fiscalentity.ErrUnsupportedVAT):
+			ctx.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		case errors.Is(err, entity.ErrStatusConflict):
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, usecase.ErrInvalidStatus):
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, usecase.ErrOverrideForbidden):
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, usecase.ErrOrderNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Заказ не найден"})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка обновления статуса заказа"})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Статус заказа обновлен", "status": req.Status})
+}
+
+// история статусов заказа: клиент видит историю только своих заказов
+func (h *OrderHandler) GetOrderHistory(ctx *gin.Context) {
+	user, ok := currentUser(ctx)
+	if !ok {
+		return
+	}
+
+	id, ok := orderIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	order, err := h.orderUsecase.GetByID(ctx.Request.Context(), id)
+	if err != nil || (order.CustomerID != user.ID && !isStaff(user)) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Заказ не найден"})
+		return
+	}
+
+	history, err := h.orderUsecase.GetStatusHistory(ctx.Request.Context(), id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения истории заказа"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"order_id": id,
+		"status":   order.Status,
+		"history":  history,
+	})
+}
+
+// количество заказов (админская функция)
+func (h *OrderHandler) GetOrdersCount(ctx *gin.Context) {
+	count, err := h.orderUsecase.Count(ctx.Request.Context())
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка подсчета заказов"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"count": count})
+}
+
+// заказы за сегодня (админская функция)
+func (h *OrderHandler) GetTodayOrders(ctx *gin.Context) {
+	orders, err := h.orderUsecase.GetToday(ctx.Request.Context())
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения заказов за сегодня"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"orders": orders,
+		"total":  len(orders),
+	})
+}
+
+// currentUser достает аутентифицированного пользователя из контекста запроса
+func currentUser(ctx *gin.Context) (*common.User, bool) {
+	userInterface, exists := ctx.Get("user")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Пользователь не авторизован"})
+		return nil, false
+	}
+
+	user, ok := userInterface.(*common.User)
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения пользователя"})
+		return nil, false
+	}
+
+	return user, true
+}
+
+// orderIDParam разбирает ID заказа из URL параметра
+func orderIDParam(ctx *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID заказа"})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// isStaff проверяет, относится ли пользователь к персоналу кофейни
+func isStaff(user *common.User) bool {
+	if user.Role == nil {
+		return false
+	}
+	return user.Role.Name == userentity.RoleAdmin || user.Role.Name == userentity.RoleManager
+}
@@ -0,0 +1,59 @@
+package http
+
+import (
+	"coffe/internal/middleware"
+	userentity "coffe/internal/user/entity"
+	"coffe/internal/user/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupOrderRoutes настраивает все маршруты для модуля заказов
+func SetupOrderRoutes(router *gin.RouterGroup, handler *OrderHandler, jwtMiddleware *middleware.JWTMiddleware, permissionUC usecase.PermissionUsecase) {
+	// Табло выдачи для экрана в зале открыто без авторизации
+	router.GET("/board", handler.GetBoard)
+
+	// Маршруты клиента
+	setupCustomerOrderRoutes(router, handler, jwtMiddleware)
+
+	// Маршруты персонала
+	setupStaffOrderRoutes(router, handler, jwtMiddleware, permissionUC)
+
+	// Админские маршруты
+	setupAdminOrderRoutes(router, handler, jwtMiddleware, permissionUC)
+}
+
+// setupCustomerOrderRoutes настраивает маршруты для оформления и просмотра своих заказов
+func setupCustomerOrderRoutes(router *gin.RouterGroup, handler *OrderHandler, jwtMiddleware *middleware.JWTMiddleware) {
+	orders := router.Group("/orders")
+	orders.Use(jwtMiddleware.Authenticate())
+	{
+		orders.POST("", handler.CreateOrder)
+		orders.GET("", handler.GetMyOrders)
+		orders.GET("/slots", handler.GetPickupSlots)
+		orders.GET("/:id", handler.GetOrderByID)
+		orders.GET("/:id/history", handler.GetOrderHistory)
+	}
+}
+
+// setupStaffOrderRoutes настраивает маршруты для обработки заказов персоналом
+func setupStaffOrderRoutes(router *gin.RouterGroup, handler *OrderHandler, jwtMiddleware *middleware.JWTMiddleware, permissionUC usecase.PermissionUsecase) {
+	staff := router.Group("/orders")
+	staff.Use(jwtMiddleware.Authenticate())
+	staff.Use(jwtMiddleware.RequireRole(userentity.RoleAdmin, userentity.RoleManager))
+	{
+		staff.GET("/status/:status", middleware.PermissionMiddleware(permissionUC, "read_order"), handler.GetOrdersByStatus)
+		staff.PATCH("/:id/status", middleware.PermissionMiddleware(permissionUC, "update_order"), handler.UpdateOrderStatus)
+	}
+}
+
+// setupAdminOrderRoutes настраивает админские маршруты статистики заказов
+func setupAdminOrderRoutes(router *gin.RouterGroup, handler *OrderHandler, jwtMiddleware *middleware.JWTMiddleware, permissionUC usecase.PermissionUsecase) {
+	admin := router.Group("/admin/orders")
+	admin.Use(jwtMiddleware.Authenticate())
+	admin.Use(jwtMiddleware.RequireRole(userentity.RoleAdmin))
+	{
+		admin.GET("/count", middleware.PermissionMiddleware(permissionUC, "read_order"), handler.GetOrdersCount)
+		admin.GET("/today", middleware.PermissionMiddleware(permissionUC, "read_order"), handler.GetTodayOrders)
+	}
+}
@@ -2,11 +2,17 @@ package entity
 
 import (
 	"coffe/internal/common"
+	"errors"
+	"fmt"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// ErrInvalidTip возвращается при некорректных чаевых.
+var ErrInvalidTip = errors.New("некорректные чаевые")
+
 // PaymentMethod определяет способ оплаты заказа.
 type PaymentMethod string
 
@@ -16,6 +22,12 @@ const (
 	PaymentMethodOnline PaymentMethod = "Иное"
 )
 
+// IsOnline сообщает, что заказ оплачивается через платежный шлюз
+// и не может быть подтвержден до списания денег.
+func (m PaymentMethod) IsOnline() bool {
+	return m == PaymentMethodOnline
+}
+
 // OrderStatus определяет статус заказа.
 type OrderStatus string
 
@@ -28,26 +40,203 @@ const (
 	OrderStatusCancelled OrderStatus = "отменен"
 )
 
+// IsValid проверяет, что статус входит в список известных статусов.
+func (s OrderStatus) IsValid() bool {
+	switch s {
+	case OrderStatusPending, OrderStatusConfirmed, OrderStatusPreparing,
+		OrderStatusReady, OrderStatusCompleted, OrderStatusCancelled:
+		return true
+	}
+	return false
+}
+
 // Order представляет заказ клиента.
 type Order struct {
-	Id            uuid.UUID     `json:"id" db:"id"`
-	CustomerID    uuid.UUID     `json:"customer_id" db:"customer_id"`
-	Customer      *common.User  `json:"customer,omitempty" db:"customer"`
-	Items         []ItemsOrders `json:"items" db:"items"`
-	Status        OrderStatus   `json:"status" db:"status"`
-	Notes         string        `json:"notes" db:"notes"`
-	TotalPrice    float64       `json:"total_price" db:"total_price"`
-	PaymentMethod PaymentMethod `json:"payment_method" db:"payment_method"`
-	CreatedAt     time.Time     `json:"created_at"`
-	UpdatedAt     time.Time     `json:"updated_at"`
+	Id             uuid.UUID       `json:"id" db:"id"`
+	Number         int             `json:"number" db:"number"` // номер заказа за день, который называет бариста
+	CustomerID     uuid.UUID       `json:"customer_id" db:"customer_id"`
+	Customer       *common.User    `json:"customer,omitempty" db:"customer"`
+	Items          []ItemsOrders   `json:"items" db:"items"`
+	Discounts      []OrderDiscount `json:"discounts,omitempty" db:"discounts" gorm:"foreignKey:OrderID"` // скидки по акциям, каждая отдельной строкой
+	PromoCode      string          `json:"promo_code,omitempty" db:"promo_code"`
+	Status         OrderStatus     `json:"status" db:"status"`
+	Notes          string          `json:"notes" db:"notes"`
+	PickupAt       *time.Time      `json:"pickup_at,omitempty" db:"pickup_at" gorm:"index"` // начало слота получения предзаказа, пусто - как можно скорее
+	MenuID         *uuid.UUID      `json:"menu_id,omitempty" db:"menu_id"`                  // меню заказа (в зале, навынос), определяет ставки налога
+	TotalPrice     common.Money    `json:"total_price" db:"total_price"`                    // сумма заказа с учетом скидок и налога (брутто)
+	NetTotal       common.Money    `json:"net_total" db:"net_total"`                        // сумма заказа без налога (нетто)
+	TaxTotal       common.Money    `json:"tax_total" db:"tax_total"`                        // сумма налога
+	TaxInclusive   bool            `json:"tax_inclusive" db:"tax_inclusive"`                // налог включен в цены позиций
+	PointsRedeemed int64           `json:"points_redeemed" db:"points_redeemed"`            // баллы, списанные в оплату
+	PaidWithPoints common.Money    `json:"paid_with_points" db:"paid_with_points"`          // сумма, оплаченная баллами
+	PaymentMethod  PaymentMethod   `json:"payment_method" db:"payment_method"`
+	Tip            common.Money    `json:"tip" db:"tip"`                                  // чаевые, не входят в TotalPrice
+	TipPercent     float64         `json:"tip_percent,omitempty" db:"tip_percent"`        // чаевые процентом от суммы заказа
+	Fiscal         FiscalData      `json:"fiscal" gorm:"embedded;embeddedPrefix:fiscal_"` // реквизиты фискального чека
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+// IsPreorder сообщает, что заказ оформлен заранее на время получения.
+func (o *Order) IsPreorder() bool {
+	return o.PickupAt != nil
+}
+
+// Board - табло выдачи заказов: номера готовящихся и готовых заказов за день.
+type Board struct {
+	Preparing []int     `json:"preparing"`
+	Ready     []int     `json:"ready"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewBoard раскладывает номера заказов по колонкам табло в порядке возрастания.
+// Заказы в других статусах пропускаются.
+func NewBoard(orders []*Order, now time.Time) *Board {
+	board := &Board{Preparing: []int{}, Ready: []int{}, UpdatedAt: now}
+	for _, order := range orders {
+		switch order.Status {
+		case OrderStatusPreparing:
+			board.Preparing = append(board.Preparing, order.Number)
+		case OrderStatusReady:
+			board.Ready = append(board.Ready, order.Number)
+		}
+	}
+	sort.Ints(board.Preparing)
+	sort.Ints(board.Ready)
+	return board
+}
+
+// FiscalData содержит реквизиты чека, зарегистрированного фискальным накопителем.
+type FiscalData struct {
+	DocumentNumber int64      `json:"document_number,omitempty" db:"document_number"` // номер фискального документа (ФД)
+	Sign           string     `json:"sign,omitempty" db:"sign"`                       // фискальный признак документа (ФП)
+	DriveNumber    string     `json:"drive_number,omitempty" db:"drive_number"`       // номер фискального накопителя (ФН)
+	RegisteredAt   *time.Time `json:"registered_at,omitempty" db:"registered_at"`
+}
+
+// IsRegistered сообщает, что чек заказа зарегистрирован в фискальном накопителе.
+func (f FiscalData) IsRegistered() bool {
+	return f.DocumentNumber != 0
 }
 
 // ItemsOrders представляет позицию заказа.
 type ItemsOrders struct {
-	ID        uuid.UUID       `json:"id" db:"id"`
-	OrderID   uuid.UUID       `json:"order_id" db:"order_id"`
-	ProductID uuid.UUID       `json:"product_id" db:"product_id"`
-	Product   *common.Product `json:"product,omitempty" db:"product"`
-	Quantity  int             `json:"quantity" db:"quantity"`
-	Price     float64         `json:"price" db:"price"` // цена на момент заказа
+	ID          uuid.UUID       `json:"id" db:"id"`
+	OrderID     uuid.UUID       `json:"order_id" db:"order_id"`
+	ProductID   uuid.UUID       `json:"product_id" db:"product_id"`
+	Product     *common.Product `json:"product,omitempty" db:"product"`
+	Name        string          `json:"name" db:"name"`                                              // название продукта на момент заказа
+	VariantID   *uuid.UUID      `json:"variant_id,omitempty" db:"variant_id"`                        // выбранный вариант (размер) продукта
+	VariantName string          `json:"variant_name,omitempty" db:"variant_name"`                    // название варианта на момент заказа
+	Modifiers   []ItemModifier  `json:"modifiers,omitempty" db:"modifiers" gorm:"foreignKey:ItemID"` // выбранные модификаторы
+	Quantity    int             `json:"quantity" db:"quantity"`
+	Price       common.Money    `json:"price" db:"price"`           // цена единицы на момент заказа с учетом модификаторов
+	TaxRate     float64         `json:"tax_rate" db:"tax_rate"`     // ставка налога в процентах
+	NetAmount   common.Money    `json:"net_amount" db:"net_amount"` // сумма позиции со скидкой без налога
+	TaxAmount   common.Money    `json:"tax_amount" db:"tax_amount"` // налог по позиции
+}
+
+// OrderDiscount хранит скидку, примененную к заказу по акции или награде штамп-карты.
+type OrderDiscount struct {
+	ID            uuid.UUID    `json:"id" db:"id"`
+	OrderID       uuid.UUID    `json:"order_id" db:"order_id" gorm:"index"`
+	PromotionID   *uuid.UUID   `json:"promotion_id,omitempty" db:"promotion_id"`
+	StampRewardID *uuid.UUID   `json:"stamp_reward_id,omitempty" db:"stamp_reward_id"`
+	Code          string       `json:"code,omitempty" db:"code"`
+	Name          string       `json:"name" db:"name"`
+	Amount        common.Money `json:"amount" db:"amount"`
+}
+
+// Subtotal возвращает сумму позиций заказа без скидок.
+func (o *Order) Subtotal() common.Money {
+	total := common.NewMoney(0)
+	for _, item := range o.Items {
+		total = total.Add(item.Price.Mul(int64(item.Quantity)))
+	}
+	return total
+}
+
+// AmountDue возвращает сумму, которую клиент оплачивает деньгами.
+func (o *Order) AmountDue() common.Money {
+	return o.TotalPrice.Sub(o.PaidWithPoints)
+}
+
+// ApplyTip рассчитывает чаевые: процент от суммы заказа, если он задан, иначе
+// фиксированную сумму Tip.
+func (o *Order) ApplyTip() error {
+	if o.Tip.IsNegative() {
+		return fmt.Errorf("%w: сумма не может быть отрицательной", ErrInvalidTip)
+	}
+	if o.TipPercent < 0 || o.TipPercent > 100 {
+		return fmt.Errorf("%w: процент должен быть от 0 до 100", ErrInvalidTip)
+	}
+	if o.TipPercent > 0 {
+		if o.Tip.IsPositive() {
+			return fmt.Errorf("%w: задается сумма или процент, но не одновременно", ErrInvalidTip)
+		}
+		o.Tip = o.TotalPrice.Percent(o.TipPercent)
+	}
+	if o.Tip.IsZero() {
+		o.Tip = common.NewMoney(0)
+	}
+	return nil
+}
+
+// ItemShare возвращает долю суммы к оплате, приходящуюся на quantity единиц позиции:
+// скидки и оплата баллами распределяются между позициями пропорционально их стоимости.
+func (o *Order) ItemShare(item ItemsOrders, quantity int) common.Money {
+	amount := item.Price.Mul(int64(quantity))
+	subtotal := o.Subtotal()
+	if !subtotal.IsPositive() {
+		return amount
+	}
+	due := o.AmountDue()
+	return common.Money{
+		Amount:   (amount.Amount*due.Amount + subtotal.Amount/2) / subtotal.Amount,
+		Currency: amount.Currency,
+	}
+}
+
+// Item возвращает позицию заказа по ID.
+func (o *Order) Item(id uuid.UUID) (ItemsOrders, bool) {
+	for _, item := range o.Items {
+		if item.ID == id {
+			return item, true
+		}
+	}
+	return ItemsOrders{}, false
+}
+
+// DisplayName возвращает название позиции с вариантом для чеков и экранов персонала.
+func (i *ItemsOrders) DisplayName() string {
+	name := i.Name
+	if name == "" && i.Product != nil {
+		name = i.Product.Name
+	}
+	if name == "" {
+		name = i.ProductID.String()
+	}
+	if i.VariantName != "" {
+		name += " (" + i.VariantName + ")"
+	}
+	return name
+}
+
+// DiscountTotal возвращает сумму всех скидок заказа.
+func (o *Order) DiscountTotal() common.Money {
+	total := common.NewMoney(0)
+	for _, discount := range o.Discounts {
+		total = total.Add(discount.Amount)
+	}
+	return total
+}
+
+// ItemModifier хранит модификатор позиции заказа с названием и надбавкой на момент заказа.
+type ItemModifier struct {
+	ID         uuid.UUID    `json:"id" db:"id"`
+	ItemID     uuid.UUID    `json:"item_id" db:"item_id" gorm:"index"`
+	ModifierID uuid.UUID    `json:"modifier_id" db:"modifier_id"`
+	GroupName  string       `json:"group_name" db:"group_name"`
+	Name       string       `json:"name" db:"name"`
+	PriceDelta common.Money `json:"price_delta" db:"price_delta"`
 }
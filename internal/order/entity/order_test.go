@@ -0,0 +1,63 @@
+package entity_test
+
+import (
+	"coffe/internal/common"
+	"coffe/internal/order/entity"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestOrder_ApplyTip(t *testing.T) {
+	tests := []struct {
+		name    string
+		tip     common.Money
+		percent float64
+		want    int64
+		wantErr bool
+	}{
+		{"без чаевых", common.Money{}, 0, 0, false},
+		{"фиксированная сумма", common.NewMoney(5000), 0, 5000, false},
+		{"процент от суммы заказа", common.Money{}, 10, 4550, false},
+		{"сумма и процент одновременно", common.NewMoney(5000), 10, 0, true},
+		{"отрицательная сумма", common.NewMoney(-100), 0, 0, true},
+		{"процент больше 100", common.Money{}, 150, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := &entity.Order{TotalPrice: common.NewMoney(45500), Tip: tt.tip, TipPercent: tt.percent}
+			err := order.ApplyTip()
+			if tt.wantErr {
+				if !errors.Is(err, entity.ErrInvalidTip) {
+					t.Errorf("ожидали ErrInvalidTip, получили %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("неожиданная ошибка: %v", err)
+			}
+			// чаевые не входят в сумму заказа
+			if order.Tip.Amount != tt.want || order.TotalPrice.Amount != 45500 {
+				t.Errorf("чаевые %d, сумма %d; ожидали чаевые %d", order.Tip.Amount, order.TotalPrice.Amount, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewBoard(t *testing.T) {
+	orders := []*entity.Order{
+		{Number: 12, Status: entity.OrderStatusReady},
+		{Number: 7, Status: entity.OrderStatusPreparing},
+		{Number: 3, Status: entity.OrderStatusReady},
+		{Number: 15, Status: entity.OrderStatusPending},
+	}
+
+	board := entity.NewBoard(orders, time.Now())
+	if len(board.Preparing) != 1 || board.Preparing[0] != 7 {
+		t.Errorf("неверная колонка готовящихся: %v", board.Preparing)
+	}
+	if len(board.Ready) != 2 || board.Ready[0] != 3 || board.Ready[1] != 12 {
+		t.Errorf("готовые заказы должны идти по возрастанию номера: %v", board.Ready)
+	}
+}
@@ -0,0 +1,98 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidPickup возвращается, если время получения нельзя забронировать.
+var ErrInvalidPickup = errors.New("некорректное время получения заказа")
+
+// ErrSlotFull возвращается, если в слоте получения не осталось мест.
+var ErrSlotFull = errors.New("слот получения заказа заполнен")
+
+// PickupSchedule описывает слоты получения предзаказов. День делится на слоты
+// длиной SlotLength в часы работы [Opens, Closes), в каждый слот принимается
+// не больше Capacity заказов.
+type PickupSchedule struct {
+	SlotLength time.Duration
+	Capacity   int
+	Opens      time.Duration // начало приема заказов от полуночи по местному времени
+	Closes     time.Duration // конец приема заказов от полуночи по местному времени
+	Lead       time.Duration // за сколько до слота предзаказ передается в работу
+	MaxDays    int           // на сколько дней вперед принимаются предзаказы, 0 - только сегодня
+}
+
+// PickupSlot - слот получения заказа с числом свободных мест.
+type PickupSlot struct {
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+	Capacity  int       `json:"capacity"`
+	Booked    int       `json:"booked"`
+	Available int       `json:"available"`
+}
+
+// ParseClock разбирает время суток в формате "08:30" и возвращает его смещение от полуночи.
+func ParseClock(value string) (time.Duration, error) {
+	clock, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("время %q: ожидается формат ЧЧ:ММ", value)
+	}
+	return time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute, nil
+}
+
+// SlotStart возвращает начало слота, в который попадает t.
+func (s PickupSchedule) SlotStart(t time.Time) time.Time {
+	day := startOfDay(t)
+	return day.Add(t.Sub(day) / s.SlotLength * s.SlotLength)
+}
+
+// Validate проверяет, что в слот времени pickup можно оформить предзаказ в момент now,
+// и возвращает начало слота. Слот должен начинаться не раньше, чем через Lead,
+// чтобы заказ успели приготовить, и целиком попадать в часы работы.
+func (s PickupSchedule) Validate(pickup, now time.Time) (time.Time, error) {
+	slot := s.SlotStart(pickup.In(now.Location()))
+	if slot.Before(now.Add(s.Lead)) {
+		return time.Time{}, fmt.Errorf("%w: ближайший слот через %d мин", ErrInvalidPickup, int(s.Lead/time.Minute))
+	}
+	offset := slot.Sub(startOfDay(slot))
+	if offset < s.Opens || offset+s.SlotLength > s.Closes {
+		return time.Time{}, fmt.Errorf("%w: заказы принимаются только в часы работы", ErrInvalidPickup)
+	}
+	if !slot.Before(startOfDay(now).AddDate(0, 0, s.MaxDays+1)) {
+		return time.Time{}, fmt.Errorf("%w: предзаказ принимается не более чем на %d дн. вперед", ErrInvalidPickup, s.MaxDays)
+	}
+	return slot, nil
+}
+
+// DayBounds возвращает начало первого и конец последнего слота дня day.
+func (s PickupSchedule) DayBounds(day time.Time) (time.Time, time.Time) {
+	midnight := startOfDay(day)
+	return midnight.Add(s.Opens), midnight.Add(s.Closes)
+}
+
+// Slots возвращает слоты дня day, которые еще можно забронировать в момент now.
+// booked содержит время получения уже оформленных заказов.
+func (s PickupSchedule) Slots(day, now time.Time, booked []time.Time) []PickupSlot {
+	counts := make(map[int64]int, len(booked))
+	for _, pickup := range booked {
+		counts[s.SlotStart(pickup.In(day.Location())).Unix()]++
+	}
+
+	slots := []PickupSlot{}
+	from, to := s.DayBounds(day)
+	for start := from; !start.Add(s.SlotLength).After(to); start = start.Add(s.SlotLength) {
+		if _, err := s.Validate(start, now); err != nil {
+			continue
+		}
+		slot := PickupSlot{Start: start, End: start.Add(s.SlotLength), Capacity: s.Capacity, Booked: counts[start.Unix()]}
+		slot.Available = max(s.Capacity-slot.Booked, 0)
+		slots = append(slots, slot)
+	}
+	return slots
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,84 @@
+package entity_test
+
+import (
+	"coffe/internal/order/entity"
+	"errors"
+	"testing"
+	"time"
+)
+
+func testSchedule() entity.PickupSchedule {
+	return entity.PickupSchedule{
+		SlotLength: 10 * time.Minute,
+		Capacity:   2,
+		Opens:      8 * time.Hour,
+		Closes:     9 * time.Hour,
+		Lead:       15 * time.Minute,
+		MaxDays:    1,
+	}
+}
+
+func TestPickupSchedule_Validate(t *testing.T) {
+	schedule := testSchedule()
+	now := time.Date(2024, 3, 1, 7, 0, 0, 0, time.UTC)
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, 3, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	slot, err := schedule.Validate(at(1, 8, 37), now)
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if !slot.Equal(at(1, 8, 30)) {
+		t.Errorf("время должно округляться до начала слота, получили %s", slot)
+	}
+
+	tests := []struct {
+		name   string
+		pickup time.Time
+		now    time.Time
+	}{
+		{"слишком скоро", at(1, 8, 10), at(1, 8, 0)},
+		{"до открытия", at(1, 7, 50), now},
+		{"слот заканчивается после закрытия", at(1, 9, 0), now},
+		{"слишком далеко", at(3, 8, 30), now},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := schedule.Validate(tt.pickup, tt.now); !errors.Is(err, entity.ErrInvalidPickup) {
+				t.Errorf("ожидали ErrInvalidPickup, получили %v", err)
+			}
+		})
+	}
+}
+
+func TestPickupSchedule_Slots(t *testing.T) {
+	schedule := testSchedule()
+	now := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	booked := []time.Time{
+		time.Date(2024, 3, 1, 8, 20, 0, 0, time.UTC),
+		time.Date(2024, 3, 1, 8, 20, 0, 0, time.UTC),
+		time.Date(2024, 3, 1, 8, 40, 0, 0, time.UTC),
+	}
+
+	slots := schedule.Slots(now, now, booked)
+	// слоты 8:00 и 8:10 уже не успеть приготовить
+	if len(slots) != 4 || !slots[0].Start.Equal(time.Date(2024, 3, 1, 8, 20, 0, 0, time.UTC)) {
+		t.Fatalf("неверные слоты: %+v", slots)
+	}
+	if slots[0].Booked != 2 || slots[0].Available != 0 {
+		t.Errorf("слот 8:20 должен быть заполнен: %+v", slots[0])
+	}
+	if slots[2].Booked != 1 || slots[2].Available != 1 {
+		t.Errorf("в слоте 8:40 должно остаться одно место: %+v", slots[2])
+	}
+}
+
+func TestParseClock(t *testing.T) {
+	if offset, err := entity.ParseClock("08:30"); err != nil || offset != 8*time.Hour+30*time.Minute {
+		t.Errorf("ожидали 8ч30м, получили %s, %v", offset, err)
+	}
+	if _, err := entity.ParseClock("8.30"); err == nil {
+		t.Error("некорректное время должно возвращать ошибку")
+	}
+}
@@ -0,0 +1,110 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidTransition является базовой ошибкой для всех недопустимых переходов статуса.
+var ErrInvalidTransition = errors.New("недопустимый переход статуса заказа")
+
+// ErrStatusConflict возвращается, если статус заказа успел измениться параллельным запросом.
+var ErrStatusConflict = errors.New("статус заказа был изменен другим запросом")
+
+// TransitionError описывает отклоненный переход статуса заказа.
+type TransitionError struct {
+	From   OrderStatus
+	To     OrderStatus
+	Reason string
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("нельзя перевести заказ из статуса %q в %q: %s", e.From, e.To, e.Reason)
+}
+
+// Unwrap позволяет проверять ошибку через errors.Is(err, ErrInvalidTransition).
+func (e *TransitionError) Unwrap() error {
+	return ErrInvalidTransition
+}
+
+// orderTransitions описывает штатный жизненный цикл заказа:
+// ожидает → подтвержден → готовится → готов → выполнен.
+// Готовый заказ можно вернуть в приготовление, если позиции нужно переделать.
+// Отмена без подтверждения менеджера возможна только до начала приготовления.
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderStatusPending:   {OrderStatusConfirmed, OrderStatusCancelled},
+	OrderStatusConfirmed: {OrderStatusPreparing, OrderStatusCancelled},
+	OrderStatusPreparing: {OrderStatusReady},
+	OrderStatusReady:     {OrderStatusCompleted, OrderStatusPreparing},
+}
+
+// overrideTransitions содержит переходы, доступные только с подтверждением менеджера.
+var overrideTransitions = map[OrderStatus][]OrderStatus{
+	OrderStatusPreparing: {OrderStatusCancelled},
+	OrderStatusReady:     {OrderStatusCancelled},
+}
+
+// IsFinal сообщает, что из статуса больше нет переходов.
+func (s OrderStatus) IsFinal() bool {
+	return s == OrderStatusCompleted || s == OrderStatusCancelled
+}
+
+// NextStatuses возвращает статусы, в которые заказ может перейти штатно.
+func (s OrderStatus) NextStatuses() []OrderStatus {
+	return orderTransitions[s]
+}
+
+// CanTransition проверяет допустимость перехода между статусами.
+// override включает переходы, разрешенные только менеджеру.
+func CanTransition(from, to OrderStatus, override bool) error {
+	if !to.IsValid() {
+		return &TransitionError{From: from, To: to, Reason: "неизвестный статус"}
+	}
+	if from == to {
+		return &TransitionError{From: from, To: to, Reason: "заказ уже находится в этом статусе"}
+	}
+	if from.IsFinal() {
+		return &TransitionError{From: from, To: to, Reason: "заказ уже закрыт"}
+	}
+
+	if containsStatus(orderTransitions[from], to) {
+		return nil
+	}
+	if containsStatus(overrideTransitions[from], to) {
+		if override {
+			return nil
+		}
+		return &TransitionError{From: from, To: to, Reason: "требуется подтверждение менеджера"}
+	}
+
+	return &TransitionError{From: from, To: to, Reason: "переход не предусмотрен"}
+}
+
+func containsStatus(statuses []OrderStatus, status OrderStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// OrderStatusHistory хранит запись об изменении статуса заказа.
+type OrderStatusHistory struct {
+	ID         uuid.UUID   `json:"id" db:"id"`
+	OrderID    uuid.UUID   `json:"order_id" db:"order_id"`
+	FromStatus OrderStatus `json:"from_status" db:"from_status"`
+	ToStatus   OrderStatus `json:"to_status" db:"to_status"`
+	ChangedBy  uuid.UUID   `json:"changed_by" db:"changed_by"`
+	Override   bool        `json:"override" db:"override"` // переход выполнен с подтверждением менеджера
+	Comment    string      `json:"comment" db:"comment"`
+	CreatedAt  time.Time   `json:"created_at" db:"created_at"`
+}
+
+// TableName задает имя таблицы истории статусов.
+func (OrderStatusHistory) TableName() string {
+	return "order_status_history"
+}
@@ -0,0 +1,53 @@
+package entity_test
+
+import (
+	"coffe/internal/order/entity"
+	"errors"
+	"testing"
+)
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     entity.OrderStatus
+		to       entity.OrderStatus
+		override bool
+		wantErr  bool
+	}{
+		{"ожидает → подтвержден", entity.OrderStatusPending, entity.OrderStatusConfirmed, false, false},
+		{"подтвержден → готовится", entity.OrderStatusConfirmed, entity.OrderStatusPreparing, false, false},
+		{"готовится → готов", entity.OrderStatusPreparing, entity.OrderStatusReady, false, false},
+		{"готов → выполнен", entity.OrderStatusReady, entity.OrderStatusCompleted, false, false},
+		{"возврат готового в приготовление", entity.OrderStatusReady, entity.OrderStatusPreparing, false, false},
+		{"отмена до приготовления", entity.OrderStatusConfirmed, entity.OrderStatusCancelled, false, false},
+		{"отмена во время приготовления", entity.OrderStatusPreparing, entity.OrderStatusCancelled, false, true},
+		{"отмена во время приготовления менеджером", entity.OrderStatusPreparing, entity.OrderStatusCancelled, true, false},
+		{"пропуск статуса", entity.OrderStatusPending, entity.OrderStatusReady, false, true},
+		{"возврат выполненного", entity.OrderStatusCompleted, entity.OrderStatusPending, true, true},
+		{"из отмененного в готов", entity.OrderStatusCancelled, entity.OrderStatusReady, true, true},
+		{"неизвестный статус", entity.OrderStatusPending, entity.OrderStatus("доставлен"), false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := entity.CanTransition(tt.from, tt.to, tt.override)
+			if tt.wantErr && err == nil {
+				t.Fatalf("ожидали ошибку перехода %q → %q", tt.from, tt.to)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("не ожидали ошибку, получили: %v", err)
+			}
+			if err == nil {
+				return
+			}
+
+			var transitionErr *entity.TransitionError
+			if !errors.As(err, &transitionErr) {
+				t.Fatalf("ожидали *entity.TransitionError, получили %T", err)
+			}
+			if !errors.Is(err, entity.ErrInvalidTransition) {
+				t.Errorf("ошибка должна оборачивать ErrInvalidTransition")
+			}
+		})
+	}
+}
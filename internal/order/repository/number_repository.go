@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// OrderNumberRepository выдает номера заказов за день.
+type OrderNumberRepository interface {
+	// Next атомарно выдает следующий номер заказа за день day, начиная с 1.
+	Next(ctx context.Context, day time.Time) (int, error)
+}
@@ -3,19 +3,33 @@ package repository
 import (
 	"coffe/internal/order/entity"
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 // OrderRepository определяет методы для работы с заказами.
 type OrderRepository interface {
-	Create(ctx context.Context, order *entity.Order) error                                // создание заказа
-	GetByID(ctx context.Context, id uuid.UUID) (*entity.Order, error)                     // поиск по id
-	Update(ctx context.Context, order *entity.Order) error                                // обновление заказа
-	Delete(ctx context.Context, id uuid.UUID) error                                       // удаление заказа
-	GetByCustomer(ctx context.Context, customerID uuid.UUID) ([]*entity.Order, error)     // заказы клиента
-	GetByStatus(ctx context.Context, status entity.OrderStatus) ([]*entity.Order, error)  // заказы по статусу
-	UpdateStatus(ctx context.Context, orderID uuid.UUID, status entity.OrderStatus) error // обновление статуса
-	Count(ctx context.Context) (int64, error)                                             // количество заказов
-	GetToday(ctx context.Context) ([]*entity.Order, error)                                // заказы за сегодня
+	Create(ctx context.Context, order *entity.Order) error                               // создание заказа
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Order, error)                    // поиск по id
+	LockByID(ctx context.Context, id uuid.UUID) (*entity.Order, error)                   // поиск по id с блокировкой строки до конца транзакции
+	Update(ctx context.Context, order *entity.Order) error                               // обновление заказа
+	Delete(ctx context.Context, id uuid.UUID) error                                      // удаление заказа
+	GetByCustomer(ctx context.Context, customerID uuid.UUID) ([]*entity.Order, error)    // заказы клиента
+	GetByStatus(ctx context.Context, status entity.OrderStatus) ([]*entity.Order, error) // заказы по статусу
+	Count(ctx context.Context) (int64, error)                                            // количество заказов
+	GetToday(ctx context.Context) ([]*entity.Order, error)                               // заказы за сегодня
+	GetForBoard(ctx context.Context, since time.Time) ([]*entity.Order, error)           // готовящиеся и готовые заказы с начала дня
+
+	// Предзаказы
+	LockPickupSlot(ctx context.Context, slot time.Time) error                       // блокировка слота получения до конца транзакции
+	GetPickupTimes(ctx context.Context, from, to time.Time) ([]time.Time, error)    // время получения неотмененных заказов в периоде
+	GetDuePreorders(ctx context.Context, before time.Time) ([]*entity.Order, error) // ожидающие предзаказы со слотом до before
+
+	// SetFiscal сохраняет реквизиты фискального чека заказа.
+	SetFiscal(ctx context.Context, orderID uuid.UUID, fiscal entity.FiscalData) error
+
+	// История статусов
+	UpdateStatus(ctx context.Context, change *entity.OrderStatusHistory) error                     // смена статуса с записью в историю
+	GetStatusHistory(ctx context.Context, orderID uuid.UUID) ([]*entity.OrderStatusHistory, error) // история статусов заказа
 }
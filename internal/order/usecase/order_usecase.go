@@ -1,25 +1,169 @@
 package usecase
 
 import (
+	"coffe/internal/common"
+	commonrepository "coffe/internal/common/repository"
+	eventsentity "coffe/internal/events/entity"
+	inventoryentity "coffe/internal/inventory/entity"
+	loyaltyentity "coffe/internal/loyalty/entity"
+	menuentity "coffe/internal/menu/entity"
+	menurepository "coffe/internal/menu/repository"
 	"coffe/internal/order/entity"
 	"coffe/internal/order/repository"
+	promotionentity "coffe/internal/promotion/entity"
+	userentity "coffe/internal/user/entity"
 	"context"
 	"errors"
+	"fmt"
+	"log"
+	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
+// ErrOrderNotFound возвращается, если заказ не найден.
+var ErrOrderNotFound = errors.New("заказ не найден")
+
+// ErrInvalidStatus возвращается, если передан неизвестный статус заказа.
+var ErrInvalidStatus = errors.New("неизвестный статус заказа")
+
+// ErrOverrideForbidden возвращается, если переход с подтверждением запрошен не менеджером.
+var ErrOverrideForbidden = errors.New("подтверждать особые переходы статуса может только менеджер")
+
+// StatusChangeRequest содержит данные для смены статуса заказа.
+type StatusChangeRequest struct {
+	OrderID   uuid.UUID
+	Status    entity.OrderStatus
+	ChangedBy uuid.UUID
+	Role      string // роль сотрудника, меняющего статус
+	Override  bool   // подтверждение менеджера для переходов вне штатного цикла
+	Comment   string
+}
+
+// ErrPaymentRequired возвращается при подтверждении онлайн-заказа или выполнении заказа,
+// который еще не оплачен полностью.
+var ErrPaymentRequired = errors.New("заказ не оплачен")
+
+// ErrProductUnavailable возвращается, если продукт нельзя заказать.
+var ErrProductUnavailable = errors.New("продукт недоступен для заказа")
+
+// StockConsumer списывает ингредиенты со склада при подтверждении заказа и возвращает
+// их при отмене подтвержденного заказа.
+type StockConsumer interface {
+	ConsumeForOrder(ctx context.Context, orderID uuid.UUID, lines []inventoryentity.OrderLine) error
+	RestockForOrder(ctx context.Context, orderID uuid.UUID, lines []inventoryentity.OrderLine) error
+}
+
+// DiscountEngine рассчитывает скидки заказа по акциям и учитывает применения промокодов.
+type DiscountEngine interface {
+	Calculate(ctx context.Context, cart promotionentity.Cart, code string) ([]promotionentity.AppliedDiscount, error)
+	RecordUsage(ctx context.Context, customerID, orderID uuid.UUID, discounts []promotionentity.AppliedDiscount) error
+	ReleaseForOrder(ctx context.Context, orderID uuid.UUID) error
+}
+
+// LoyaltyLedger ведет баллы клиентов: оплату баллами, начисление и возврат при отмене.
+type LoyaltyLedger interface {
+	Redeem(ctx context.Context, customerID, orderID uuid.UUID, points int64, total common.Money) (common.Money, error)
+	EarnForOrder(ctx context.Context, customerID, orderID uuid.UUID, paid common.Money) error
+	ReverseForOrder(ctx context.Context, customerID, orderID uuid.UUID) error
+}
+
+// StampCards ведет штамп-карты клиентов: применяет награды к заказу, начисляет штампы
+// за выполненный заказ и отменяет их при отмене.
+type StampCards interface {
+	ApplyRewards(ctx context.Context, customerID, orderID uuid.UUID, lines []loyaltyentity.StampLine, limit common.Money) ([]*loyaltyentity.StampReward, error)
+	IssueForOrder(ctx context.Context, customerID, orderID uuid.UUID, lines []loyaltyentity.StampLine) error
+	ReverseForOrder(ctx context.Context, customerID, orderID uuid.UUID) error
+}
+
+// PaymentGate сообщает, сколько по заказу списано через платежный шлюз, и снимает
+// незавершенные платежи отмененного заказа.
+type PaymentGate interface {
+	CapturedAmount(ctx context.Context, orderID uuid.UUID) (common.Money, error)
+	ReleaseForOrder(ctx context.Context, orderID uuid.UUID) error
+}
+
+// TaxPolicies возвращает правила налогообложения заказа из меню.
+type TaxPolicies interface {
+	Policy(ctx context.Context, menuID *uuid.UUID) (*menuentity.TaxPolicy, error)
+}
+
+// FiscalQueue ставит в очередь фискальный чек выполненного заказа.
+type FiscalQueue interface {
+	Enqueue(ctx context.Context, order *entity.Order) error
+}
+
+// KitchenTickets создает тикеты станций кухни для заказа, переданного в приготовление.
+type KitchenTickets interface {
+	OpenForOrder(ctx context.Context, order *entity.Order) error
+}
+
+// EventPublisher рассылает события заказов подписчикам в реальном времени.
+type EventPublisher interface {
+	Publish(ctx context.Context, event *eventsentity.Event)
+}
+
 // OrderUsecase реализует бизнес-логику для работы с заказами.
 type OrderUsecase struct {
-	orderRepo repository.OrderRepository
+	orderRepo   repository.OrderRepository
+	productRepo menurepository.ProductRepository
+	menuRepo    menurepository.MenuRepository
+	txManager   commonrepository.TransactionManager
+	stock       StockConsumer
+	discounts   DiscountEngine
+	loyalty     LoyaltyLedger
+	stamps      StampCards
+	payments    PaymentGate
+	taxes       TaxPolicies
+	fiscal      FiscalQueue
+	numbers     repository.OrderNumberRepository
+	events      EventPublisher
+	kitchen     KitchenTickets
+	schedule    entity.PickupSchedule
 }
 
 // NewOrderUsecase создает новый экземпляр OrderUsecase.
-func NewOrderUsecase(orderRepo repository.OrderRepository) *OrderUsecase {
-	return &OrderUsecase{orderRepo: orderRepo}
+func NewOrderUsecase(
+	orderRepo repository.OrderRepository,
+	productRepo menurepository.ProductRepository,
+	menuRepo menurepository.MenuRepository,
+	txManager commonrepository.TransactionManager,
+	stock StockConsumer,
+	discounts DiscountEngine,
+	loyalty LoyaltyLedger,
+	stamps StampCards,
+	payments PaymentGate,
+	taxes TaxPolicies,
+	fiscal FiscalQueue,
+	numbers repository.OrderNumberRepository,
+	events EventPublisher,
+	kitchen KitchenTickets,
+	schedule entity.PickupSchedule,
+) *OrderUsecase {
+	return &OrderUsecase{
+		orderRepo:   orderRepo,
+		productRepo: productRepo,
+		menuRepo:    menuRepo,
+		txManager:   txManager,
+		stock:       stock,
+		discounts:   discounts,
+		loyalty:     loyalty,
+		stamps:      stamps,
+		payments:    payments,
+		taxes:       taxes,
+		fiscal:      fiscal,
+		numbers:     numbers,
+		events:      events,
+		kitchen:     kitchen,
+		schedule:    schedule,
+	}
 }
 
-// Create создает новый заказ.
+// Create создает новый заказ и выдает ему номер за день, по которому заказ называют
+// при выдаче. Номер выдается до транзакции, поэтому при ошибке в нумерации возможны пропуски.
+// Предзаказ с временем получения бронирует место в слоте и получает номер дня получения;
+// если слот заполнен, возвращается entity.ErrSlotFull.
 func (u *OrderUsecase) Create(ctx context.Context, order *entity.Order) error {
 	if order.CustomerID == uuid.Nil {
 		return errors.New("customer_id не может быть пустым")
@@ -27,7 +171,343 @@ func (u *OrderUsecase) Create(ctx context.Context, order *entity.Order) error {
 	if len(order.Items) == 0 {
 		return errors.New("заказ не может быть пустым")
 	}
-	return u.orderRepo.Create(ctx, order)
+	if order.PointsRedeemed < 0 {
+		return errors.New("количество баллов не может быть отрицательным")
+	}
+	for _, item := range order.Items {
+		if item.ProductID == uuid.Nil {
+			return errors.New("product_id не может быть пустым")
+		}
+		if item.Quantity <= 0 {
+			return errors.New("количество должно быть больше нуля")
+		}
+	}
+
+	numberDay := time.Now()
+	if order.IsPreorder() {
+		slot, err := u.schedule.Validate(*order.PickupAt, time.Now())
+		if err != nil {
+			return err
+		}
+		order.PickupAt = &slot
+		numberDay = slot
+	}
+
+	cart, err := u.priceItems(ctx, order)
+	if err != nil {
+		return err
+	}
+	cart.CustomerID = order.CustomerID
+	policy, err := u.taxes.Policy(ctx, order.MenuID)
+	if err != nil {
+		return err
+	}
+
+	order.Number, err = u.numbers.Next(ctx, numberDay)
+	if err != nil {
+		return fmt.Errorf("номер заказа: %w", err)
+	}
+	order.Id = uuid.New()
+	order.Status = entity.OrderStatusPending
+	for i := range order.Items {
+		item := &order.Items[i]
+		item.ID = uuid.New()
+		item.OrderID = order.Id
+		for j := range item.Modifiers {
+			item.Modifiers[j].ID = uuid.New()
+			item.Modifiers[j].ItemID = item.ID
+		}
+	}
+
+	err = u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		applied, err := u.discounts.Calculate(ctx, cart, order.PromoCode)
+		if err != nil {
+			return err
+		}
+		rewards, err := u.stamps.ApplyRewards(ctx, order.CustomerID, order.Id, stampLines(cart), order.TotalPrice.Sub(appliedTotal(applied)))
+		if err != nil {
+			return err
+		}
+		applyDiscounts(order, applied, rewards)
+		applyTax(order, policy, cart)
+
+		order.PaidWithPoints, err = u.loyalty.Redeem(ctx, order.CustomerID, order.Id, order.PointsRedeemed, order.TotalPrice)
+		if err != nil {
+			return err
+		}
+		if err := order.ApplyTip(); err != nil {
+			return err
+		}
+		if order.IsPreorder() {
+			if err := u.reserveSlot(ctx, *order.PickupAt); err != nil {
+				return err
+			}
+		}
+
+		if err := u.orderRepo.Create(ctx, order); err != nil {
+			return err
+		}
+		return u.discounts.RecordUsage(ctx, order.CustomerID, order.Id, applied)
+	})
+	if err != nil {
+		return err
+	}
+
+	u.events.Publish(ctx, eventsentity.NewOrderEvent(eventsentity.EventOrderCreated, order))
+	return nil
+}
+
+// reserveSlot проверяет, что в слоте получения есть место. Слот блокируется до конца
+// транзакции, поэтому параллельные заказы не превышают его вместимость.
+func (u *OrderUsecase) reserveSlot(ctx context.Context, slot time.Time) error {
+	if err := u.orderRepo.LockPickupSlot(ctx, slot); err != nil {
+		return err
+	}
+	booked, err := u.orderRepo.GetPickupTimes(ctx, slot, slot.Add(u.schedule.SlotLength))
+	if err != nil {
+		return err
+	}
+	if len(booked) >= u.schedule.Capacity {
+		return fmt.Errorf("%w: %s", entity.ErrSlotFull, slot.Format("15:04"))
+	}
+	return nil
+}
+
+// PickupSlots возвращает слоты получения дня day со свободными местами.
+func (u *OrderUsecase) PickupSlots(ctx context.Context, day time.Time) ([]entity.PickupSlot, error) {
+	from, to := u.schedule.DayBounds(day)
+	booked, err := u.orderRepo.GetPickupTimes(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return u.schedule.Slots(day, time.Now(), booked), nil
+}
+
+// ReleasePreorders переводит в статус "подтвержден" ожидающие предзаказы, до слота
+// получения которых осталось не больше Lead, и возвращает их число. Неоплаченные
+// онлайн-предзаказы остаются в ожидании до оплаты.
+func (u *OrderUsecase) ReleasePreorders(ctx context.Context) (int, error) {
+	orders, err := u.orderRepo.GetDuePreorders(ctx, time.Now().Add(u.schedule.Lead))
+	if err != nil {
+		return 0, err
+	}
+	released := 0
+	for _, order := range orders {
+		err := u.UpdateStatus(ctx, StatusChangeRequest{
+			OrderID: order.Id,
+			Status:  entity.OrderStatusConfirmed,
+			Comment: "предзаказ передан в работу по расписанию",
+		})
+		switch {
+		case err == nil:
+			released++
+		case errors.Is(err, ErrPaymentRequired), errors.Is(err, entity.ErrInvalidTransition), errors.Is(err, entity.ErrStatusConflict):
+			// заказ еще не оплачен или его статус уже изменили
+		default:
+			log.Printf("предзаказ %d (%s): %v", order.Number, order.Id, err)
+		}
+	}
+	return released, nil
+}
+
+// RunScheduler передает предзаказы в работу каждые interval до отмены ctx.
+func (u *OrderUsecase) RunScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := u.ReleasePreorders(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("расписание предзаказов: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// applyDiscounts записывает скидки по акциям и наградам штамп-карт в заказ отдельными
+// строками и уменьшает сумму к оплате.
+func applyDiscounts(order *entity.Order, applied []promotionentity.AppliedDiscount, rewards []*loyaltyentity.StampReward) {
+	order.Discounts = make([]entity.OrderDiscount, 0, len(applied)+len(rewards))
+	for _, discount := range applied {
+		promotionID := discount.PromotionID
+		order.Discounts = append(order.Discounts, entity.OrderDiscount{
+			ID:          uuid.New(),
+			OrderID:     order.Id,
+			PromotionID: &promotionID,
+			Code:        discount.Code,
+			Name:        discount.Name,
+			Amount:      discount.Amount,
+		})
+	}
+	for _, reward := range rewards {
+		order.Discounts = append(order.Discounts, entity.OrderDiscount{
+			ID:            uuid.New(),
+			OrderID:       order.Id,
+			StampRewardID: &reward.ID,
+			Name:          "Награда штамп-карты",
+			Amount:        reward.Amount,
+		})
+	}
+	order.TotalPrice = order.Subtotal().Sub(order.DiscountTotal())
+}
+
+// applyTax распределяет скидки между позициями пропорционально их стоимости и
+// рассчитывает налог по каждой позиции и по заказу. Если цены не включают налог,
+// он добавляется к сумме заказа.
+func applyTax(order *entity.Order, policy *menuentity.TaxPolicy, cart promotionentity.Cart) {
+	weights := make([]int64, len(order.Items))
+	for i, item := range order.Items {
+		weights[i] = item.Price.Mul(int64(item.Quantity)).Amount
+	}
+	amounts := order.TotalPrice.AllocateBy(weights)
+
+	order.TaxInclusive = policy.Inclusive
+	order.NetTotal = common.NewMoney(0)
+	order.TaxTotal = common.NewMoney(0)
+	gross := common.NewMoney(0)
+	for i := range order.Items {
+		item := &order.Items[i]
+		amount := common.NewMoney(0)
+		if amounts != nil {
+			amount = amounts[i]
+		}
+
+		item.TaxRate = policy.Rate(cart.Lines[i].Category)
+		var itemGross common.Money
+		item.NetAmount, item.TaxAmount, itemGross = policy.Split(amount, item.TaxRate)
+		order.NetTotal = order.NetTotal.Add(item.NetAmount)
+		order.TaxTotal = order.TaxTotal.Add(item.TaxAmount)
+		gross = gross.Add(itemGross)
+	}
+	order.TotalPrice = gross
+}
+
+// appliedTotal возвращает сумму скидок по акциям.
+func appliedTotal(applied []promotionentity.AppliedDiscount) common.Money {
+	total := common.NewMoney(0)
+	for _, discount := range applied {
+		total = total.Add(discount.Amount)
+	}
+	return total
+}
+
+// stampLines собирает позиции корзины для применения наград штамп-карт.
+func stampLines(cart promotionentity.Cart) []loyaltyentity.StampLine {
+	lines := make([]loyaltyentity.StampLine, 0, len(cart.Lines))
+	for _, line := range cart.Lines {
+		lines = append(lines, loyaltyentity.StampLine{
+			ProductID: line.ProductID,
+			Category:  line.Category,
+			Quantity:  line.Quantity,
+			UnitPrice: line.UnitPrice,
+		})
+	}
+	return lines
+}
+
+// priceItems фиксирует в позициях текущие цены каталога и пересчитывает сумму заказа.
+// Цены, переданные клиентом, игнорируются. Возвращает позиции для расчета скидок.
+func (u *OrderUsecase) priceItems(ctx context.Context, order *entity.Order) (promotionentity.Cart, error) {
+	cart := promotionentity.Cart{Lines: make([]promotionentity.CartLine, 0, len(order.Items))}
+	total := common.NewMoney(0)
+	for i := range order.Items {
+		item := &order.Items[i]
+
+		product, err := u.productRepo.GetByID(ctx, item.ProductID)
+		if err != nil {
+			return cart, fmt.Errorf("%w: продукт %s не найден", ErrProductUnavailable, item.ProductID)
+		}
+		if !product.IsActive {
+			return cart, fmt.Errorf("%w: %s снят с продажи", ErrProductUnavailable, product.Name)
+		}
+
+		inMenu, err := u.menuRepo.IsProductInActiveMenu(ctx, product.ID)
+		if err != nil {
+			return cart, err
+		}
+		if !inMenu {
+			return cart, fmt.Errorf("%w: %s отсутствует в действующем меню", ErrProductUnavailable, product.Name)
+		}
+
+		price, err := variantPrice(product, item)
+		if err != nil {
+			return cart, err
+		}
+		price, err = modifiersPrice(product, item, price)
+		if err != nil {
+			return cart, err
+		}
+
+		item.Name = product.Name
+		item.Price = price
+		total = total.Add(price.Mul(int64(item.Quantity)))
+		cart.Lines = append(cart.Lines, promotionentity.CartLine{
+			ProductID: product.ID,
+			Category:  product.Category,
+			Quantity:  item.Quantity,
+			UnitPrice: price,
+		})
+	}
+
+	order.TotalPrice = total
+	return cart, nil
+}
+
+// variantPrice определяет цену позиции с учетом выбранного варианта. Если клиент не указал
+// вариант, используется вариант продукта по умолчанию, а при его отсутствии - цена продукта.
+func variantPrice(product *menuentity.Product, item *entity.ItemsOrders) (common.Money, error) {
+	var variant *menuentity.ProductVariant
+	if item.VariantID != nil {
+		variant = product.Variant(*item.VariantID)
+		if variant == nil || !variant.IsActive {
+			return common.Money{}, fmt.Errorf("%w: у продукта %s нет варианта %s", ErrProductUnavailable, product.Name, *item.VariantID)
+		}
+	} else {
+		variant = product.DefaultVariant()
+	}
+
+	if variant == nil {
+		return product.Price, nil
+	}
+	price := variant.PriceFor(product.Price)
+	if !price.IsPositive() {
+		return common.Money{}, fmt.Errorf("%w: некорректная цена варианта %s продукта %s", ErrProductUnavailable, variant.Name, product.Name)
+	}
+	item.VariantID = &variant.ID
+	item.VariantName = variant.Name
+	return price, nil
+}
+
+// modifiersPrice проверяет выбранные модификаторы по группам продукта, фиксирует их
+// в позиции и добавляет их надбавки к цене единицы.
+func modifiersPrice(product *menuentity.Product, item *entity.ItemsOrders, price common.Money) (common.Money, error) {
+	ids := make([]uuid.UUID, 0, len(item.Modifiers))
+	for _, modifier := range item.Modifiers {
+		ids = append(ids, modifier.ModifierID)
+	}
+	selected, err := product.SelectModifiers(ids)
+	if err != nil {
+		return common.Money{}, err
+	}
+
+	groupNames := make(map[uuid.UUID]string, len(product.ModifierGroups))
+	for _, group := range product.ModifierGroups {
+		groupNames[group.ID] = group.Name
+	}
+
+	item.Modifiers = make([]entity.ItemModifier, 0, len(selected))
+	for _, modifier := range selected {
+		item.Modifiers = append(item.Modifiers, entity.ItemModifier{
+			ModifierID: modifier.ID,
+			GroupName:  groupNames[modifier.GroupID],
+			Name:       modifier.Name,
+			PriceDelta: modifier.PriceDelta,
+		})
+		price = price.Add(modifier.PriceDelta)
+	}
+	return price, nil
 }
 
 // GetByID возвращает заказ по идентификатору.
@@ -35,7 +515,14 @@ func (u *OrderUsecase) GetByID(ctx context.Context, id uuid.UUID) (*entity.Order
 	if id == uuid.Nil {
 		return nil, errors.New("id не может быть пустым")
 	}
-	return u.orderRepo.GetByID(ctx, id)
+	order, err := u.orderRepo.GetByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ошибка получения заказа: %w", err)
+	}
+	return order, nil
 }
 
 // Update обновляет заказ.
@@ -64,14 +551,165 @@ func (u *OrderUsecase) GetByCustomer(ctx context.Context, customerID uuid.UUID)
 
 // GetByStatus возвращает заказы по статусу.
 func (u *OrderUsecase) GetByStatus(ctx context.Context, status entity.OrderStatus) ([]*entity.Order, error) {
+	if !status.IsValid() {
+		return nil, ErrInvalidStatus
+	}
 	return u.orderRepo.GetByStatus(ctx, status)
 }
 
-func (u *OrderUsecase) UpdateStatus(ctx context.Context, orderID uuid.UUID, status entity.OrderStatus) error {
-	if orderID == uuid.Nil {
+// UpdateStatus переводит заказ в новый статус по таблице переходов и записывает изменение в историю.
+// При подтверждении заказа ингредиенты списываются со склада в той же транзакции;
+// при их нехватке статус не меняется и возвращается *inventoryentity.ShortageError.
+// При отмене подтвержденного заказа ингредиенты возвращаются на склад. Заказ, переданный в приготовление, получает тикеты станций кухни.
+// Онлайн-заказ нельзя подтвердить, пока его оплата не списана, а любой заказ нельзя
+// выполнить, пока платежи не покрывают сумму к оплате; при отмене незавершенные
+// платежи снимаются. За выполненный заказ клиенту начисляются баллы и штампы,
+// а фискальный чек ставится в очередь; при отмене баллы и штампы возвращаются,
+// а применения акций освобождаются.
+func (u *OrderUsecase) UpdateStatus(ctx context.Context, req StatusChangeRequest) error {
+	if req.OrderID == uuid.Nil {
 		return errors.New("order_id не может быть пустым")
 	}
-	return u.orderRepo.UpdateStatus(ctx, orderID, status)
+	if !req.Status.IsValid() {
+		return ErrInvalidStatus
+	}
+	if req.Override && req.Role != userentity.RoleManager && req.Role != userentity.RoleAdmin {
+		return ErrOverrideForbidden
+	}
+
+	order, err := u.GetByID(ctx, req.OrderID)
+	if err != nil {
+		return err
+	}
+
+	if err := entity.CanTransition(order.Status, req.Status, req.Override); err != nil {
+		return err
+	}
+	if req.Status == entity.OrderStatusCompleted || req.Status == entity.OrderStatusConfirmed && order.PaymentMethod.IsOnline() {
+		if err := u.checkPaid(ctx, order); err != nil {
+			return err
+		}
+	}
+
+	change := &entity.OrderStatusHistory{
+		ID:         uuid.New(),
+		OrderID:    order.Id,
+		FromStatus: order.Status,
+		ToStatus:   req.Status,
+		ChangedBy:  req.ChangedBy,
+		Override:   req.Override,
+		Comment:    req.Comment,
+	}
+	err = u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := u.orderRepo.UpdateStatus(ctx, change); err != nil {
+			return err
+		}
+
+		switch req.Status {
+		case entity.OrderStatusConfirmed:
+			return u.stock.ConsumeForOrder(ctx, order.Id, orderLines(order))
+		case entity.OrderStatusPreparing:
+			return u.kitchen.OpenForOrder(ctx, order)
+		case entity.OrderStatusCompleted:
+			if err := u.loyalty.EarnForOrder(ctx, order.CustomerID, order.Id, order.AmountDue()); err != nil {
+				return err
+			}
+			if err := u.stamps.IssueForOrder(ctx, order.CustomerID, order.Id, itemStampLines(order)); err != nil {
+				return err
+			}
+			return u.fiscal.Enqueue(ctx, order)
+		case entity.OrderStatusCancelled:
+			if err := u.loyalty.ReverseForOrder(ctx, order.CustomerID, order.Id); err != nil {
+				return err
+			}
+			if err := u.stamps.ReverseForOrder(ctx, order.CustomerID, order.Id); err != nil {
+				return err
+			}
+			if change.FromStatus == entity.OrderStatusConfirmed {
+				// заказ еще не начали готовить, списанные ингредиенты возвращаются на склад
+				if err := u.stock.RestockForOrder(ctx, order.Id, orderLines(order)); err != nil {
+					return err
+				}
+			}
+			return u.discounts.ReleaseForOrder(ctx, order.Id)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if req.Status == entity.OrderStatusCancelled {
+		// шлюз вызывается после фиксации отмены; если снять платеж не удалось,
+		// авторизация истечет в шлюзе без списания
+		if err := u.payments.ReleaseForOrder(ctx, order.Id); err != nil {
+			log.Printf("заказ %d (%s): не удалось снять платежи: %v", order.Number, order.Id, err)
+		}
+	}
+
+	eventType := eventsentity.EventOrderStatusChanged
+	if req.Status == entity.OrderStatusCancelled {
+		eventType = eventsentity.EventOrderCancelled
+	}
+	order.Status = req.Status
+	event := eventsentity.NewOrderEvent(eventType, order)
+	event.PreviousStatus = change.FromStatus
+	u.events.Publish(ctx, event)
+	return nil
+}
+
+// checkPaid проверяет, что платежи заказа покрывают сумму к оплате.
+func (u *OrderUsecase) checkPaid(ctx context.Context, order *entity.Order) error {
+	due := order.AmountDue()
+	if !due.IsPositive() {
+		return nil
+	}
+	paid, err := u.payments.CapturedAmount(ctx, order.Id)
+	if err != nil {
+		return err
+	}
+	if paid.Cmp(due) < 0 {
+		return fmt.Errorf("%w: оплачено %s из %s", ErrPaymentRequired, paid, due)
+	}
+	return nil
+}
+
+// orderLines собирает позиции заказа для расчета расхода ингредиентов.
+func orderLines(order *entity.Order) []inventoryentity.OrderLine {
+	lines := make([]inventoryentity.OrderLine, 0, len(order.Items))
+	for _, item := range order.Items {
+		modifierIDs := make([]uuid.UUID, 0, len(item.Modifiers))
+		for _, modifier := range item.Modifiers {
+			modifierIDs = append(modifierIDs, modifier.ModifierID)
+		}
+		lines = append(lines, inventoryentity.OrderLine{
+			ProductID:   item.ProductID,
+			VariantID:   item.VariantID,
+			ModifierIDs: modifierIDs,
+			Quantity:    item.Quantity,
+		})
+	}
+	return lines
+}
+
+// itemStampLines собирает позиции заказа для начисления штампов.
+func itemStampLines(order *entity.Order) []loyaltyentity.StampLine {
+	lines := make([]loyaltyentity.StampLine, 0, len(order.Items))
+	for _, item := range order.Items {
+		lines = append(lines, loyaltyentity.StampLine{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+			UnitPrice: item.Price,
+		})
+	}
+	return lines
+}
+
+// GetStatusHistory возвращает историю изменения статусов заказа.
+func (u *OrderUsecase) GetStatusHistory(ctx context.Context, orderID uuid.UUID) ([]*entity.OrderStatusHistory, error) {
+	if orderID == uuid.Nil {
+		return nil, errors.New("order_id не может быть пустым")
+	}
+	return u.orderRepo.GetStatusHistory(ctx, orderID)
 }
 
 func (u *OrderUsecase) Count(ctx context.Context) (int64, error) {
@@ -81,3 +719,14 @@ func (u *OrderUsecase) Count(ctx context.Context) (int64, error) {
 func (u *OrderUsecase) GetToday(ctx context.Context) ([]*entity.Order, error) {
 	return u.orderRepo.GetToday(ctx)
 }
+
+// Board возвращает табло выдачи: номера заказов за сегодня, которые готовятся и готовы.
+func (u *OrderUsecase) Board(ctx context.Context) (*entity.Board, error) {
+	now := time.Now()
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	orders, err := u.orderRepo.GetForBoard(ctx, startOfDay)
+	if err != nil {
+		return nil, err
+	}
+	return entity.NewBoard(orders, now), nil
+}
@@ -0,0 +1,385 @@
+package usecase_test
+
+import (
+	"coffe/internal/common"
+	"coffe/internal/common/repository/repositorytest"
+	inventoryentity "coffe/internal/inventory/entity"
+	menuentity "coffe/internal/menu/entity"
+	"coffe/internal/order/entity"
+	"coffe/internal/order/usecase"
+	"coffe/internal/order/usecase/mocks"
+	userentity "coffe/internal/user/entity"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+)
+
+type orderMocks struct {
+	orderRepo   *mocks.MockOrderRepository
+	productRepo *mocks.MockProductRepository
+	menuRepo    *mocks.MockMenuRepository
+	stock       *mocks.MockStockConsumer
+	discounts   *mocks.MockDiscountEngine
+	loyalty     *mocks.MockLoyaltyLedger
+	stamps      *mocks.MockStampCards
+	payments    *mocks.MockPaymentGate
+	taxes       *mocks.MockTaxPolicies
+	fiscal      *mocks.MockFiscalQueue
+	numbers     *mocks.MockOrderNumberRepository
+	events      *mocks.MockEventPublisher
+	kitchen     *mocks.MockKitchenTickets
+}
+
+// testSchedule принимает предзаказы круглосуточно по одному заказу в слот.
+var testSchedule = entity.PickupSchedule{
+	SlotLength: 10 * time.Minute,
+	Capacity:   1,
+	Closes:     24 * time.Hour,
+	MaxDays:    1,
+}
+
+func newOrderUsecase(t *testing.T) (*usecase.OrderUsecase, orderMocks) {
+	ctrl := gomock.NewController(t)
+	m := orderMocks{
+		orderRepo:   mocks.NewMockOrderRepository(ctrl),
+		productRepo: mocks.NewMockProductRepository(ctrl),
+		menuRepo:    mocks.NewMockMenuRepository(ctrl),
+		stock:       mocks.NewMockStockConsumer(ctrl),
+		discounts:   mocks.NewMockDiscountEngine(ctrl),
+		loyalty:     mocks.NewMockLoyaltyLedger(ctrl),
+		stamps:      mocks.NewMockStampCards(ctrl),
+		payments:    mocks.NewMockPaymentGate(ctrl),
+		taxes:       mocks.NewMockTaxPolicies(ctrl),
+		fiscal:      mocks.NewMockFiscalQueue(ctrl),
+		numbers:     mocks.NewMockOrderNumberRepository(ctrl),
+		events:      mocks.NewMockEventPublisher(ctrl),
+		kitchen:     mocks.NewMockKitchenTickets(ctrl),
+	}
+	orders := usecase.NewOrderUsecase(m.orderRepo, m.productRepo, m.menuRepo, repositorytest.InlineTx{}, m.stock, m.discounts,
+		m.loyalty, m.stamps, m.payments, m.taxes, m.fiscal, m.numbers, m.events, m.kitchen, testSchedule)
+	return orders, m
+}
+
+func TestOrderUsecase_UpdateStatus_InvalidTransition(t *testing.T) {
+	orders, m := newOrderUsecase(t)
+	ctx := context.Background()
+	order := &entity.Order{Id: uuid.New(), Status: entity.OrderStatusPending}
+
+	m.orderRepo.EXPECT().GetByID(ctx, order.Id).Return(order, nil)
+
+	err := orders.UpdateStatus(ctx, usecase.StatusChangeRequest{OrderID: order.Id, Status: entity.OrderStatusCompleted})
+	if !errors.Is(err, entity.ErrInvalidTransition) {
+		t.Errorf("ожидали ErrInvalidTransition, получили %v", err)
+	}
+}
+
+func TestOrderUsecase_UpdateStatus_OverrideForbidden(t *testing.T) {
+	orders, _ := newOrderUsecase(t)
+
+	err := orders.UpdateStatus(context.Background(), usecase.StatusChangeRequest{
+		OrderID:  uuid.New(),
+		Status:   entity.OrderStatusCancelled,
+		Override: true,
+		Role:     userentity.RoleClient,
+	})
+	if !errors.Is(err, usecase.ErrOverrideForbidden) {
+		t.Errorf("ожидали ErrOverrideForbidden, получили %v", err)
+	}
+}
+
+func TestOrderUsecase_UpdateStatus_Conflict(t *testing.T) {
+	orders, m := newOrderUsecase(t)
+	ctx := context.Background()
+	order := &entity.Order{Id: uuid.New(), Status: entity.OrderStatusConfirmed}
+
+	m.orderRepo.EXPECT().GetByID(ctx, order.Id).Return(order, nil)
+	m.orderRepo.EXPECT().UpdateStatus(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, change *entity.OrderStatusHistory) error {
+		if change.FromStatus != entity.OrderStatusConfirmed || change.ToStatus != entity.OrderStatusPreparing || change.Comment != "в работу" {
+			t.Errorf("неверная запись истории: %+v", change)
+		}
+		return entity.ErrStatusConflict
+	})
+
+	err := orders.UpdateStatus(ctx, usecase.StatusChangeRequest{OrderID: order.Id, Status: entity.OrderStatusPreparing, Comment: "в работу"})
+	if !errors.Is(err, entity.ErrStatusConflict) {
+		t.Errorf("ожидали ErrStatusConflict, получили %v", err)
+	}
+}
+
+// expectPricing настраивает каталог и расчет скидок для заказа из одного продукта.
+func expectPricing(ctx context.Context, m orderMocks, product *menuentity.Product, policy *menuentity.TaxPolicy) {
+	m.productRepo.EXPECT().GetByID(ctx, product.ID).Return(product, nil)
+	m.menuRepo.EXPECT().IsProductInActiveMenu(ctx, product.ID).Return(true, nil)
+	m.taxes.EXPECT().Policy(ctx, gomock.Any()).Return(policy, nil)
+	m.numbers.EXPECT().Next(ctx, gomock.Any()).Return(7, nil)
+	m.discounts.EXPECT().Calculate(ctx, gomock.Any(), "").Return(nil, nil)
+	m.stamps.EXPECT().ApplyRewards(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	m.loyalty.EXPECT().Redeem(ctx, gomock.Any(), gomock.Any(), int64(0), gomock.Any()).Return(common.NewMoney(0), nil)
+}
+
+// expectCreated настраивает сохранение заказа и событие о нем.
+func expectCreated(ctx context.Context, m orderMocks, order *entity.Order) {
+	m.orderRepo.EXPECT().Create(ctx, order).Return(nil)
+	m.discounts.EXPECT().RecordUsage(ctx, order.CustomerID, gomock.Any(), gomock.Any()).Return(nil)
+	m.events.EXPECT().Publish(ctx, gomock.Any())
+}
+
+func TestOrderUsecase_Create(t *testing.T) {
+	orders, m := newOrderUsecase(t)
+	ctx := context.Background()
+	product := &menuentity.Product{ID: uuid.New(), Name: "Латте", Category: "coffee", Price: common.NewMoney(25000), IsActive: true}
+	order := &entity.Order{
+		CustomerID: uuid.New(),
+		Items:      []entity.ItemsOrders{{ProductID: product.ID, Quantity: 2, Price: common.NewMoney(100)}},
+	}
+
+	expectPricing(ctx, m, product, &menuentity.TaxPolicy{Inclusive: true})
+	expectCreated(ctx, m, order)
+
+	if err := orders.Create(ctx, order); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if order.Number != 7 || order.Status != entity.OrderStatusPending {
+		t.Errorf("заказ должен получить номер и статус ожидания: %d, %q", order.Number, order.Status)
+	}
+	if order.TotalPrice.Cmp(common.NewMoney(50000)) != 0 || order.Items[0].Name != "Латте" {
+		t.Errorf("цена должна браться из каталога: %s, %+v", order.TotalPrice, order.Items[0])
+	}
+}
+
+func TestOrderUsecase_Create_InactiveProduct(t *testing.T) {
+	orders, m := newOrderUsecase(t)
+	ctx := context.Background()
+	product := &menuentity.Product{ID: uuid.New(), Name: "Латте", Price: common.NewMoney(25000)}
+	order := &entity.Order{CustomerID: uuid.New(), Items: []entity.ItemsOrders{{ProductID: product.ID, Quantity: 1}}}
+
+	m.productRepo.EXPECT().GetByID(ctx, product.ID).Return(product, nil)
+
+	if err := orders.Create(ctx, order); !errors.Is(err, usecase.ErrProductUnavailable) {
+		t.Errorf("ожидали ErrProductUnavailable, получили %v", err)
+	}
+}
+
+func TestOrderUsecase_UpdateStatus_CancelConfirmed(t *testing.T) {
+	orders, m := newOrderUsecase(t)
+	ctx := context.Background()
+	productID := uuid.New()
+	order := &entity.Order{
+		Id:            uuid.New(),
+		CustomerID:    uuid.New(),
+		Status:        entity.OrderStatusConfirmed,
+		PaymentMethod: entity.PaymentMethodCash,
+		Items:         []entity.ItemsOrders{{ProductID: productID, Quantity: 2}},
+	}
+
+	m.orderRepo.EXPECT().GetByID(ctx, order.Id).Return(order, nil)
+	m.orderRepo.EXPECT().UpdateStatus(ctx, gomock.Any()).Return(nil)
+	m.loyalty.EXPECT().ReverseForOrder(ctx, order.CustomerID, order.Id).Return(nil)
+	m.stamps.EXPECT().ReverseForOrder(ctx, order.CustomerID, order.Id).Return(nil)
+	m.stock.EXPECT().RestockForOrder(ctx, order.Id, []inventoryentity.OrderLine{{ProductID: productID, ModifierIDs: []uuid.UUID{}, Quantity: 2}}).Return(nil)
+	m.discounts.EXPECT().ReleaseForOrder(ctx, order.Id).Return(nil)
+	m.payments.EXPECT().ReleaseForOrder(ctx, order.Id).Return(nil)
+	m.events.EXPECT().Publish(ctx, gomock.Any())
+
+	if err := orders.UpdateStatus(ctx, usecase.StatusChangeRequest{OrderID: order.Id, Status: entity.OrderStatusCancelled}); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+}
+
+func TestOrderUsecase_Create_NonPositiveVariantPrice(t *testing.T) {
+	orders, m := newOrderUsecase(t)
+	ctx := context.Background()
+	variant := &menuentity.ProductVariant{ID: uuid.New(), Name: "S", PriceDelta: common.NewMoney(-25000), IsActive: true}
+	product := &menuentity.Product{ID: uuid.New(), Name: "Латте", Price: common.NewMoney(25000), IsActive: true, Variants: []*menuentity.ProductVariant{variant}}
+	order := &entity.Order{CustomerID: uuid.New(), Items: []entity.ItemsOrders{{ProductID: product.ID, VariantID: &variant.ID, Quantity: 1}}}
+
+	m.productRepo.EXPECT().GetByID(ctx, product.ID).Return(product, nil)
+	m.menuRepo.EXPECT().IsProductInActiveMenu(ctx, product.ID).Return(true, nil)
+
+	if err := orders.Create(ctx, order); !errors.Is(err, usecase.ErrProductUnavailable) {
+		t.Errorf("позиция с нулевой ценой не должна продаваться, получили %v", err)
+	}
+}
+
+func TestOrderUsecase_UpdateStatus_CancelReleasesDiscounts(t *testing.T) {
+	orders, m := newOrderUsecase(t)
+	ctx := context.Background()
+	order := &entity.Order{Id: uuid.New(), CustomerID: uuid.New(), Status: entity.OrderStatusPending, PaymentMethod: entity.PaymentMethodCash}
+
+	m.orderRepo.EXPECT().GetByID(ctx, order.Id).Return(order, nil)
+	m.orderRepo.EXPECT().UpdateStatus(ctx, gomock.Any()).Return(nil)
+	m.loyalty.EXPECT().ReverseForOrder(ctx, order.CustomerID, order.Id).Return(nil)
+	m.stamps.EXPECT().ReverseForOrder(ctx, order.CustomerID, order.Id).Return(nil)
+	m.discounts.EXPECT().ReleaseForOrder(ctx, order.Id).Return(nil)
+	m.payments.EXPECT().ReleaseForOrder(ctx, order.Id).Return(nil)
+	m.events.EXPECT().Publish(ctx, gomock.Any())
+
+	// ингредиенты неподтвержденного заказа не списывались, возвращать на склад нечего
+	if err := orders.UpdateStatus(ctx, usecase.StatusChangeRequest{OrderID: order.Id, Status: entity.OrderStatusCancelled}); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+}
+
+func TestOrderUsecase_UpdateStatus_CancelReleaseError(t *testing.T) {
+	orders, m := newOrderUsecase(t)
+	ctx := context.Background()
+	order := &entity.Order{Id: uuid.New(), CustomerID: uuid.New(), Status: entity.OrderStatusPending}
+
+	m.orderRepo.EXPECT().GetByID(ctx, order.Id).Return(order, nil)
+	m.orderRepo.EXPECT().UpdateStatus(ctx, gomock.Any()).Return(nil)
+	m.loyalty.EXPECT().ReverseForOrder(ctx, order.CustomerID, order.Id).Return(nil)
+	m.stamps.EXPECT().ReverseForOrder(ctx, order.CustomerID, order.Id).Return(nil)
+	m.discounts.EXPECT().ReleaseForOrder(ctx, order.Id).Return(errors.New("нет связи с базой"))
+
+	err := orders.UpdateStatus(ctx, usecase.StatusChangeRequest{OrderID: order.Id, Status: entity.OrderStatusCancelled})
+	if err == nil {
+		t.Fatal("ошибка освобождения акций должна отменять смену статуса")
+	}
+}
+
+func TestOrderUsecase_UpdateStatus_CompleteUnpaid(t *testing.T) {
+	orders, m := newOrderUsecase(t)
+	ctx := context.Background()
+	order := &entity.Order{
+		Id:            uuid.New(),
+		CustomerID:    uuid.New(),
+		Status:        entity.OrderStatusReady,
+		PaymentMethod: entity.PaymentMethodOnline,
+		TotalPrice:    common.NewMoney(50000),
+	}
+
+	m.orderRepo.EXPECT().GetByID(ctx, order.Id).Return(order, nil)
+	m.payments.EXPECT().CapturedAmount(ctx, order.Id).Return(common.NewMoney(30000), nil)
+
+	// заказ не закрывается, пока списано меньше суммы к оплате
+	err := orders.UpdateStatus(ctx, usecase.StatusChangeRequest{OrderID: order.Id, Status: entity.OrderStatusCompleted})
+	if !errors.Is(err, usecase.ErrPaymentRequired) {
+		t.Errorf("ожидали ErrPaymentRequired, получили %v", err)
+	}
+}
+
+func TestOrderUsecase_UpdateStatus_CancelPaymentReleaseError(t *testing.T) {
+	orders, m := newOrderUsecase(t)
+	ctx := context.Background()
+	order := &entity.Order{Id: uuid.New(), CustomerID: uuid.New(), Status: entity.OrderStatusPending, PaymentMethod: entity.PaymentMethodOnline}
+
+	m.orderRepo.EXPECT().GetByID(ctx, order.Id).Return(order, nil)
+	m.orderRepo.EXPECT().UpdateStatus(ctx, gomock.Any()).Return(nil)
+	m.loyalty.EXPECT().ReverseForOrder(ctx, order.CustomerID, order.Id).Return(nil)
+	m.stamps.EXPECT().ReverseForOrder(ctx, order.CustomerID, order.Id).Return(nil)
+	m.discounts.EXPECT().ReleaseForOrder(ctx, order.Id).Return(nil)
+	m.payments.EXPECT().ReleaseForOrder(ctx, order.Id).Return(errors.New("шлюз недоступен"))
+	m.events.EXPECT().Publish(ctx, gomock.Any())
+
+	err := orders.UpdateStatus(ctx, usecase.StatusChangeRequest{OrderID: order.Id, Status: entity.OrderStatusCancelled})
+	if err != nil {
+		t.Fatalf("ошибка шлюза после отмены не должна возвращаться: %v", err)
+	}
+	if order.Status != entity.OrderStatusCancelled {
+		t.Errorf("ожидали статус %q, получили %q", entity.OrderStatusCancelled, order.Status)
+	}
+}
+
+func TestOrderUsecase_Create_TipPercent(t *testing.T) {
+	orders, m := newOrderUsecase(t)
+	ctx := context.Background()
+	product := &menuentity.Product{ID: uuid.New(), Name: "Латте", Price: common.NewMoney(25000), IsActive: true}
+	order := &entity.Order{
+		CustomerID: uuid.New(),
+		TipPercent: 10,
+		Items:      []entity.ItemsOrders{{ProductID: product.ID, Quantity: 2}},
+	}
+
+	expectPricing(ctx, m, product, &menuentity.TaxPolicy{Inclusive: true})
+	expectCreated(ctx, m, order)
+
+	if err := orders.Create(ctx, order); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	// чаевые считаются от суммы заказа и не входят в нее
+	if order.Tip.Cmp(common.NewMoney(5000)) != 0 || order.TotalPrice.Cmp(common.NewMoney(50000)) != 0 {
+		t.Errorf("ожидали чаевые 50.00 к заказу на 500.00, получили %s и %s", order.Tip, order.TotalPrice)
+	}
+}
+
+func TestOrderUsecase_Create_TaxExclusive(t *testing.T) {
+	orders, m := newOrderUsecase(t)
+	ctx := context.Background()
+	product := &menuentity.Product{ID: uuid.New(), Name: "Латте", Category: "coffee", Price: common.NewMoney(25000), IsActive: true}
+	order := &entity.Order{
+		CustomerID: uuid.New(),
+		Items:      []entity.ItemsOrders{{ProductID: product.ID, Quantity: 2}},
+	}
+
+	expectPricing(ctx, m, product, &menuentity.TaxPolicy{Inclusive: false, DefaultRate: 20})
+	expectCreated(ctx, m, order)
+
+	if err := orders.Create(ctx, order); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	// налог 20% начисляется сверх цены: 500 ₽ + 100 ₽
+	if order.TaxInclusive || order.NetTotal.Cmp(common.NewMoney(50000)) != 0 || order.TaxTotal.Cmp(common.NewMoney(10000)) != 0 {
+		t.Errorf("неверный налог: без налога %s, налог %s", order.NetTotal, order.TaxTotal)
+	}
+	if order.TotalPrice.Cmp(common.NewMoney(60000)) != 0 || order.Items[0].TaxAmount.Cmp(common.NewMoney(10000)) != 0 {
+		t.Errorf("ожидали итог 600.00 с налогом позиции 100.00, получили %s и %s", order.TotalPrice, order.Items[0].TaxAmount)
+	}
+}
+
+func TestOrderUsecase_Create_SlotFull(t *testing.T) {
+	orders, m := newOrderUsecase(t)
+	ctx := context.Background()
+	product := &menuentity.Product{ID: uuid.New(), Name: "Латте", Price: common.NewMoney(25000), IsActive: true}
+	tomorrow := time.Now().AddDate(0, 0, 1)
+	pickup := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 12, 0, 0, 0, time.Local)
+	order := &entity.Order{
+		CustomerID: uuid.New(),
+		PickupAt:   &pickup,
+		Items:      []entity.ItemsOrders{{ProductID: product.ID, Quantity: 1}},
+	}
+
+	expectPricing(ctx, m, product, &menuentity.TaxPolicy{Inclusive: true})
+	m.orderRepo.EXPECT().LockPickupSlot(ctx, pickup).Return(nil)
+	m.orderRepo.EXPECT().GetPickupTimes(ctx, pickup, pickup.Add(testSchedule.SlotLength)).Return([]time.Time{pickup}, nil)
+
+	if err := orders.Create(ctx, order); !errors.Is(err, entity.ErrSlotFull) {
+		t.Errorf("ожидали ErrSlotFull, получили %v", err)
+	}
+}
+
+func TestOrderUsecase_ReleasePreorders(t *testing.T) {
+	orders, m := newOrderUsecase(t)
+	ctx := context.Background()
+	unpaid := &entity.Order{Id: uuid.New(), Status: entity.OrderStatusPending, PaymentMethod: entity.PaymentMethodOnline, TotalPrice: common.NewMoney(20000)}
+	changed := &entity.Order{Id: uuid.New(), Status: entity.OrderStatusPending, PaymentMethod: entity.PaymentMethodCash}
+	due := &entity.Order{Id: uuid.New(), Status: entity.OrderStatusPending, PaymentMethod: entity.PaymentMethodCash}
+
+	m.orderRepo.EXPECT().GetDuePreorders(ctx, gomock.Any()).Return([]*entity.Order{unpaid, changed, due}, nil)
+
+	// неоплаченный онлайн-предзаказ остается в ожидании
+	m.orderRepo.EXPECT().GetByID(ctx, unpaid.Id).Return(unpaid, nil)
+	m.payments.EXPECT().CapturedAmount(ctx, unpaid.Id).Return(common.NewMoney(0), nil)
+
+	// статус заказа уже изменили параллельно
+	m.orderRepo.EXPECT().GetByID(ctx, changed.Id).Return(changed, nil)
+	m.orderRepo.EXPECT().UpdateStatus(ctx, gomock.Any()).Return(entity.ErrStatusConflict)
+
+	m.orderRepo.EXPECT().GetByID(ctx, due.Id).Return(due, nil)
+	m.orderRepo.EXPECT().UpdateStatus(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, change *entity.OrderStatusHistory) error {
+		if change.OrderID != due.Id || change.ToStatus != entity.OrderStatusConfirmed {
+			t.Errorf("неверная смена статуса: %+v", change)
+		}
+		return nil
+	})
+	m.stock.EXPECT().ConsumeForOrder(ctx, due.Id, gomock.Any()).Return(nil)
+	m.events.EXPECT().Publish(ctx, gomock.Any())
+
+	released, err := orders.ReleasePreorders(ctx)
+	if err != nil || released != 1 {
+		t.Fatalf("ожидали один переданный в работу предзаказ, получили %d, %v", released, err)
+	}
+}
@@ -0,0 +1,351 @@
+package http
+
+import (
+	"coffe/internal/common"
+	orderentity "coffe/internal/order/entity"
+	"coffe/internal/payment/entity"
+	"coffe/internal/payment/provider"
+	"coffe/internal/payment/usecase"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// maxWebhookSize ограничивает размер тела уведомления шлюза.
+const maxWebhookSize = 1 << 20
+
+// RefundRequest содержит параметры возврата. Без позиций возвращается весь остаток платежа.
+type RefundRequest struct {
+	PaymentID *uuid.UUID          `json:"payment_id"`
+	Items     []RefundItemRequest `json:"items" binding:"dive"`
+	Restock   bool                `json:"restock"`
+	Reason    string              `json:"reason" binding:"required"`
+}
+
+// RefundItemRequest описывает возвращаемую позицию заказа.
+type RefundItemRequest struct {
+	ItemID   uuid.UUID `json:"item_id" binding:"required"`
+	Quantity int       `json:"quantity" binding:"required,gt=0"`
+}
+
+// RecordPaymentRequest содержит оплату, принятую на кассе. Без суммы при оплате
+// позиций списывается их доля в сумме заказа.
+type RecordPaymentRequest struct {
+	Method   string               `json:"method" binding:"required,oneof=Наличка Карта"`
+	Amount   common.Money         `json:"amount"`
+	Tip      common.Money         `json:"tip"`
+	Tendered common.Money         `json:"tendered"`
+	Items    []PaymentItemRequest `json:"items" binding:"dive"`
+}
+
+// PaymentItemRequest описывает позицию заказа, оплачиваемую отдельным платежом.
+type PaymentItemRequest struct {
+	ItemID   uuid.UUID `json:"item_id" binding:"required"`
+	Quantity int       `json:"quantity" binding:"required,gt=0"`
+}
+
+type PaymentHandler struct {
+	paymentUsecase *usecase.PaymentUsecase
+	refundUsecase  *usecase.RefundUsecase
+}
+
+func NewPaymentHandler(paymentUsecase *usecase.PaymentUsecase, refundUsecase *usecase.RefundUsecase) *PaymentHandler {
+	return &PaymentHandler{paymentUsecase: paymentUsecase, refundUsecase: refundUsecase}
+}
+
+// оплата своего заказа онлайн; повторный запрос возвращает незавершенный платеж
+func (h *PaymentHandler) PayOrder(ctx *gin.Context) {
+	userInterface, exists := ctx.Get("user")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Пользователь не авторизован"})
+		return
+	}
+	user, ok := userInterface.(*common.User)
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения пользователя"})
+		return
+	}
+
+	orderID, ok := uuidParam(ctx, "id", "Неверный формат ID заказа")
+	if !ok {
+		return
+	}
+
+	payment, err := h.paymentUsecase.Pay(ctx.Request.Context(), orderID, user.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrOrderNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Заказ не найден"})
+		case errors.Is(err, usecase.ErrNotPayable):
+			ctx.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, payment)
+}
+
+// платежи заказа (для персонала)
+func (h *PaymentHandler) GetOrderPayments(ctx *gin.Context) {
+	orderID, ok := uuidParam(ctx, "id", "Неверный формат ID заказа")
+	if !ok {
+		return
+	}
+
+	summary, err := h.paymentUsecase.GetSummary(ctx.Request.Context(), orderID)
+	if err != nil {
+		if errors.Is(err, usecase.ErrOrderNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Заказ не найден"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения платежей"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, summary)
+}
+
+// оплата заказа на кассе наличными или картой, в том числе частями (для персонала)
+func (h *PaymentHandler) RecordPayment(ctx *gin.Context) {
+	userInterface, exists := ctx.Get("user")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Пользователь не авторизован"})
+		return
+	}
+	user, ok := userInterface.(*common.User)
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения пользователя"})
+		return
+	}
+
+	orderID, ok := uuidParam(ctx, "id", "Неверный формат ID заказа")
+	if !ok {
+		return
+	}
+
+	var req RecordPaymentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат данных", "details": err.Error()})
+		return
+	}
+
+	paymentReq := usecase.RecordPaymentRequest{
+		OrderID:    orderID,
+		Method:     orderentity.PaymentMethod(req.Method),
+		Amount:     req.Amount,
+		Tip:        req.Tip,
+		Tendered:   req.Tendered,
+		RecordedBy: user.ID,
+	}
+	for _, item := range req.Items {
+		paymentReq.Items = append(paymentReq.Items, usecase.PaymentItemRequest{ItemID: item.ItemID, Quantity: item.Quantity})
+	}
+
+	payment, err := h.paymentUsecase.RecordPayment(ctx.Request.Context(), paymentReq)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrOrderNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Заказ не найден"})
+		case errors.Is(err, usecase.ErrInvalidPayment), errors.Is(err, usecase.ErrNotPayable):
+			ctx.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка оплаты заказа"})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, payment)
+}
+
+// деление остатка к оплате заказа на равные части (для персонала)
+func (h *PaymentHandler) SplitBill(ctx *gin.Context) {
+	orderID, ok := uuidParam(ctx, "id", "Неверный формат ID заказа")
+	if !ok {
+		return
+	}
+	parts, err := strconv.Atoi(ctx.DefaultQuery("parts", "2"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверное количество частей"})
+		return
+	}
+
+	amounts, err := h.paymentUsecase.SplitEvenly(ctx.Request.Context(), orderID, parts)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrOrderNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Заказ не найден"})
+		case errors.Is(err, usecase.ErrInvalidPayment):
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка деления счета"})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"order_id": orderID,
+		"parts":    amounts,
+	})
+}
+
+// списание авторизованного платежа (для персонала)
+func (h *PaymentHandler) CapturePayment(ctx *gin.Context) {
+	id, ok := uuidParam(ctx, "id", "Неверный формат ID платежа")
+	if !ok {
+		return
+	}
+
+	payment, err := h.paymentUsecase.Capture(ctx.Request.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrPaymentNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, entity.ErrInvalidPaymentTransition):
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, payment)
+}
+
+// уведомление платежного шлюза об изменении платежа
+func (h *PaymentHandler) Webhook(ctx *gin.Context) {
+	body, err := io.ReadAll(io.LimitReader(ctx.Request.Body, maxWebhookSize))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Ошибка чтения уведомления"})
+		return
+	}
+
+	if err := h.paymentUsecase.HandleWebhook(ctx.Request.Context(), ctx.Request.Header, body); err != nil {
+		switch {
+		case errors.Is(err, provider.ErrInvalidSignature):
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		case errors.Is(err, usecase.ErrPaymentNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Уведомление обработано"})
+}
+
+// возврат денег по выполненному или отмененному заказу (для персонала)
+func (h *PaymentHandler) RefundOrder(ctx *gin.Context) {
+	userInterface, exists := ctx.Get("user")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Пользователь не авторизован"})
+		return
+	}
+	user, ok := userInterface.(*common.User)
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения пользователя"})
+		return
+	}
+
+	orderID, ok := uuidParam(ctx, "id", "Неверный формат ID заказа")
+	if !ok {
+		return
+	}
+
+	var req RefundRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат данных", "details": err.Error()})
+		return
+	}
+
+	refundReq := usecase.RefundRequest{
+		OrderID:     orderID,
+		PaymentID:   req.PaymentID,
+		Restock:     req.Restock,
+		Reason:      req.Reason,
+		RequestedBy: user.ID,
+	}
+	if user.Role != nil {
+		refundReq.Role = user.Role.Name
+	}
+	for _, item := range req.Items {
+		refundReq.Lines = append(refundReq.Lines, usecase.RefundLineRequest{ItemID: item.ItemID, Quantity: item.Quantity})
+	}
+
+	refund, err := h.refundUsecase.Refund(ctx.Request.Context(), refundReq)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrOrderNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Заказ не найден"})
+		case errors.Is(err, usecase.ErrPaymentNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, usecase.ErrApprovalRequired):
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, usecase.ErrInvalidRefund), errors.Is(err, usecase.ErrNotRefundable):
+			ctx.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, refund)
+}
+
+// возвраты заказа (для персонала)
+func (h *PaymentHandler) GetOrderRefunds(ctx *gin.Context) {
+	orderID, ok := uuidParam(ctx, "id", "Неверный формат ID заказа")
+	if !ok {
+		return
+	}
+
+	refunds, err := h.refundUsecase.GetByOrder(ctx.Request.Context(), orderID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения возвратов"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"order_id": orderID,
+		"refunds":  refunds,
+		"total":    len(refunds),
+	})
+}
+
+// итоги платежей и возвратов за день для сверки кассы; ?date=2006-01-02, по умолчанию сегодня
+func (h *PaymentHandler) GetDailyTotals(ctx *gin.Context) {
+	day := time.Now()
+	if date := ctx.Query("date"); date != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", date, time.Local)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат даты, ожидается ГГГГ-ММ-ДД"})
+			return
+		}
+		day = parsed
+	}
+
+	totals, err := h.refundUsecase.GetDailyTotals(ctx.Request.Context(), day)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения итогов"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, totals)
+}
+
+// uuidParam разбирает UUID из URL параметра
+func uuidParam(ctx *gin.Context, name, invalidMessage string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param(name))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": invalidMessage})
+		return uuid.Nil, false
+	}
+	return id, true
+}
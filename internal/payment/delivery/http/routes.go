@@ -0,0 +1,35 @@
+package http
+
+import (
+	"coffe/internal/middleware"
+	userentity "coffe/internal/user/entity"
+	"coffe/internal/user/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupPaymentRoutes настраивает маршруты оплаты заказов
+func SetupPaymentRoutes(router *gin.RouterGroup, handler *PaymentHandler, jwtMiddleware *middleware.JWTMiddleware, permissionUC usecase.PermissionUsecase) {
+	// Уведомления шлюза подписаны и не требуют авторизации
+	router.POST("/payments/webhook", handler.Webhook)
+
+	customer := router.Group("/orders")
+	customer.Use(jwtMiddleware.Authenticate())
+	{
+		customer.POST("/:id/payments", handler.PayOrder)
+		// Возврат оформляет любой сотрудник с правом refund_order, крупный - только менеджер
+		customer.POST("/:id/refunds", middleware.PermissionMiddleware(permissionUC, "refund_order"), handler.RefundOrder)
+	}
+
+	staff := router.Group("")
+	staff.Use(jwtMiddleware.Authenticate())
+	staff.Use(jwtMiddleware.RequireRole(userentity.RoleAdmin, userentity.RoleManager))
+	{
+		staff.GET("/orders/:id/payments", middleware.PermissionMiddleware(permissionUC, "read_order"), handler.GetOrderPayments)
+		staff.POST("/orders/:id/payments/offline", middleware.PermissionMiddleware(permissionUC, "update_order"), handler.RecordPayment)
+		staff.GET("/orders/:id/split", middleware.PermissionMiddleware(permissionUC, "read_order"), handler.SplitBill)
+		staff.POST("/payments/:id/capture", middleware.PermissionMiddleware(permissionUC, "update_order"), handler.CapturePayment)
+		staff.GET("/orders/:id/refunds", middleware.PermissionMiddleware(permissionUC, "read_order"), handler.GetOrderRefunds)
+		staff.GET("/admin/payments/daily", middleware.PermissionMiddleware(permissionUC, "read_order"), handler.GetDailyTotals)
+	}
+}
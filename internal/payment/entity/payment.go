@@ -0,0 +1,136 @@
+package entity
+
+import (
+	"coffe/internal/common"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidPaymentTransition возвращается при недопустимом переходе статуса платежа.
+var ErrInvalidPaymentTransition = errors.New("недопустимый переход статуса платежа")
+
+// PaymentStatus определяет статус платежа.
+type PaymentStatus string
+
+const (
+	PaymentPending           PaymentStatus = "ожидает"            // создан, ждет подтверждения клиентом
+	PaymentAuthorized        PaymentStatus = "авторизован"        // сумма заблокирована на счете клиента
+	PaymentCaptured          PaymentStatus = "оплачен"            // деньги списаны
+	PaymentPartiallyRefunded PaymentStatus = "частично возвращен" // часть суммы возвращена клиенту
+	PaymentRefunded          PaymentStatus = "возвращен"          // вся сумма возвращена клиенту
+	PaymentFailed            PaymentStatus = "отклонен"           // платеж отклонен шлюзом
+	PaymentCancelled         PaymentStatus = "отменен"            // авторизация снята без списания
+)
+
+// paymentTransitions описывает жизненный цикл платежа:
+// ожидает → авторизован → оплачен → частично возвращен → возвращен.
+// Шлюз может сразу списать деньги, минуя авторизацию.
+var paymentTransitions = map[PaymentStatus][]PaymentStatus{
+	PaymentPending:           {PaymentAuthorized, PaymentCaptured, PaymentFailed, PaymentCancelled},
+	PaymentAuthorized:        {PaymentCaptured, PaymentFailed, PaymentCancelled},
+	PaymentCaptured:          {PaymentPartiallyRefunded, PaymentRefunded},
+	PaymentPartiallyRefunded: {PaymentPartiallyRefunded, PaymentRefunded},
+}
+
+// Payment представляет платеж по заказу: онлайн через платежный шлюз или наличными
+// и картой на кассе. Заказ может быть оплачен несколькими платежами.
+type Payment struct {
+	ID              uuid.UUID     `json:"id" db:"id"`
+	OrderID         uuid.UUID     `json:"order_id" db:"order_id" gorm:"index"`
+	Provider        string        `json:"provider,omitempty" db:"provider"`                    // пусто - оплата на кассе
+	ExternalID      string        `json:"external_id,omitempty" db:"external_id" gorm:"index"` // ID платежа в шлюзе
+	Method          string        `json:"method" db:"method"`
+	Amount          common.Money  `json:"amount" db:"amount"`
+	Tip             common.Money  `json:"tip" db:"tip"`                                           // чаевые сверх Amount, не входят в выручку
+	Tendered        common.Money  `json:"tendered" db:"tendered"`                                 // получено наличными от клиента
+	Change          common.Money  `json:"change" db:"change"`                                     // выданная сдача
+	Lines           []PaymentLine `json:"lines,omitempty" db:"lines" gorm:"foreignKey:PaymentID"` // позиции, оплаченные этим платежом
+	RefundedAmount  common.Money  `json:"refunded_amount" db:"refunded_amount"`
+	RefundedTip     common.Money  `json:"refunded_tip" db:"refunded_tip"` // чаевые, возвращенные клиенту при полном возврате
+	Status          PaymentStatus `json:"status" db:"status"`
+	ConfirmationURL string        `json:"confirmation_url,omitempty" db:"confirmation_url"` // страница подтверждения оплаты клиентом
+	FailureReason   string        `json:"failure_reason,omitempty" db:"failure_reason"`
+	CreatedBy       *uuid.UUID    `json:"created_by,omitempty" db:"created_by"` // кассир, принявший оплату на кассе
+	CapturedAt      *time.Time    `json:"captured_at,omitempty" db:"captured_at"`
+	CreatedAt       time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time     `json:"updated_at" db:"updated_at"`
+}
+
+// PaymentLine хранит позицию заказа, оплаченную отдельным платежом при разделении счета.
+type PaymentLine struct {
+	ID        uuid.UUID    `json:"id" db:"id"`
+	PaymentID uuid.UUID    `json:"payment_id" db:"payment_id" gorm:"index"`
+	ItemID    uuid.UUID    `json:"item_id" db:"item_id"`
+	Quantity  int          `json:"quantity" db:"quantity"`
+	Amount    common.Money `json:"amount" db:"amount"`
+}
+
+// Summary описывает состояние оплаты заказа.
+type Summary struct {
+	OrderID     uuid.UUID    `json:"order_id"`
+	Due         common.Money `json:"due"`         // сумма к оплате деньгами
+	Paid        common.Money `json:"paid"`        // списано за вычетом возвратов
+	Outstanding common.Money `json:"outstanding"` // осталось оплатить
+	Tip         common.Money `json:"tip"`         // чаевые, указанные в заказе
+	TipPaid     common.Money `json:"tip_paid"`    // чаевые, полученные с платежами, за вычетом возвращенных
+	IsPaid      bool         `json:"is_paid"`
+	Payments    []*Payment   `json:"payments"`
+}
+
+// IsActive сообщает, что платеж еще может быть оплачен.
+func (p *Payment) IsActive() bool {
+	return p.Status == PaymentPending || p.Status == PaymentAuthorized
+}
+
+// IsCaptured сообщает, что деньги по платежу списаны.
+func (p *Payment) IsCaptured() bool {
+	return p.Status == PaymentCaptured || p.Status == PaymentPartiallyRefunded || p.Status == PaymentRefunded
+}
+
+// Charged возвращает сумму, которую платеж списывает с клиента: оплату заказа и чаевые.
+func (p *Payment) Charged() common.Money {
+	return p.Amount.Add(p.Tip)
+}
+
+// Paid возвращает списанную сумму за вычетом возвратов.
+func (p *Payment) Paid() common.Money {
+	if !p.IsCaptured() {
+		return common.NewMoney(0)
+	}
+	return p.Amount.Sub(p.RefundedAmount)
+}
+
+// TipPaid возвращает списанные чаевые за вычетом возвращенных.
+func (p *Payment) TipPaid() common.Money {
+	if !p.IsCaptured() {
+		return common.NewMoney(0)
+	}
+	return p.Tip.Sub(p.RefundedTip)
+}
+
+// Transition переводит платеж в статус to. Повторный переход в тот же статус ничего
+// не меняет, чтобы повторные уведомления шлюза обрабатывались без ошибок.
+func (p *Payment) Transition(to PaymentStatus, at time.Time) error {
+	if p.Status == to && to != PaymentPartiallyRefunded {
+		return nil
+	}
+	allowed := false
+	for _, status := range paymentTransitions[p.Status] {
+		if status == to {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return fmt.Errorf("%w: %q → %q", ErrInvalidPaymentTransition, p.Status, to)
+	}
+
+	p.Status = to
+	if to == PaymentCaptured {
+		p.CapturedAt = &at
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package entity_test
+
+import (
+	"coffe/internal/common"
+	"coffe/internal/payment/entity"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPayment_Transition(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    entity.PaymentStatus
+		to      entity.PaymentStatus
+		wantErr bool
+	}{
+		{"ожидает → авторизован", entity.PaymentPending, entity.PaymentAuthorized, false},
+		{"ожидает → оплачен", entity.PaymentPending, entity.PaymentCaptured, false},
+		{"авторизован → оплачен", entity.PaymentAuthorized, entity.PaymentCaptured, false},
+		{"авторизован → отменен", entity.PaymentAuthorized, entity.PaymentCancelled, false},
+		{"повторное уведомление", entity.PaymentCaptured, entity.PaymentCaptured, false},
+		{"оплачен → возвращен", entity.PaymentCaptured, entity.PaymentRefunded, false},
+		{"оплачен → авторизован", entity.PaymentCaptured, entity.PaymentAuthorized, true},
+		{"отмена списанного", entity.PaymentCaptured, entity.PaymentCancelled, true},
+		{"оплата отклоненного", entity.PaymentFailed, entity.PaymentCaptured, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payment := &entity.Payment{Status: tt.from}
+			err := payment.Transition(tt.to, time.Now())
+			if tt.wantErr {
+				if !errors.Is(err, entity.ErrInvalidPaymentTransition) {
+					t.Fatalf("ожидали ErrInvalidPaymentTransition, получили %v", err)
+				}
+				if payment.Status != tt.from {
+					t.Errorf("статус изменился после ошибки: %q", payment.Status)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("не ожидали ошибку, получили: %v", err)
+			}
+			if payment.Status != tt.to {
+				t.Errorf("ожидали статус %q, получили %q", tt.to, payment.Status)
+			}
+		})
+	}
+}
+
+func TestPayment_Paid(t *testing.T) {
+	payment := &entity.Payment{Amount: common.NewMoney(50000), RefundedAmount: common.NewMoney(0), Status: entity.PaymentAuthorized}
+	if !payment.Paid().IsZero() {
+		t.Errorf("авторизованный платеж не должен считаться оплаченным: %s", payment.Paid())
+	}
+
+	payment.Status = entity.PaymentPartiallyRefunded
+	payment.RefundedAmount = common.NewMoney(15000)
+	if got := payment.Paid(); got.Amount != 35000 {
+		t.Errorf("ожидали 350 ₽ за вычетом возврата, получили %s", got)
+	}
+}
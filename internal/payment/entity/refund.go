@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"coffe/internal/common"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Refund представляет возврат денег по платежу заказа: полный или по отдельным позициям.
+type Refund struct {
+	ID         uuid.UUID    `json:"id" db:"id"`
+	PaymentID  uuid.UUID    `json:"payment_id" db:"payment_id" gorm:"index"`
+	OrderID    uuid.UUID    `json:"order_id" db:"order_id" gorm:"index"`
+	Amount     common.Money `json:"amount" db:"amount"`
+	Tip        common.Money `json:"tip" db:"tip"` // чаевые, возвращенные вместе с оплатой заказа
+	Reason     string       `json:"reason" db:"reason"`
+	Restock    bool         `json:"restock" db:"restock"` // ингредиенты возвращены на склад
+	Lines      []RefundLine `json:"lines,omitempty" db:"lines" gorm:"foreignKey:RefundID"`
+	CreatedBy  uuid.UUID    `json:"created_by" db:"created_by"`
+	ApprovedBy *uuid.UUID   `json:"approved_by,omitempty" db:"approved_by"` // менеджер, подтвердивший возврат выше порога
+	CreatedAt  time.Time    `json:"created_at" db:"created_at" gorm:"index"`
+}
+
+// RefundLine хранит возвращенную позицию заказа.
+type RefundLine struct {
+	ID        uuid.UUID    `json:"id" db:"id"`
+	RefundID  uuid.UUID    `json:"refund_id" db:"refund_id" gorm:"index"`
+	ItemID    uuid.UUID    `json:"item_id" db:"item_id"`
+	ProductID uuid.UUID    `json:"product_id" db:"product_id"`
+	Quantity  int          `json:"quantity" db:"quantity"`
+	Amount    common.Money `json:"amount" db:"amount"` // доля оплаты, приходящаяся на позицию
+}
+
+// Totals содержит итоги платежей и возвратов за период для сверки кассы.
+type Totals struct {
+	From     time.Time    `json:"from"`
+	To       time.Time    `json:"to"`
+	Captured common.Money `json:"captured"` // списано по платежам
+	Payments int64        `json:"payments"`
+	Refunded common.Money `json:"refunded"` // возвращено клиентам
+	Refunds  int64        `json:"refunds"`
+	Net      common.Money `json:"net"`
+	Tips     common.Money `json:"tips"` // чаевые персоналу, не входят в выручку
+}
@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"coffe/internal/common"
+	"coffe/internal/payment/entity"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// FakeName - название локального тестового шлюза.
+const FakeName = "fake"
+
+// SignatureHeader - заголовок с HMAC-SHA256 подписью тела уведомления.
+const SignatureHeader = "X-Signature"
+
+// declineKopecks - суммы с таким числом копеек тестовый шлюз отклоняет,
+// чтобы локально проверять неуспешную оплату.
+const declineKopecks = 13
+
+// FakeProvider - платежный шлюз в памяти для локального запуска и тестов. Авторизует
+// любой платеж, кроме сумм с 13 копейками, и подписывает уведомления общим секретом.
+type FakeProvider struct {
+	secret   []byte
+	mu       sync.Mutex
+	payments map[string]*fakePayment
+}
+
+type fakePayment struct {
+	amount   common.Money
+	captured common.Money
+	refunded common.Money
+	status   entity.PaymentStatus
+}
+
+// fakeWebhook - тело уведомления тестового шлюза.
+type fakeWebhook struct {
+	PaymentID string               `json:"payment_id"`
+	Status    entity.PaymentStatus `json:"status"`
+	Amount    common.Money         `json:"amount"`
+	Reason    string               `json:"reason,omitempty"`
+}
+
+// NewFakeProvider создает тестовый шлюз с секретом для подписи уведомлений.
+func NewFakeProvider(secret string) *FakeProvider {
+	return &FakeProvider{secret: []byte(secret), payments: make(map[string]*fakePayment)}
+}
+
+// Name возвращает название шлюза.
+func (p *FakeProvider) Name() string {
+	return FakeName
+}
+
+// Authorize создает платеж и сразу авторизует его.
+func (p *FakeProvider) Authorize(_ context.Context, req AuthorizeRequest) (*Result, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	externalID := "fake_" + req.PaymentID.String()
+	if payment, ok := p.payments[externalID]; ok {
+		return &Result{ExternalID: externalID, Status: payment.status}, nil
+	}
+
+	payment := &fakePayment{amount: req.Amount, status: entity.PaymentAuthorized}
+	result := &Result{ExternalID: externalID, Status: entity.PaymentAuthorized}
+	if req.Amount.Amount%100 == declineKopecks {
+		payment.status = entity.PaymentFailed
+		result.Status = entity.PaymentFailed
+		result.FailureReason = "платеж отклонен банком"
+	}
+	p.payments[externalID] = payment
+	return result, nil
+}
+
+// Capture списывает авторизованную сумму.
+func (p *FakeProvider) Capture(_ context.Context, externalID string, amount common.Money) (*Result, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	payment, err := p.payment(externalID)
+	if err != nil {
+		return nil, err
+	}
+	if payment.status != entity.PaymentAuthorized {
+		return nil, fmt.Errorf("платеж %s в статусе %q нельзя списать", externalID, payment.status)
+	}
+	if amount.Cmp(payment.amount) > 0 {
+		return nil, fmt.Errorf("сумма списания %s больше авторизованной %s", amount, payment.amount)
+	}
+
+	payment.captured = amount
+	payment.status = entity.PaymentCaptured
+	return &Result{ExternalID: externalID, Status: payment.status}, nil
+}
+
+// Void снимает авторизацию.
+func (p *FakeProvider) Void(_ context.Context, externalID string) (*Result, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	payment, err := p.payment(externalID)
+	if err != nil {
+		return nil, err
+	}
+	if payment.status != entity.PaymentAuthorized && payment.status != entity.PaymentPending {
+		return nil, fmt.Errorf("платеж %s в статусе %q нельзя отменить", externalID, payment.status)
+	}
+
+	payment.status = entity.PaymentCancelled
+	return &Result{ExternalID: externalID, Status: payment.status}, nil
+}
+
+// Refund возвращает часть или всю списанную сумму.
+func (p *FakeProvider) Refund(_ context.Context, externalID string, amount common.Money) (*Result, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	payment, err := p.payment(externalID)
+	if err != nil {
+		return nil, err
+	}
+	if payment.status != entity.PaymentCaptured && payment.status != entity.PaymentPartiallyRefunded {
+		return nil, fmt.Errorf("платеж %s в статусе %q нельзя вернуть", externalID, payment.status)
+	}
+	refunded := payment.refunded.Add(amount)
+	if refunded.Cmp(payment.captured) > 0 {
+		return nil, fmt.Errorf("сумма возврата больше списанной %s", payment.captured)
+	}
+
+	payment.refunded = refunded
+	payment.status = entity.PaymentPartiallyRefunded
+	if refunded.Cmp(payment.captured) == 0 {
+		payment.status = entity.PaymentRefunded
+	}
+	return &Result{ExternalID: externalID, Status: payment.status}, nil
+}
+
+// VerifyWebhook проверяет подпись уведомления и разбирает его.
+func (p *FakeProvider) VerifyWebhook(header http.Header, body []byte) (*Event, error) {
+	signature, err := hex.DecodeString(header.Get(SignatureHeader))
+	if err != nil || !hmac.Equal(signature, p.sign(body)) {
+		return nil, ErrInvalidSignature
+	}
+
+	var webhook fakeWebhook
+	if err := json.Unmarshal(body, &webhook); err != nil {
+		return nil, fmt.Errorf("некорректное уведомление: %w", err)
+	}
+	return &Event{
+		ExternalID:    webhook.PaymentID,
+		Status:        webhook.Status,
+		Amount:        webhook.Amount,
+		FailureReason: webhook.Reason,
+	}, nil
+}
+
+// Sign возвращает подпись тела уведомления для заголовка X-Signature.
+// Используется, чтобы отправлять уведомления тестового шлюза вручную.
+func (p *FakeProvider) Sign(body []byte) string {
+	return hex.EncodeToString(p.sign(body))
+}
+
+func (p *FakeProvider) sign(body []byte) []byte {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+func (p *FakeProvider) payment(externalID string) (*fakePayment, error) {
+	payment, ok := p.payments[externalID]
+	if !ok {
+		return nil, fmt.Errorf("платеж %s не найден", externalID)
+	}
+	return payment, nil
+}
@@ -0,0 +1,68 @@
+package provider_test
+
+import (
+	"coffe/internal/common"
+	"coffe/internal/payment/entity"
+	"coffe/internal/payment/provider"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestFakeProvider_Lifecycle(t *testing.T) {
+	fake := provider.NewFakeProvider("secret")
+	ctx := context.Background()
+	amount := common.NewMoney(45000)
+
+	result, err := fake.Authorize(ctx, provider.AuthorizeRequest{PaymentID: uuid.New(), Amount: amount})
+	if err != nil || result.Status != entity.PaymentAuthorized {
+		t.Fatalf("ожидали авторизацию, получили %+v, %v", result, err)
+	}
+	if result, err = fake.Capture(ctx, result.ExternalID, amount); err != nil || result.Status != entity.PaymentCaptured {
+		t.Fatalf("ожидали списание, получили %+v, %v", result, err)
+	}
+	if result, err = fake.Refund(ctx, result.ExternalID, common.NewMoney(15000)); err != nil || result.Status != entity.PaymentPartiallyRefunded {
+		t.Fatalf("ожидали частичный возврат, получили %+v, %v", result, err)
+	}
+	if _, err = fake.Refund(ctx, result.ExternalID, amount); err == nil {
+		t.Error("возврат больше списанной суммы должен отклоняться")
+	}
+	if _, err = fake.Void(ctx, result.ExternalID); err == nil {
+		t.Error("списанный платеж нельзя отменить")
+	}
+}
+
+func TestFakeProvider_Decline(t *testing.T) {
+	fake := provider.NewFakeProvider("secret")
+
+	result, err := fake.Authorize(context.Background(), provider.AuthorizeRequest{PaymentID: uuid.New(), Amount: common.NewMoney(45013)})
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if result.Status != entity.PaymentFailed || result.FailureReason == "" {
+		t.Errorf("сумма с 13 копейками должна отклоняться: %+v", result)
+	}
+}
+
+func TestFakeProvider_VerifyWebhook(t *testing.T) {
+	fake := provider.NewFakeProvider("secret")
+	body := []byte(`{"payment_id":"fake_1","status":"оплачен","amount":"450.00"}`)
+
+	header := http.Header{}
+	header.Set(provider.SignatureHeader, fake.Sign(body))
+	event, err := fake.VerifyWebhook(header, body)
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if event.ExternalID != "fake_1" || event.Status != entity.PaymentCaptured || event.Amount.Amount != 45000 {
+		t.Errorf("неверное уведомление: %+v", event)
+	}
+
+	header.Set(provider.SignatureHeader, provider.NewFakeProvider("other").Sign(body))
+	if _, err := fake.VerifyWebhook(header, body); !errors.Is(err, provider.ErrInvalidSignature) {
+		t.Errorf("ожидали ErrInvalidSignature, получили %v", err)
+	}
+}
@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"coffe/internal/common"
+	"coffe/internal/payment/entity"
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidSignature возвращается, если подпись уведомления шлюза не прошла проверку.
+var ErrInvalidSignature = errors.New("неверная подпись уведомления платежного шлюза")
+
+// Provider определяет операции платежного шлюза.
+type Provider interface {
+	Name() string                                                                         // название шлюза
+	Authorize(ctx context.Context, req AuthorizeRequest) (*Result, error)                 // создать платеж и заблокировать сумму
+	Capture(ctx context.Context, externalID string, amount common.Money) (*Result, error) // списать заблокированную сумму
+	Void(ctx context.Context, externalID string) (*Result, error)                         // снять авторизацию без списания
+	Refund(ctx context.Context, externalID string, amount common.Money) (*Result, error)  // вернуть списанную сумму полностью или частично
+
+	// VerifyWebhook проверяет подпись уведомления шлюза и разбирает его.
+	VerifyWebhook(header http.Header, body []byte) (*Event, error)
+}
+
+// AuthorizeRequest содержит данные нового платежа.
+type AuthorizeRequest struct {
+	PaymentID   uuid.UUID // ID платежа в системе, ключ идемпотентности
+	OrderID     uuid.UUID
+	Amount      common.Money
+	Description string
+}
+
+// Result описывает ответ шлюза на операцию с платежом.
+type Result struct {
+	ExternalID      string
+	Status          entity.PaymentStatus
+	ConfirmationURL string
+	FailureReason   string
+}
+
+// Event описывает уведомление шлюза об изменении платежа.
+type Event struct {
+	ExternalID    string
+	Status        entity.PaymentStatus
+	Amount        common.Money // сумма операции: списания или возврата
+	FailureReason string
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"coffe/internal/payment/entity"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PaymentRepository определяет методы для работы с платежами.
+type PaymentRepository interface {
+	Create(ctx context.Context, payment *entity.Payment) error                                 // создание платежа
+	Update(ctx context.Context, payment *entity.Payment) error                                 // обновление платежа
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Payment, error)                        // поиск по id
+	GetByOrder(ctx context.Context, orderID uuid.UUID) ([]*entity.Payment, error)              // платежи заказа
+	GetByExternalID(ctx context.Context, provider, externalID string) (*entity.Payment, error) // поиск по ID платежа в шлюзе
+
+	// LockByID блокирует платеж до конца транзакции, чтобы уведомления шлюза и действия
+	// персонала меняли его последовательно.
+	LockByID(ctx context.Context, id uuid.UUID) (*entity.Payment, error)
+
+	// Возвраты
+	CreateRefund(ctx context.Context, refund *entity.Refund) error                      // запись возврата с позициями
+	GetRefundsByOrder(ctx context.Context, orderID uuid.UUID) ([]*entity.Refund, error) // возвраты заказа с позициями
+
+	// GetTotals возвращает суммы списаний и возвратов за период [from, to).
+	GetTotals(ctx context.Context, from, to time.Time) (*entity.Totals, error)
+}
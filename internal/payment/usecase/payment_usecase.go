@@ -0,0 +1,326 @@
+package usecase
+
+import (
+	"coffe/internal/common"
+	commonrepository "coffe/internal/common/repository"
+	eventsentity "coffe/internal/events/entity"
+	orderentity "coffe/internal/order/entity"
+	orderrepository "coffe/internal/order/repository"
+	"coffe/internal/payment/entity"
+	"coffe/internal/payment/provider"
+	"coffe/internal/payment/repository"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrPaymentNotFound возвращается, если платеж не найден.
+var ErrPaymentNotFound = errors.New("платеж не найден")
+
+// ErrOrderNotFound возвращается, если оплачиваемый заказ не найден.
+var ErrOrderNotFound = errors.New("заказ не найден")
+
+// ErrNotPayable возвращается, если заказ нельзя оплатить через платежный шлюз.
+var ErrNotPayable = errors.New("заказ нельзя оплатить онлайн")
+
+// EventPublisher рассылает события заказов подписчикам в реальном времени.
+type EventPublisher interface {
+	Publish(ctx context.Context, event *eventsentity.Event)
+}
+
+// PaymentUsecase проводит оплату заказов через платежный шлюз и на кассе.
+type PaymentUsecase struct {
+	paymentRepo repository.PaymentRepository
+	orderRepo   orderrepository.OrderRepository
+	txManager   commonrepository.TransactionManager
+	provider    provider.Provider
+	events      EventPublisher
+}
+
+// NewPaymentUsecase создает новый экземпляр PaymentUsecase.
+func NewPaymentUsecase(
+	paymentRepo repository.PaymentRepository,
+	orderRepo orderrepository.OrderRepository,
+	txManager commonrepository.TransactionManager,
+	provider provider.Provider,
+	events EventPublisher,
+) *PaymentUsecase {
+	return &PaymentUsecase{
+		paymentRepo: paymentRepo,
+		orderRepo:   orderRepo,
+		txManager:   txManager,
+		provider:    provider,
+		events:      events,
+	}
+}
+
+// Pay создает платеж на неоплаченную сумму заказа клиента вместе с чаевыми
+// и авторизует его в шлюзе.
+// Если по заказу уже есть незавершенный платеж, возвращается он. Платеж создается
+// под блокировкой заказа, поэтому параллельные запросы получают один и тот же платеж,
+// а шлюз вызывается вне транзакции с ID платежа в качестве ключа идемпотентности:
+// повторная авторизация того же платежа не создает в шлюзе второй.
+func (u *PaymentUsecase) Pay(ctx context.Context, orderID, customerID uuid.UUID) (*entity.Payment, error) {
+	var order *orderentity.Order
+	var payment *entity.Payment
+	err := u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		order, err = u.orderRepo.LockByID(ctx, orderID)
+		if err != nil || order.CustomerID != customerID {
+			return ErrOrderNotFound
+		}
+		if !order.PaymentMethod.IsOnline() {
+			return fmt.Errorf("%w: способ оплаты %q", ErrNotPayable, order.PaymentMethod)
+		}
+		if order.Status.IsFinal() {
+			return fmt.Errorf("%w: заказ уже закрыт", ErrNotPayable)
+		}
+
+		payments, err := u.paymentRepo.GetByOrder(ctx, orderID)
+		if err != nil {
+			return err
+		}
+		paid := common.NewMoney(0)
+		for _, existing := range payments {
+			if existing.IsActive() {
+				payment = existing
+				return nil
+			}
+			paid = paid.Add(existing.Paid())
+		}
+		amount := order.AmountDue().Sub(paid)
+		tip := tipDue(order.Tip, payments)
+		if !amount.IsPositive() && !tip.IsPositive() {
+			return fmt.Errorf("%w: заказ уже оплачен", ErrNotPayable)
+		}
+		if amount.IsNegative() {
+			amount = common.NewMoney(0)
+		}
+
+		payment = &entity.Payment{
+			ID:       uuid.New(),
+			OrderID:  order.Id,
+			Provider: u.provider.Name(),
+			Method:   string(order.PaymentMethod),
+			Amount:   amount,
+			Tip:      tip,
+			Status:   entity.PaymentPending,
+		}
+		return u.paymentRepo.Create(ctx, payment)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if payment.ExternalID != "" {
+		return payment, nil
+	}
+
+	// платеж без ID шлюза еще не авторизован: при ошибке шлюза следующий запрос
+	// повторит авторизацию с тем же ключом
+	result, err := u.provider.Authorize(ctx, provider.AuthorizeRequest{
+		PaymentID:   payment.ID,
+		OrderID:     order.Id,
+		Amount:      payment.Charged(),
+		Description: fmt.Sprintf("Заказ %s", order.Id),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("платежный шлюз: %w", err)
+	}
+	return u.applyLocked(ctx, payment.ID, result)
+}
+
+// Capture списывает авторизованную сумму платежа. Шлюз вызывается вне транзакции,
+// чтобы не держать блокировку платежа на время сетевого запроса; если результат
+// не удалось сохранить, статус платежа догонит уведомление шлюза.
+func (u *PaymentUsecase) Capture(ctx context.Context, paymentID uuid.UUID) (*entity.Payment, error) {
+	payment, err := u.paymentRepo.GetByID(ctx, paymentID)
+	if err != nil {
+		return nil, ErrPaymentNotFound
+	}
+	if payment.Status != entity.PaymentAuthorized {
+		return nil, fmt.Errorf("%w: списать можно только авторизованный платеж", entity.ErrInvalidPaymentTransition)
+	}
+
+	result, err := u.provider.Capture(ctx, payment.ExternalID, payment.Charged())
+	if err != nil {
+		return nil, fmt.Errorf("платежный шлюз: %w", err)
+	}
+	if payment, err = u.applyLocked(ctx, paymentID, result); err != nil {
+		return nil, err
+	}
+	u.publishPaid(ctx, nil, payment)
+	return payment, nil
+}
+
+// HandleWebhook проверяет уведомление шлюза и переводит платеж в сообщенный статус.
+// Устаревшие и повторные уведомления игнорируются. Возвраты учитываются при их
+// оформлении, поэтому уведомления о них только подтверждают статус.
+func (u *PaymentUsecase) HandleWebhook(ctx context.Context, header http.Header, body []byte) error {
+	event, err := u.provider.VerifyWebhook(header, body)
+	if err != nil {
+		return err
+	}
+	if event.Status == entity.PaymentRefunded || event.Status == entity.PaymentPartiallyRefunded {
+		return nil
+	}
+
+	found, err := u.paymentRepo.GetByExternalID(ctx, u.provider.Name(), event.ExternalID)
+	if err != nil {
+		return ErrPaymentNotFound
+	}
+
+	var captured *entity.Payment
+	err = u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		payment, err := u.paymentRepo.LockByID(ctx, found.ID)
+		if err != nil {
+			return ErrPaymentNotFound
+		}
+		if err := payment.Transition(event.Status, time.Now()); err != nil {
+			if errors.Is(err, entity.ErrInvalidPaymentTransition) {
+				return nil
+			}
+			return err
+		}
+		if event.FailureReason != "" {
+			payment.FailureReason = event.FailureReason
+		}
+		if err := u.paymentRepo.Update(ctx, payment); err != nil {
+			return err
+		}
+		if payment.IsCaptured() {
+			captured = payment
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if captured != nil {
+		u.publishPaid(ctx, nil, captured)
+	}
+	return nil
+}
+
+// publishPaid сообщает подписчикам о списании платежа. Если заказ не передан,
+// он загружается по платежу.
+func (u *PaymentUsecase) publishPaid(ctx context.Context, order *orderentity.Order, payment *entity.Payment) {
+	if order == nil {
+		var err error
+		if order, err = u.orderRepo.GetByID(ctx, payment.OrderID); err != nil {
+			return
+		}
+	}
+	event := eventsentity.NewOrderEvent(eventsentity.EventOrderPaid, order)
+	amount := payment.Charged()
+	event.Amount = &amount
+	u.events.Publish(ctx, event)
+}
+
+// GetByOrder возвращает платежи заказа.
+func (u *PaymentUsecase) GetByOrder(ctx context.Context, orderID uuid.UUID) ([]*entity.Payment, error) {
+	if orderID == uuid.Nil {
+		return nil, errors.New("order_id не может быть пустым")
+	}
+	return u.paymentRepo.GetByOrder(ctx, orderID)
+}
+
+// CapturedAmount возвращает сумму, списанную по заказу, за вычетом возвратов.
+func (u *PaymentUsecase) CapturedAmount(ctx context.Context, orderID uuid.UUID) (common.Money, error) {
+	payments, err := u.paymentRepo.GetByOrder(ctx, orderID)
+	if err != nil {
+		return common.Money{}, err
+	}
+	paid := common.NewMoney(0)
+	for _, payment := range payments {
+		paid = paid.Add(payment.Paid())
+	}
+	return paid, nil
+}
+
+// ReleaseForOrder снимает в шлюзе незавершенные платежи отмененного заказа.
+// Списанные платежи остаются без изменений и возвращаются отдельно.
+// Вызывается после фиксации отмены: шлюз не вызывается внутри транзакции заказа.
+func (u *PaymentUsecase) ReleaseForOrder(ctx context.Context, orderID uuid.UUID) error {
+	payments, err := u.paymentRepo.GetByOrder(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	for _, payment := range payments {
+		if !payment.IsActive() {
+			continue
+		}
+
+		result := &provider.Result{Status: entity.PaymentCancelled}
+		if payment.ExternalID != "" {
+			if result, err = u.provider.Void(ctx, payment.ExternalID); err != nil {
+				return fmt.Errorf("платежный шлюз: %w", err)
+			}
+		}
+		if _, err := u.applyLocked(ctx, payment.ID, result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyLocked сохраняет ответ шлюза в платеже под блокировкой строки. ID платежа
+// в шлюзе и ссылка на оплату записываются из ответа на авторизацию.
+func (u *PaymentUsecase) applyLocked(ctx context.Context, paymentID uuid.UUID, result *provider.Result) (*entity.Payment, error) {
+	var payment *entity.Payment
+	err := u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		payment, err = u.paymentRepo.LockByID(ctx, paymentID)
+		if err != nil {
+			return ErrPaymentNotFound
+		}
+		if payment.ExternalID == "" {
+			payment.ExternalID = result.ExternalID
+			payment.ConfirmationURL = result.ConfirmationURL
+		}
+		if err := applyResult(payment, result); err != nil {
+			return err
+		}
+		return u.paymentRepo.Update(ctx, payment)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
+
+// TipsTotal возвращает сумму чаевых, полученных с платежами за период [from, to),
+// без чаевых, возвращенных клиентам.
+func (u *PaymentUsecase) TipsTotal(ctx context.Context, from, to time.Time) (common.Money, error) {
+	totals, err := u.paymentRepo.GetTotals(ctx, from, to)
+	if err != nil {
+		return common.Money{}, err
+	}
+	return totals.Tips, nil
+}
+
+// tipDue возвращает чаевые заказа, еще не полученные с платежами.
+func tipDue(tip common.Money, payments []*entity.Payment) common.Money {
+	due := tip
+	for _, payment := range payments {
+		if payment.IsActive() || payment.IsCaptured() {
+			due = due.Sub(payment.Tip)
+		}
+	}
+	if due.IsNegative() {
+		return common.NewMoney(0)
+	}
+	return due
+}
+
+// applyResult переводит платеж в статус, сообщенный шлюзом.
+func applyResult(payment *entity.Payment, result *provider.Result) error {
+	if err := payment.Transition(result.Status, time.Now()); err != nil {
+		return err
+	}
+	payment.FailureReason = result.FailureReason
+	return nil
+}
@@ -0,0 +1,251 @@
+package usecase_test
+
+import (
+	"coffe/internal/common"
+	"coffe/internal/common/repository/repositorytest"
+	eventsentity "coffe/internal/events/entity"
+	orderentity "coffe/internal/order/entity"
+	"coffe/internal/payment/entity"
+	"coffe/internal/payment/provider"
+	"coffe/internal/payment/usecase"
+	"coffe/internal/payment/usecase/mocks"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+)
+
+// eventLog запоминает опубликованные события заказов.
+type eventLog struct {
+	events []*eventsentity.Event
+}
+
+func (l *eventLog) Publish(_ context.Context, event *eventsentity.Event) {
+	l.events = append(l.events, event)
+}
+
+func onlineOrder(customerID uuid.UUID) *orderentity.Order {
+	return &orderentity.Order{
+		Id:             uuid.New(),
+		CustomerID:     customerID,
+		Status:         orderentity.OrderStatusPending,
+		PaymentMethod:  orderentity.PaymentMethodOnline,
+		TotalPrice:     common.NewMoney(50000),
+		PaidWithPoints: common.NewMoney(10000),
+	}
+}
+
+func TestPaymentUsecase_Pay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockPaymentRepo := mocks.NewMockPaymentRepository(ctrl)
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	payments := usecase.NewPaymentUsecase(mockPaymentRepo, mockOrderRepo, repositorytest.InlineTx{}, provider.NewFakeProvider("secret"), &eventLog{})
+
+	ctx := context.Background()
+	customerID := uuid.New()
+	order := onlineOrder(customerID)
+
+	mockOrderRepo.EXPECT().LockByID(ctx, order.Id).Return(order, nil)
+	mockPaymentRepo.EXPECT().GetByOrder(ctx, order.Id).Return(nil, nil)
+	expectCreated(ctx, mockPaymentRepo)
+
+	payment, err := payments.Pay(ctx, order.Id, customerID)
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	// оплачивается сумма за вычетом баллов
+	if payment.Status != entity.PaymentAuthorized || payment.Amount.Amount != 40000 || payment.ExternalID == "" {
+		t.Errorf("неверный платеж: %+v", payment)
+	}
+}
+
+// expectCreated ожидает создание платежа и сохранение ответа шлюза в нем же.
+func expectCreated(ctx context.Context, repo *mocks.MockPaymentRepository) {
+	var created *entity.Payment
+	repo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, payment *entity.Payment) error {
+		created = payment
+		return nil
+	})
+	repo.EXPECT().LockByID(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, id uuid.UUID) (*entity.Payment, error) {
+		if created == nil || created.ID != id {
+			return nil, errors.New("платеж не найден")
+		}
+		return created, nil
+	})
+	repo.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+}
+
+func TestPaymentUsecase_Pay_ReusesPending(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockPaymentRepo := mocks.NewMockPaymentRepository(ctrl)
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	fake := provider.NewFakeProvider("secret")
+	payments := usecase.NewPaymentUsecase(mockPaymentRepo, mockOrderRepo, repositorytest.InlineTx{}, fake, &eventLog{})
+
+	ctx := context.Background()
+	customerID := uuid.New()
+	order := onlineOrder(customerID)
+	// платеж создан, но шлюз не ответил: повтор авторизует его же, нового платежа нет
+	pending := &entity.Payment{ID: uuid.New(), OrderID: order.Id, Amount: common.NewMoney(40000), Status: entity.PaymentPending}
+
+	mockOrderRepo.EXPECT().LockByID(ctx, order.Id).Return(order, nil).Times(2)
+	mockPaymentRepo.EXPECT().GetByOrder(ctx, order.Id).Return([]*entity.Payment{pending}, nil).Times(2)
+	mockPaymentRepo.EXPECT().LockByID(ctx, pending.ID).Return(pending, nil)
+	mockPaymentRepo.EXPECT().Update(ctx, pending).Return(nil)
+
+	payment, err := payments.Pay(ctx, order.Id, customerID)
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if payment.ID != pending.ID || payment.Status != entity.PaymentAuthorized || payment.ExternalID == "" {
+		t.Fatalf("неверный платеж: %+v", payment)
+	}
+	externalID := payment.ExternalID
+
+	// авторизованный платеж возвращается без повторного обращения к шлюзу
+	again, err := payments.Pay(ctx, order.Id, customerID)
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if again.ID != pending.ID || again.ExternalID != externalID {
+		t.Errorf("ожидали тот же платеж, получили %+v", again)
+	}
+}
+
+func TestPaymentUsecase_Pay_WithTip(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockPaymentRepo := mocks.NewMockPaymentRepository(ctrl)
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	events := &eventLog{}
+	payments := usecase.NewPaymentUsecase(mockPaymentRepo, mockOrderRepo, repositorytest.InlineTx{}, provider.NewFakeProvider("secret"), events)
+
+	ctx := context.Background()
+	customerID := uuid.New()
+	order := onlineOrder(customerID)
+	order.Tip = common.NewMoney(5000)
+
+	mockOrderRepo.EXPECT().LockByID(ctx, order.Id).Return(order, nil)
+	mockOrderRepo.EXPECT().GetByID(ctx, order.Id).Return(order, nil)
+	mockPaymentRepo.EXPECT().GetByOrder(ctx, order.Id).Return(nil, nil)
+	expectCreated(ctx, mockPaymentRepo)
+
+	payment, err := payments.Pay(ctx, order.Id, customerID)
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	// чаевые авторизуются вместе с заказом, но учитываются отдельно от его суммы
+	if payment.Amount.Amount != 40000 || payment.Tip.Amount != 5000 {
+		t.Errorf("неверный платеж: %+v", payment)
+	}
+
+	mockPaymentRepo.EXPECT().GetByID(ctx, payment.ID).Return(payment, nil)
+	mockPaymentRepo.EXPECT().LockByID(ctx, payment.ID).Return(payment, nil)
+	mockPaymentRepo.EXPECT().Update(ctx, payment).Return(nil)
+	if _, err := payments.Capture(ctx, payment.ID); err != nil {
+		t.Fatalf("списание с чаевыми: %v", err)
+	}
+	if payment.Paid().Amount != 40000 {
+		t.Errorf("чаевые не должны входить в оплату заказа: %s", payment.Paid())
+	}
+	if len(events.events) != 1 || events.events[0].Type != eventsentity.EventOrderPaid || events.events[0].Amount.Amount != 45000 {
+		t.Errorf("ожидали событие оплаты на 450.00, получили %+v", events.events)
+	}
+}
+
+func TestPaymentUsecase_ReleaseForOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockPaymentRepo := mocks.NewMockPaymentRepository(ctrl)
+	fake := provider.NewFakeProvider("secret")
+	payments := usecase.NewPaymentUsecase(mockPaymentRepo, mocks.NewMockOrderRepository(ctrl), repositorytest.InlineTx{}, fake, &eventLog{})
+
+	ctx := context.Background()
+	orderID := uuid.New()
+	authorized := &entity.Payment{ID: uuid.New(), OrderID: orderID, Amount: common.NewMoney(40000), Status: entity.PaymentPending}
+	result, err := fake.Authorize(ctx, provider.AuthorizeRequest{PaymentID: authorized.ID, OrderID: orderID, Amount: authorized.Amount})
+	if err != nil {
+		t.Fatal(err)
+	}
+	authorized.ExternalID, authorized.Status = result.ExternalID, result.Status
+	captured := &entity.Payment{ID: uuid.New(), OrderID: orderID, Amount: common.NewMoney(1000), Status: entity.PaymentCaptured}
+
+	// списанный платеж не снимается, авторизация снимается и сохраняется под блокировкой
+	mockPaymentRepo.EXPECT().GetByOrder(ctx, orderID).Return([]*entity.Payment{captured, authorized}, nil)
+	mockPaymentRepo.EXPECT().LockByID(ctx, authorized.ID).Return(authorized, nil)
+	mockPaymentRepo.EXPECT().Update(ctx, authorized).Return(nil)
+
+	if err := payments.ReleaseForOrder(ctx, orderID); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if authorized.Status != entity.PaymentCancelled || captured.Status != entity.PaymentCaptured {
+		t.Errorf("неверные статусы: %s, %s", authorized.Status, captured.Status)
+	}
+}
+
+func TestPaymentUsecase_Pay_NotPayable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	payments := usecase.NewPaymentUsecase(mocks.NewMockPaymentRepository(ctrl), mockOrderRepo, repositorytest.InlineTx{}, provider.NewFakeProvider("secret"), &eventLog{})
+
+	customerID := uuid.New()
+	cash := onlineOrder(customerID)
+	cash.PaymentMethod = orderentity.PaymentMethodCash
+	mockOrderRepo.EXPECT().LockByID(gomock.Any(), cash.Id).Return(cash, nil).Times(2)
+
+	if _, err := payments.Pay(context.Background(), cash.Id, customerID); !errors.Is(err, usecase.ErrNotPayable) {
+		t.Errorf("ожидали ErrNotPayable, получили %v", err)
+	}
+	// чужой заказ не виден
+	if _, err := payments.Pay(context.Background(), cash.Id, uuid.New()); !errors.Is(err, usecase.ErrOrderNotFound) {
+		t.Errorf("ожидали ErrOrderNotFound, получили %v", err)
+	}
+}
+
+func TestPaymentUsecase_HandleWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockPaymentRepo := mocks.NewMockPaymentRepository(ctrl)
+	fake := provider.NewFakeProvider("secret")
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	events := &eventLog{}
+	payments := usecase.NewPaymentUsecase(mockPaymentRepo, mockOrderRepo, repositorytest.InlineTx{}, fake, events)
+
+	ctx := context.Background()
+	order := onlineOrder(uuid.New())
+	payment := &entity.Payment{ID: uuid.New(), OrderID: order.Id, Provider: provider.FakeName, ExternalID: "fake_1", Status: entity.PaymentAuthorized}
+
+	mockPaymentRepo.EXPECT().GetByExternalID(ctx, provider.FakeName, "fake_1").Return(payment, nil).Times(2)
+	mockPaymentRepo.EXPECT().LockByID(ctx, payment.ID).Return(payment, nil).Times(2)
+	mockPaymentRepo.EXPECT().Update(ctx, payment).Return(nil)
+	mockOrderRepo.EXPECT().GetByID(ctx, order.Id).Return(order, nil)
+
+	webhook := func(body string) error {
+		header := http.Header{}
+		header.Set(provider.SignatureHeader, fake.Sign([]byte(body)))
+		return payments.HandleWebhook(ctx, header, []byte(body))
+	}
+
+	if err := webhook(`{"payment_id":"fake_1","status":"оплачен"}`); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if payment.Status != entity.PaymentCaptured || payment.CapturedAt == nil {
+		t.Errorf("платеж не отмечен оплаченным: %+v", payment)
+	}
+
+	// устаревшее уведомление об авторизации не откатывает статус
+	if err := webhook(`{"payment_id":"fake_1","status":"авторизован"}`); err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if payment.Status != entity.PaymentCaptured {
+		t.Errorf("статус откатился: %q", payment.Status)
+	}
+	// о списании сообщается один раз
+	if len(events.events) != 1 || events.events[0].OrderID != order.Id {
+		t.Errorf("ожидали одно событие оплаты, получили %+v", events.events)
+	}
+
+	if err := payments.HandleWebhook(ctx, http.Header{}, []byte(`{}`)); !errors.Is(err, provider.ErrInvalidSignature) {
+		t.Errorf("ожидали ErrInvalidSignature, получили %v", err)
+	}
+}
@@ -0,0 +1,355 @@
+package usecase
+
+import (
+	"coffe/internal/common"
+	commonrepository "coffe/internal/common/repository"
+	inventoryentity "coffe/internal/inventory/entity"
+	orderentity "coffe/internal/order/entity"
+	orderrepository "coffe/internal/order/repository"
+	"coffe/internal/payment/entity"
+	"coffe/internal/payment/provider"
+	"coffe/internal/payment/repository"
+	userentity "coffe/internal/user/entity"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidRefund возвращается при некорректных параметрах возврата.
+var ErrInvalidRefund = errors.New("некорректный возврат")
+
+// ErrNotRefundable возвращается, если по заказу нельзя оформить возврат.
+var ErrNotRefundable = errors.New("по заказу нельзя оформить возврат")
+
+// ErrApprovalRequired возвращается, если сумма возвратов по заказу превышает порог,
+// а возврат оформляет не менеджер.
+var ErrApprovalRequired = errors.New("возврат на эту сумму может оформить только менеджер")
+
+// RefundRequest содержит данные возврата. Без позиций возвращается весь остаток платежа
+// вместе с позициями, которые он оплатил, и чаевыми.
+type RefundRequest struct {
+	OrderID     uuid.UUID
+	PaymentID   *uuid.UUID // пусто - платеж с наибольшим остатком
+	Lines       []RefundLineRequest
+	Restock     bool // вернуть ингредиенты возвращенных позиций на склад
+	Reason      string
+	RequestedBy uuid.UUID
+	Role        string // роль сотрудника, оформляющего возврат
+}
+
+// RefundLineRequest описывает возвращаемую позицию заказа.
+type RefundLineRequest struct {
+	ItemID   uuid.UUID
+	Quantity int
+}
+
+// StockReturner возвращает на склад ингредиенты возвращенных позиций.
+type StockReturner interface {
+	RestockForOrder(ctx context.Context, orderID uuid.UUID, lines []inventoryentity.OrderLine) error
+}
+
+// LoyaltyReverser отменяет баллы, начисленные за заказ, при возврате денег.
+type LoyaltyReverser interface {
+	ReverseForRefund(ctx context.Context, customerID, orderID uuid.UUID, refunded, paid common.Money) error
+}
+
+// StampReverser отменяет штампы, начисленные за заказ, при возврате денег.
+type StampReverser interface {
+	ReverseForRefund(ctx context.Context, customerID, orderID uuid.UUID, refunded, paid common.Money) error
+}
+
+// RefundUsecase оформляет возвраты денег по выполненным и отмененным заказам.
+type RefundUsecase struct {
+	paymentRepo       repository.PaymentRepository
+	orderRepo         orderrepository.OrderRepository
+	txManager         commonrepository.TransactionManager
+	provider          provider.Provider
+	stock             StockReturner
+	loyalty           LoyaltyReverser
+	stamps            StampReverser
+	approvalThreshold common.Money // сумма возвратов по заказу, выше которой возврат оформляет только менеджер
+}
+
+// NewRefundUsecase создает новый экземпляр RefundUsecase.
+func NewRefundUsecase(
+	paymentRepo repository.PaymentRepository,
+	orderRepo orderrepository.OrderRepository,
+	txManager commonrepository.TransactionManager,
+	provider provider.Provider,
+	stock StockReturner,
+	loyalty LoyaltyReverser,
+	stamps StampReverser,
+	approvalThreshold common.Money,
+) *RefundUsecase {
+	return &RefundUsecase{
+		paymentRepo:       paymentRepo,
+		orderRepo:         orderRepo,
+		txManager:         txManager,
+		provider:          provider,
+		stock:             stock,
+		loyalty:           loyalty,
+		stamps:            stamps,
+		approvalThreshold: approvalThreshold,
+	}
+}
+
+// Refund возвращает деньги по платежу заказа: весь остаток платежа или долю оплаты,
+// приходящуюся на выбранные позиции с учетом скидок и баллов. При полном возврате
+// клиенту возвращаются и чаевые платежа. Онлайн-платежи возвращаются через шлюз.
+// По выполненному заказу в той же транзакции отменяются баллы и штампы, начисленные
+// за возвращенную часть.
+func (u *RefundUsecase) Refund(ctx context.Context, req RefundRequest) (*entity.Refund, error) {
+	order, err := u.orderRepo.GetByID(ctx, req.OrderID)
+	if err != nil {
+		return nil, ErrOrderNotFound
+	}
+	if order.Status != orderentity.OrderStatusCompleted && order.Status != orderentity.OrderStatusCancelled {
+		return nil, fmt.Errorf("%w: заказ в статусе %q", ErrNotRefundable, order.Status)
+	}
+
+	var refund *entity.Refund
+	err = u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		payment, err := u.selectPayment(ctx, order.Id, req.PaymentID)
+		if err != nil {
+			return err
+		}
+		refundable := payment.Paid()
+
+		previous, err := u.paymentRepo.GetRefundsByOrder(ctx, order.Id)
+		if err != nil {
+			return err
+		}
+		lines, err := refundLines(order, payment, previous, req.Lines)
+		if err != nil {
+			return err
+		}
+		if req.Restock && len(lines) == 0 {
+			return fmt.Errorf("%w: платеж оплачивает долю счета, для возврата на склад укажите позиции", ErrInvalidRefund)
+		}
+
+		amount := refundable
+		tip := payment.TipPaid()
+		if len(req.Lines) > 0 {
+			amount = common.NewMoney(0)
+			for _, line := range lines {
+				amount = amount.Add(line.Amount)
+			}
+			amount = amount.Min(refundable)
+			// при возврате отдельных позиций чаевые остаются персоналу
+			tip = common.NewMoney(0)
+		}
+		if !amount.IsPositive() && !tip.IsPositive() {
+			return fmt.Errorf("%w: нечего возвращать", ErrInvalidRefund)
+		}
+
+		refund = &entity.Refund{
+			ID:        uuid.New(),
+			PaymentID: payment.ID,
+			OrderID:   order.Id,
+			Amount:    amount,
+			Tip:       tip,
+			Reason:    req.Reason,
+			Restock:   req.Restock,
+			Lines:     lines,
+			CreatedBy: req.RequestedBy,
+		}
+		// порог сравнивается с суммой всех возвратов заказа, чтобы крупный
+		// возврат нельзя было провести несколькими мелкими
+		total := amount
+		for _, earlier := range previous {
+			total = total.Add(earlier.Amount)
+		}
+		if u.approvalThreshold.IsPositive() && total.Cmp(u.approvalThreshold) > 0 {
+			if req.Role != userentity.RoleManager && req.Role != userentity.RoleAdmin {
+				return fmt.Errorf("%w: порог %s", ErrApprovalRequired, u.approvalThreshold)
+			}
+			refund.ApprovedBy = &req.RequestedBy
+		}
+
+		if payment.ExternalID != "" {
+			if _, err := u.provider.Refund(ctx, payment.ExternalID, amount.Add(tip)); err != nil {
+				return fmt.Errorf("платежный шлюз: %w", err)
+			}
+		}
+		payment.RefundedAmount = payment.RefundedAmount.Add(amount)
+		payment.RefundedTip = payment.RefundedTip.Add(tip)
+		status := entity.PaymentPartiallyRefunded
+		if payment.RefundedAmount.Cmp(payment.Amount) >= 0 && payment.RefundedTip.Cmp(payment.Tip) >= 0 {
+			status = entity.PaymentRefunded
+		}
+		if err := payment.Transition(status, time.Now()); err != nil {
+			return err
+		}
+		if err := u.paymentRepo.Update(ctx, payment); err != nil {
+			return err
+		}
+		if err := u.paymentRepo.CreateRefund(ctx, refund); err != nil {
+			return err
+		}
+		if order.Status == orderentity.OrderStatusCompleted {
+			if err := u.loyalty.ReverseForRefund(ctx, order.CustomerID, order.Id, total, order.AmountDue()); err != nil {
+				return err
+			}
+			if err := u.stamps.ReverseForRefund(ctx, order.CustomerID, order.Id, total, order.AmountDue()); err != nil {
+				return err
+			}
+		}
+
+		if !req.Restock {
+			return nil
+		}
+		return u.stock.RestockForOrder(ctx, order.Id, stockLines(order, lines))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return refund, nil
+}
+
+// GetByOrder возвращает возвраты заказа.
+func (u *RefundUsecase) GetByOrder(ctx context.Context, orderID uuid.UUID) ([]*entity.Refund, error) {
+	if orderID == uuid.Nil {
+		return nil, errors.New("order_id не может быть пустым")
+	}
+	return u.paymentRepo.GetRefundsByOrder(ctx, orderID)
+}
+
+// GetDailyTotals возвращает суммы списаний и возвратов за календарный день day.
+// Чаевые показываются отдельно и не входят в выручку.
+func (u *RefundUsecase) GetDailyTotals(ctx context.Context, day time.Time) (*entity.Totals, error) {
+	from := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	totals, err := u.paymentRepo.GetTotals(ctx, from, from.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	totals.Net = totals.Captured.Sub(totals.Refunded)
+	return totals, nil
+}
+
+// selectPayment блокирует платеж, по которому оформляется возврат: указанный или
+// списанный платеж заказа с наибольшим невозвращенным остатком.
+func (u *RefundUsecase) selectPayment(ctx context.Context, orderID uuid.UUID, paymentID *uuid.UUID) (*entity.Payment, error) {
+	if paymentID == nil {
+		payments, err := u.paymentRepo.GetByOrder(ctx, orderID)
+		if err != nil {
+			return nil, err
+		}
+		var best *entity.Payment
+		for _, payment := range payments {
+			if refundableTotal(payment).IsPositive() && (best == nil || refundableTotal(payment).Cmp(refundableTotal(best)) > 0) {
+				best = payment
+			}
+		}
+		if best == nil {
+			return nil, fmt.Errorf("%w: по заказу нет оплаченных платежей", ErrNotRefundable)
+		}
+		paymentID = &best.ID
+	}
+
+	payment, err := u.paymentRepo.LockByID(ctx, *paymentID)
+	if err != nil || payment.OrderID != orderID {
+		return nil, ErrPaymentNotFound
+	}
+	if !refundableTotal(payment).IsPositive() {
+		return nil, fmt.Errorf("%w: платеж уже возвращен или не оплачен", ErrNotRefundable)
+	}
+	return payment, nil
+}
+
+// refundableTotal возвращает невозвращенный остаток платежа вместе с чаевыми.
+func refundableTotal(payment *entity.Payment) common.Money {
+	return payment.Paid().Add(payment.TipPaid())
+}
+
+// refundLines проверяет возвращаемые позиции и считает приходящуюся на них долю оплаты.
+// Без позиций возвращаются позиции, оплаченные платежом: при оплате по позициям -
+// его позиции, при оплате всего заказа одним платежом - все позиции заказа.
+// Платеж, оплативший долю счета без позиций, возвращается без позиций.
+func refundLines(order *orderentity.Order, payment *entity.Payment, previous []*entity.Refund, requested []RefundLineRequest) ([]entity.RefundLine, error) {
+	refunded := make(map[uuid.UUID]int)
+	byPayment := make(map[uuid.UUID]int)
+	for _, refund := range previous {
+		for _, line := range refund.Lines {
+			refunded[line.ItemID] += line.Quantity
+			if refund.PaymentID == payment.ID {
+				byPayment[line.ItemID] += line.Quantity
+			}
+		}
+	}
+	if len(requested) == 0 {
+		requested = paidLines(order, payment, refunded, byPayment)
+	}
+
+	lines := make([]entity.RefundLine, 0, len(requested))
+	for _, line := range requested {
+		item, ok := order.Item(line.ItemID)
+		if !ok {
+			return nil, fmt.Errorf("%w: позиция %s не найдена в заказе", ErrInvalidRefund, line.ItemID)
+		}
+		if line.Quantity <= 0 {
+			return nil, fmt.Errorf("%w: количество должно быть больше нуля", ErrInvalidRefund)
+		}
+		if remaining := item.Quantity - refunded[item.ID]; line.Quantity > remaining {
+			return nil, fmt.Errorf("%w: по позиции можно вернуть не больше %d шт.", ErrInvalidRefund, remaining)
+		}
+		refunded[item.ID] += line.Quantity
+
+		lines = append(lines, entity.RefundLine{
+			ID:        uuid.New(),
+			ItemID:    item.ID,
+			ProductID: item.ProductID,
+			Quantity:  line.Quantity,
+			Amount:    order.ItemShare(item, line.Quantity),
+		})
+	}
+	return lines, nil
+}
+
+// paidLines возвращает позиции, оплаченные платежом и еще не возвращенные.
+// refunded содержит возвраты по всему заказу, byPayment - по этому платежу.
+func paidLines(order *orderentity.Order, payment *entity.Payment, refunded, byPayment map[uuid.UUID]int) []RefundLineRequest {
+	var lines []RefundLineRequest
+	if len(payment.Lines) > 0 {
+		for _, line := range payment.Lines {
+			item, ok := order.Item(line.ItemID)
+			if !ok {
+				continue
+			}
+			if remaining := min(line.Quantity-byPayment[line.ItemID], item.Quantity-refunded[line.ItemID]); remaining > 0 {
+				lines = append(lines, RefundLineRequest{ItemID: line.ItemID, Quantity: remaining})
+			}
+		}
+		return lines
+	}
+	if payment.Amount.Cmp(order.AmountDue()) < 0 {
+		return nil
+	}
+	for _, item := range order.Items {
+		if remaining := item.Quantity - refunded[item.ID]; remaining > 0 {
+			lines = append(lines, RefundLineRequest{ItemID: item.ID, Quantity: remaining})
+		}
+	}
+	return lines
+}
+
+// stockLines собирает возвращенные позиции для возврата ингредиентов на склад.
+func stockLines(order *orderentity.Order, lines []entity.RefundLine) []inventoryentity.OrderLine {
+	result := make([]inventoryentity.OrderLine, 0, len(lines))
+	for _, line := range lines {
+		item, _ := order.Item(line.ItemID)
+		modifierIDs := make([]uuid.UUID, 0, len(item.Modifiers))
+		for _, modifier := range item.Modifiers {
+			modifierIDs = append(modifierIDs, modifier.ModifierID)
+		}
+		result = append(result, inventoryentity.OrderLine{
+			ProductID:   item.ProductID,
+			VariantID:   item.VariantID,
+			ModifierIDs: modifierIDs,
+			Quantity:    line.Quantity,
+		})
+	}
+	return result
+}
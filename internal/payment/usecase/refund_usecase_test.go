@@ -0,0 +1,257 @@
+package usecase_test
+
+import (
+	"coffe/internal/common"
+	"coffe/internal/common/repository/repositorytest"
+	inventoryentity "coffe/internal/inventory/entity"
+	orderentity "coffe/internal/order/entity"
+	"coffe/internal/payment/entity"
+	"coffe/internal/payment/provider"
+	"coffe/internal/payment/usecase"
+	"coffe/internal/payment/usecase/mocks"
+	userentity "coffe/internal/user/entity"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+)
+
+// completedOrder - выполненный заказ: 2 латте по 250 ₽ и круассан за 200 ₽, скидка 70 ₽.
+func completedOrder() *orderentity.Order {
+	order := &orderentity.Order{
+		Id:            uuid.New(),
+		Status:        orderentity.OrderStatusCompleted,
+		PaymentMethod: orderentity.PaymentMethodCard,
+		Items: []orderentity.ItemsOrders{
+			{ID: uuid.New(), ProductID: uuid.New(), Quantity: 2, Price: common.NewMoney(25000)},
+			{ID: uuid.New(), ProductID: uuid.New(), Quantity: 1, Price: common.NewMoney(20000)},
+		},
+		Discounts: []orderentity.OrderDiscount{{Amount: common.NewMoney(7000)}},
+	}
+	order.TotalPrice = order.Subtotal().Sub(order.DiscountTotal())
+	return order
+}
+
+func TestRefundUsecase_Refund_Lines(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockPaymentRepo := mocks.NewMockPaymentRepository(ctrl)
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockStock := mocks.NewMockStockReturner(ctrl)
+	mockLoyalty := mocks.NewMockLoyaltyReverser(ctrl)
+	mockStamps := mocks.NewMockStampReverser(ctrl)
+	refunds := usecase.NewRefundUsecase(mockPaymentRepo, mockOrderRepo, repositorytest.InlineTx{}, provider.NewFakeProvider("secret"), mockStock, mockLoyalty, mockStamps, common.NewMoney(100000))
+
+	ctx := context.Background()
+	order := completedOrder()
+	latte := order.Items[0]
+	payment := &entity.Payment{ID: uuid.New(), OrderID: order.Id, Amount: common.NewMoney(63000), RefundedAmount: common.NewMoney(0), Status: entity.PaymentCaptured}
+
+	mockOrderRepo.EXPECT().GetByID(ctx, order.Id).Return(order, nil)
+	mockPaymentRepo.EXPECT().GetByOrder(ctx, order.Id).Return([]*entity.Payment{payment}, nil)
+	mockPaymentRepo.EXPECT().LockByID(ctx, payment.ID).Return(payment, nil)
+	// один латте уже возвращен
+	mockPaymentRepo.EXPECT().GetRefundsByOrder(ctx, order.Id).Return([]*entity.Refund{
+		{Lines: []entity.RefundLine{{ItemID: latte.ID, Quantity: 1}}},
+	}, nil)
+	mockPaymentRepo.EXPECT().Update(ctx, payment).Return(nil)
+	mockPaymentRepo.EXPECT().CreateRefund(ctx, gomock.Any()).Return(nil)
+	// баллы и штампы отменяются за возвращенную долю заказа: 225 ₽ из 630 ₽
+	mockLoyalty.EXPECT().ReverseForRefund(ctx, order.CustomerID, order.Id, common.NewMoney(22500), common.NewMoney(63000)).Return(nil)
+	mockStamps.EXPECT().ReverseForRefund(ctx, order.CustomerID, order.Id, common.NewMoney(22500), common.NewMoney(63000)).Return(nil)
+	mockStock.EXPECT().RestockForOrder(ctx, order.Id, []inventoryentity.OrderLine{
+		{ProductID: latte.ProductID, ModifierIDs: []uuid.UUID{}, Quantity: 1},
+	}).Return(nil)
+
+	refund, err := refunds.Refund(ctx, usecase.RefundRequest{
+		OrderID: order.Id,
+		Lines:   []usecase.RefundLineRequest{{ItemID: latte.ID, Quantity: 1}},
+		Restock: true,
+		Reason:  "пролили",
+	})
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	// скидка распределяется пропорционально: 250 × 630 / 700 = 225 ₽
+	if refund.Amount.Amount != 22500 || refund.ApprovedBy != nil {
+		t.Errorf("неверный возврат: %+v", refund)
+	}
+	if payment.Status != entity.PaymentPartiallyRefunded || payment.RefundedAmount.Amount != 22500 {
+		t.Errorf("неверный платеж после возврата: %+v", payment)
+	}
+
+	// второй латте уже нельзя вернуть дважды
+	mockOrderRepo.EXPECT().GetByID(ctx, order.Id).Return(order, nil)
+	mockPaymentRepo.EXPECT().GetByOrder(ctx, order.Id).Return([]*entity.Payment{payment}, nil)
+	mockPaymentRepo.EXPECT().LockByID(ctx, payment.ID).Return(payment, nil)
+	mockPaymentRepo.EXPECT().GetRefundsByOrder(ctx, order.Id).Return([]*entity.Refund{
+		{Lines: []entity.RefundLine{{ItemID: latte.ID, Quantity: 2}}},
+	}, nil)
+	_, err = refunds.Refund(ctx, usecase.RefundRequest{
+		OrderID: order.Id,
+		Lines:   []usecase.RefundLineRequest{{ItemID: latte.ID, Quantity: 1}},
+	})
+	if !errors.Is(err, usecase.ErrInvalidRefund) {
+		t.Errorf("ожидали ErrInvalidRefund, получили %v", err)
+	}
+}
+
+func TestRefundUsecase_Refund_SplitPayment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockPaymentRepo := mocks.NewMockPaymentRepository(ctrl)
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockStock := mocks.NewMockStockReturner(ctrl)
+	mockLoyalty := mocks.NewMockLoyaltyReverser(ctrl)
+	mockStamps := mocks.NewMockStampReverser(ctrl)
+	refunds := usecase.NewRefundUsecase(mockPaymentRepo, mockOrderRepo, repositorytest.InlineTx{}, provider.NewFakeProvider("secret"), mockStock, mockLoyalty, mockStamps, common.NewMoney(0))
+
+	ctx := context.Background()
+	order := completedOrder()
+	croissant := order.Items[1]
+	// счет разделен по позициям: первый гость оплатил круассан, второй - латте
+	first := &entity.Payment{ID: uuid.New(), OrderID: order.Id, Amount: common.NewMoney(18000), Status: entity.PaymentCaptured,
+		Lines: []entity.PaymentLine{{ItemID: croissant.ID, Quantity: 1, Amount: common.NewMoney(18000)}}}
+
+	mockOrderRepo.EXPECT().GetByID(ctx, order.Id).Return(order, nil)
+	mockPaymentRepo.EXPECT().LockByID(ctx, first.ID).Return(first, nil)
+	mockPaymentRepo.EXPECT().GetRefundsByOrder(ctx, order.Id).Return(nil, nil)
+	mockPaymentRepo.EXPECT().Update(ctx, first).Return(nil)
+	mockPaymentRepo.EXPECT().CreateRefund(ctx, gomock.Any()).Return(nil)
+	mockLoyalty.EXPECT().ReverseForRefund(ctx, order.CustomerID, order.Id, common.NewMoney(18000), common.NewMoney(63000)).Return(nil)
+	mockStamps.EXPECT().ReverseForRefund(ctx, order.CustomerID, order.Id, common.NewMoney(18000), common.NewMoney(63000)).Return(nil)
+	mockStock.EXPECT().RestockForOrder(ctx, order.Id, []inventoryentity.OrderLine{
+		{ProductID: croissant.ProductID, ModifierIDs: []uuid.UUID{}, Quantity: 1},
+	}).Return(nil)
+
+	// полный возврат платежа возвращает только оплаченные им позиции
+	refund, err := refunds.Refund(ctx, usecase.RefundRequest{OrderID: order.Id, PaymentID: &first.ID, Restock: true})
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if refund.Amount.Amount != 18000 || len(refund.Lines) != 1 || refund.Lines[0].ItemID != croissant.ID {
+		t.Errorf("неверный возврат: %+v", refund)
+	}
+}
+
+func TestRefundUsecase_Refund_Tip(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockPaymentRepo := mocks.NewMockPaymentRepository(ctrl)
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockLoyalty := mocks.NewMockLoyaltyReverser(ctrl)
+	mockStamps := mocks.NewMockStampReverser(ctrl)
+	refunds := usecase.NewRefundUsecase(mockPaymentRepo, mockOrderRepo, repositorytest.InlineTx{}, provider.NewFakeProvider("secret"), mocks.NewMockStockReturner(ctrl), mockLoyalty, mockStamps, common.Money{})
+
+	ctx := context.Background()
+	order := completedOrder()
+	payment := &entity.Payment{ID: uuid.New(), OrderID: order.Id, Amount: common.NewMoney(63000), Tip: common.NewMoney(5000), Status: entity.PaymentCaptured}
+
+	mockOrderRepo.EXPECT().GetByID(ctx, order.Id).Return(order, nil)
+	mockPaymentRepo.EXPECT().GetByOrder(ctx, order.Id).Return([]*entity.Payment{payment}, nil)
+	mockPaymentRepo.EXPECT().LockByID(ctx, payment.ID).Return(payment, nil)
+	mockPaymentRepo.EXPECT().GetRefundsByOrder(ctx, order.Id).Return(nil, nil)
+	mockPaymentRepo.EXPECT().Update(ctx, payment).Return(nil)
+	mockPaymentRepo.EXPECT().CreateRefund(ctx, gomock.Any()).Return(nil)
+	mockLoyalty.EXPECT().ReverseForRefund(ctx, order.CustomerID, order.Id, gomock.Any(), gomock.Any()).Return(nil)
+	mockStamps.EXPECT().ReverseForRefund(ctx, order.CustomerID, order.Id, gomock.Any(), gomock.Any()).Return(nil)
+
+	// полный возврат возвращает клиенту и чаевые
+	refund, err := refunds.Refund(ctx, usecase.RefundRequest{OrderID: order.Id})
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if refund.Amount.Amount != 63000 || refund.Tip.Amount != 5000 {
+		t.Errorf("неверный возврат: %+v", refund)
+	}
+	if payment.Status != entity.PaymentRefunded || !payment.TipPaid().IsZero() {
+		t.Errorf("неверный платеж после возврата: %+v", payment)
+	}
+}
+
+func TestRefundUsecase_Refund_ApprovalThreshold(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockPaymentRepo := mocks.NewMockPaymentRepository(ctrl)
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	mockLoyalty := mocks.NewMockLoyaltyReverser(ctrl)
+	mockStamps := mocks.NewMockStampReverser(ctrl)
+	refunds := usecase.NewRefundUsecase(mockPaymentRepo, mockOrderRepo, repositorytest.InlineTx{}, provider.NewFakeProvider("secret"), nil, mockLoyalty, mockStamps, common.NewMoney(50000))
+
+	ctx := context.Background()
+	order := completedOrder()
+	payment := &entity.Payment{ID: uuid.New(), OrderID: order.Id, Amount: common.NewMoney(63000), RefundedAmount: common.NewMoney(0), Status: entity.PaymentCaptured}
+	managerID := uuid.New()
+
+	mockOrderRepo.EXPECT().GetByID(ctx, order.Id).Return(order, nil).Times(2)
+	mockPaymentRepo.EXPECT().GetByOrder(ctx, order.Id).Return([]*entity.Payment{payment}, nil).Times(2)
+	mockPaymentRepo.EXPECT().LockByID(ctx, payment.ID).Return(payment, nil).Times(2)
+	mockPaymentRepo.EXPECT().GetRefundsByOrder(ctx, order.Id).Return(nil, nil).Times(2)
+
+	// полный возврат 630 ₽ выше порога 500 ₽
+	_, err := refunds.Refund(ctx, usecase.RefundRequest{OrderID: order.Id, Role: userentity.RoleClient})
+	if !errors.Is(err, usecase.ErrApprovalRequired) {
+		t.Fatalf("ожидали ErrApprovalRequired, получили %v", err)
+	}
+
+	mockPaymentRepo.EXPECT().Update(ctx, payment).Return(nil)
+	mockPaymentRepo.EXPECT().CreateRefund(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, refund *entity.Refund) error {
+		if len(refund.Lines) != 2 {
+			t.Errorf("полный возврат должен включать все позиции: %+v", refund.Lines)
+		}
+		return nil
+	})
+	mockLoyalty.EXPECT().ReverseForRefund(ctx, order.CustomerID, order.Id, gomock.Any(), gomock.Any()).Return(nil)
+	mockStamps.EXPECT().ReverseForRefund(ctx, order.CustomerID, order.Id, gomock.Any(), gomock.Any()).Return(nil)
+	refund, err := refunds.Refund(ctx, usecase.RefundRequest{OrderID: order.Id, RequestedBy: managerID, Role: userentity.RoleManager})
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if refund.Amount.Amount != 63000 || refund.ApprovedBy == nil || *refund.ApprovedBy != managerID {
+		t.Errorf("неверный возврат: %+v", refund)
+	}
+	if payment.Status != entity.PaymentRefunded {
+		t.Errorf("ожидали статус %q, получили %q", entity.PaymentRefunded, payment.Status)
+	}
+}
+
+func TestRefundUsecase_Refund_ApprovalThreshold_PerOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockPaymentRepo := mocks.NewMockPaymentRepository(ctrl)
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	refunds := usecase.NewRefundUsecase(mockPaymentRepo, mockOrderRepo, repositorytest.InlineTx{}, provider.NewFakeProvider("secret"), nil, nil, nil, common.NewMoney(50000))
+
+	ctx := context.Background()
+	order := completedOrder()
+	latte := order.Items[0]
+	payment := &entity.Payment{ID: uuid.New(), OrderID: order.Id, Amount: common.NewMoney(63000), RefundedAmount: common.NewMoney(30000), Status: entity.PaymentPartiallyRefunded}
+
+	mockOrderRepo.EXPECT().GetByID(ctx, order.Id).Return(order, nil)
+	mockPaymentRepo.EXPECT().LockByID(ctx, payment.ID).Return(payment, nil)
+	mockPaymentRepo.EXPECT().GetRefundsByOrder(ctx, order.Id).Return([]*entity.Refund{
+		{Amount: common.NewMoney(30000), Lines: []entity.RefundLine{{ItemID: order.Items[1].ID, Quantity: 1}}},
+	}, nil)
+
+	// латте за 225 ₽ ниже порога, но вместе с прошлым возвратом на 300 ₽ его превышает
+	_, err := refunds.Refund(ctx, usecase.RefundRequest{
+		OrderID:   order.Id,
+		PaymentID: &payment.ID,
+		Lines:     []usecase.RefundLineRequest{{ItemID: latte.ID, Quantity: 1}},
+		Role:      "barista",
+	})
+	if !errors.Is(err, usecase.ErrApprovalRequired) {
+		t.Errorf("ожидали ErrApprovalRequired, получили %v", err)
+	}
+}
+
+func TestRefundUsecase_Refund_OrderInProgress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	refunds := usecase.NewRefundUsecase(mocks.NewMockPaymentRepository(ctrl), mockOrderRepo, repositorytest.InlineTx{}, provider.NewFakeProvider("secret"), nil, nil, nil, common.NewMoney(0))
+
+	order := completedOrder()
+	order.Status = orderentity.OrderStatusPreparing
+	mockOrderRepo.EXPECT().GetByID(gomock.Any(), order.Id).Return(order, nil)
+
+	if _, err := refunds.Refund(context.Background(), usecase.RefundRequest{OrderID: order.Id}); !errors.Is(err, usecase.ErrNotRefundable) {
+		t.Errorf("ожидали ErrNotRefundable, получили %v", err)
+	}
+}
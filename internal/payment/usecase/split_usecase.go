@@ -0,0 +1,220 @@
+package usecase
+
+import (
+	"coffe/internal/common"
+	orderentity "coffe/internal/order/entity"
+	"coffe/internal/payment/entity"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidPayment возвращается при некорректных данных оплаты на кассе.
+var ErrInvalidPayment = errors.New("некорректная оплата")
+
+// PaymentItemRequest содержит позицию заказа, оплачиваемую отдельным платежом.
+type PaymentItemRequest struct {
+	ItemID   uuid.UUID
+	Quantity int
+}
+
+// RecordPaymentRequest содержит данные оплаты, принятой на кассе.
+type RecordPaymentRequest struct {
+	OrderID    uuid.UUID
+	Method     orderentity.PaymentMethod // наличные или карта
+	Amount     common.Money              // пусто при оплате позиций - их доля в сумме заказа
+	Tip        common.Money              // чаевые сверх суммы платежа
+	Tendered   common.Money              // получено наличными; пусто - без сдачи
+	Items      []PaymentItemRequest      // оплата по позициям
+	RecordedBy uuid.UUID
+}
+
+// RecordPayment принимает на кассе часть оплаты заказа наличными или картой.
+// Заказ можно разделить на несколько платежей поровну или по позициям; для наличных
+// рассчитывается сдача с полученной суммы. Чаевые принимаются сверх суммы платежа
+// и не уменьшают остаток к оплате.
+// Остаток считается под блокировкой заказа: параллельные платежи на кассе и онлайн
+// проверяются по очереди и не могут вместе превысить сумму заказа.
+func (u *PaymentUsecase) RecordPayment(ctx context.Context, req RecordPaymentRequest) (*entity.Payment, error) {
+	if req.Method != orderentity.PaymentMethodCash && req.Method != orderentity.PaymentMethodCard {
+		return nil, fmt.Errorf("%w: на кассе принимаются только наличные или карта", ErrInvalidPayment)
+	}
+	if req.Amount.IsNegative() || req.Tip.IsNegative() || req.Tendered.IsNegative() {
+		return nil, fmt.Errorf("%w: сумма не может быть отрицательной", ErrInvalidPayment)
+	}
+
+	var (
+		order   *orderentity.Order
+		payment *entity.Payment
+	)
+	err := u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		order, err = u.orderRepo.LockByID(ctx, req.OrderID)
+		if err != nil {
+			return ErrOrderNotFound
+		}
+		if order.Status.IsFinal() {
+			return fmt.Errorf("%w: заказ уже закрыт", ErrNotPayable)
+		}
+
+		payments, err := u.paymentRepo.GetByOrder(ctx, order.Id)
+		if err != nil {
+			return err
+		}
+		outstanding := outstandingAmount(order, payments)
+		if !outstanding.IsPositive() && !req.Tip.IsPositive() {
+			return fmt.Errorf("%w: заказ уже оплачен", ErrNotPayable)
+		}
+
+		payment = &entity.Payment{
+			ID:      uuid.New(),
+			OrderID: order.Id,
+			Method:  string(req.Method),
+			Amount:  req.Amount,
+			Tip:     req.Tip,
+			Status:  entity.PaymentPending,
+		}
+		if len(req.Items) > 0 {
+			lines, amount, err := paymentLines(order, payments, req.Items)
+			if err != nil {
+				return err
+			}
+			payment.Lines = lines
+			if req.Amount.IsZero() {
+				payment.Amount = amount.Min(outstanding)
+			}
+		}
+		if !payment.Amount.IsPositive() && !payment.Tip.IsPositive() {
+			return fmt.Errorf("%w: сумма должна быть больше нуля", ErrInvalidPayment)
+		}
+		if payment.Amount.Cmp(outstanding) > 0 {
+			return fmt.Errorf("%w: сумма больше остатка к оплате %s", ErrInvalidPayment, outstanding)
+		}
+
+		payment.Tendered = payment.Charged()
+		payment.Change = common.NewMoney(0)
+		if req.Method == orderentity.PaymentMethodCash && !req.Tendered.IsZero() {
+			if req.Tendered.Cmp(payment.Charged()) < 0 {
+				return fmt.Errorf("%w: получено меньше суммы платежа с чаевыми", ErrInvalidPayment)
+			}
+			payment.Tendered = req.Tendered
+			payment.Change = req.Tendered.Sub(payment.Charged())
+		}
+		if req.RecordedBy != uuid.Nil {
+			payment.CreatedBy = &req.RecordedBy
+		}
+		if err := payment.Transition(entity.PaymentCaptured, time.Now()); err != nil {
+			return err
+		}
+		return u.paymentRepo.Create(ctx, payment)
+	})
+	if err != nil {
+		return nil, err
+	}
+	u.publishPaid(ctx, order, payment)
+	return payment, nil
+}
+
+// SplitEvenly делит остаток к оплате заказа на parts равных частей.
+// Копейки, которые не делятся поровну, достаются первым частям.
+func (u *PaymentUsecase) SplitEvenly(ctx context.Context, orderID uuid.UUID, parts int) ([]common.Money, error) {
+	if parts < 1 {
+		return nil, fmt.Errorf("%w: количество частей должно быть больше нуля", ErrInvalidPayment)
+	}
+	summary, err := u.GetSummary(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	return summary.Outstanding.Allocate(parts), nil
+}
+
+// GetSummary возвращает состояние оплаты заказа: сумму к оплате, оплаченную часть,
+// остаток и платежи.
+func (u *PaymentUsecase) GetSummary(ctx context.Context, orderID uuid.UUID) (*entity.Summary, error) {
+	order, err := u.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, ErrOrderNotFound
+	}
+	payments, err := u.paymentRepo.GetByOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &entity.Summary{
+		OrderID:  order.Id,
+		Due:      order.AmountDue(),
+		Paid:     common.NewMoney(0),
+		Tip:      order.Tip,
+		TipPaid:  common.NewMoney(0),
+		Payments: payments,
+	}
+	for _, payment := range payments {
+		summary.Paid = summary.Paid.Add(payment.Paid())
+		summary.TipPaid = summary.TipPaid.Add(payment.TipPaid())
+	}
+	summary.Outstanding = summary.Due.Sub(summary.Paid)
+	if summary.Outstanding.IsNegative() {
+		summary.Outstanding = common.NewMoney(0)
+	}
+	summary.IsPaid = !summary.Outstanding.IsPositive()
+	return summary, nil
+}
+
+// outstandingAmount возвращает сумму заказа, еще не покрытую списанными
+// и ожидающими списания платежами.
+func outstandingAmount(order *orderentity.Order, payments []*entity.Payment) common.Money {
+	outstanding := order.AmountDue()
+	for _, payment := range payments {
+		if payment.IsActive() {
+			outstanding = outstanding.Sub(payment.Amount)
+			continue
+		}
+		outstanding = outstanding.Sub(payment.Paid())
+	}
+	if outstanding.IsNegative() {
+		return common.NewMoney(0)
+	}
+	return outstanding
+}
+
+// paymentLines проверяет оплачиваемые позиции и рассчитывает их долю в сумме заказа.
+// Позицию нельзя оплатить больше раз, чем она заказана, с учетом прежних платежей.
+func paymentLines(order *orderentity.Order, payments []*entity.Payment, items []PaymentItemRequest) ([]entity.PaymentLine, common.Money, error) {
+	covered := make(map[uuid.UUID]int)
+	for _, payment := range payments {
+		if !payment.IsActive() && !payment.IsCaptured() {
+			continue
+		}
+		for _, line := range payment.Lines {
+			covered[line.ItemID] += line.Quantity
+		}
+	}
+
+	amount := common.NewMoney(0)
+	lines := make([]entity.PaymentLine, 0, len(items))
+	for _, requested := range items {
+		item, ok := order.Item(requested.ItemID)
+		if !ok {
+			return nil, common.Money{}, fmt.Errorf("%w: позиция %s не найдена в заказе", ErrInvalidPayment, requested.ItemID)
+		}
+		if requested.Quantity <= 0 {
+			return nil, common.Money{}, fmt.Errorf("%w: количество должно быть больше нуля", ErrInvalidPayment)
+		}
+		if covered[item.ID]+requested.Quantity > item.Quantity {
+			return nil, common.Money{}, fmt.Errorf("%w: позиция %s уже оплачена", ErrInvalidPayment, item.ID)
+		}
+		covered[item.ID] += requested.Quantity
+
+		share := order.ItemShare(item, requested.Quantity)
+		lines = append(lines, entity.PaymentLine{
+			ItemID:   item.ID,
+			Quantity: requested.Quantity,
+			Amount:   share,
+		})
+		amount = amount.Add(share)
+	}
+	return lines, amount, nil
+}
@@ -0,0 +1,159 @@
+package usecase_test
+
+import (
+	"coffe/internal/common"
+	"coffe/internal/common/repository/repositorytest"
+	orderentity "coffe/internal/order/entity"
+	"coffe/internal/payment/entity"
+	"coffe/internal/payment/provider"
+	"coffe/internal/payment/usecase"
+	"coffe/internal/payment/usecase/mocks"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+)
+
+// tableOrder возвращает заказ на кассе: два латте по 250 ₽ и круассан за 200 ₽.
+func tableOrder() *orderentity.Order {
+	return &orderentity.Order{
+		Id:            uuid.New(),
+		CustomerID:    uuid.New(),
+		Status:        orderentity.OrderStatusReady,
+		PaymentMethod: orderentity.PaymentMethodCash,
+		TotalPrice:    common.NewMoney(70000),
+		Items: []orderentity.ItemsOrders{
+			{ID: uuid.New(), Quantity: 2, Price: common.NewMoney(25000)},
+			{ID: uuid.New(), Quantity: 1, Price: common.NewMoney(20000)},
+		},
+	}
+}
+
+func TestPaymentUsecase_RecordPayment_CashChange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockPaymentRepo := mocks.NewMockPaymentRepository(ctrl)
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	payments := usecase.NewPaymentUsecase(mockPaymentRepo, mockOrderRepo, repositorytest.InlineTx{}, provider.NewFakeProvider("secret"), &eventLog{})
+
+	ctx := context.Background()
+	order := tableOrder()
+	card := &entity.Payment{OrderID: order.Id, Amount: common.NewMoney(40000), RefundedAmount: common.NewMoney(0), Status: entity.PaymentCaptured}
+
+	mockOrderRepo.EXPECT().LockByID(ctx, order.Id).Return(order, nil)
+	mockPaymentRepo.EXPECT().GetByOrder(ctx, order.Id).Return([]*entity.Payment{card}, nil)
+	mockPaymentRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+	// остаток 300 ₽ и 50 ₽ чаевых оплачиваются наличными с 500 ₽
+	payment, err := payments.RecordPayment(ctx, usecase.RecordPaymentRequest{
+		OrderID:  order.Id,
+		Method:   orderentity.PaymentMethodCash,
+		Amount:   common.NewMoney(30000),
+		Tip:      common.NewMoney(5000),
+		Tendered: common.NewMoney(50000),
+	})
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if payment.Status != entity.PaymentCaptured || payment.Change.Amount != 15000 || payment.Provider != "" {
+		t.Errorf("неверный платеж: %+v", payment)
+	}
+}
+
+func TestPaymentUsecase_RecordPayment_Overpaid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockPaymentRepo := mocks.NewMockPaymentRepository(ctrl)
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	payments := usecase.NewPaymentUsecase(mockPaymentRepo, mockOrderRepo, repositorytest.InlineTx{}, provider.NewFakeProvider("secret"), &eventLog{})
+
+	ctx := context.Background()
+	order := tableOrder()
+	mockOrderRepo.EXPECT().LockByID(ctx, order.Id).Return(order, nil).Times(2)
+	mockPaymentRepo.EXPECT().GetByOrder(ctx, order.Id).Return(nil, nil).Times(2)
+
+	// больше остатка заплатить нельзя, сдача выдается только наличными
+	if _, err := payments.RecordPayment(ctx, usecase.RecordPaymentRequest{
+		OrderID: order.Id,
+		Method:  orderentity.PaymentMethodCard,
+		Amount:  common.NewMoney(80000),
+	}); !errors.Is(err, usecase.ErrInvalidPayment) {
+		t.Errorf("ожидали ErrInvalidPayment, получили %v", err)
+	}
+	if _, err := payments.RecordPayment(ctx, usecase.RecordPaymentRequest{
+		OrderID:  order.Id,
+		Method:   orderentity.PaymentMethodCash,
+		Amount:   common.NewMoney(30000),
+		Tendered: common.NewMoney(20000),
+	}); !errors.Is(err, usecase.ErrInvalidPayment) {
+		t.Errorf("ожидали ErrInvalidPayment, получили %v", err)
+	}
+}
+
+func TestPaymentUsecase_RecordPayment_ByItems(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockPaymentRepo := mocks.NewMockPaymentRepository(ctrl)
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	payments := usecase.NewPaymentUsecase(mockPaymentRepo, mockOrderRepo, repositorytest.InlineTx{}, provider.NewFakeProvider("secret"), &eventLog{})
+
+	ctx := context.Background()
+	order := tableOrder()
+	// скидка 70 ₽ распределяется между позициями пропорционально стоимости
+	order.TotalPrice = common.NewMoney(63000)
+	latte := order.Items[0]
+	first := &entity.Payment{
+		OrderID:        order.Id,
+		Amount:         common.NewMoney(22500),
+		RefundedAmount: common.NewMoney(0),
+		Status:         entity.PaymentCaptured,
+		Lines:          []entity.PaymentLine{{ItemID: latte.ID, Quantity: 1, Amount: common.NewMoney(22500)}},
+	}
+
+	mockOrderRepo.EXPECT().LockByID(ctx, order.Id).Return(order, nil).Times(2)
+	mockPaymentRepo.EXPECT().GetByOrder(ctx, order.Id).Return([]*entity.Payment{first}, nil)
+	mockPaymentRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+	payment, err := payments.RecordPayment(ctx, usecase.RecordPaymentRequest{
+		OrderID: order.Id,
+		Method:  orderentity.PaymentMethodCard,
+		Items:   []usecase.PaymentItemRequest{{ItemID: latte.ID, Quantity: 1}},
+	})
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if payment.Amount.Amount != 22500 || len(payment.Lines) != 1 || payment.Lines[0].ItemID != latte.ID {
+		t.Errorf("неверный платеж по позициям: %+v", payment)
+	}
+
+	// оба латте уже оплачены
+	mockPaymentRepo.EXPECT().GetByOrder(ctx, order.Id).Return([]*entity.Payment{first, payment}, nil)
+	if _, err := payments.RecordPayment(ctx, usecase.RecordPaymentRequest{
+		OrderID: order.Id,
+		Method:  orderentity.PaymentMethodCard,
+		Items:   []usecase.PaymentItemRequest{{ItemID: latte.ID, Quantity: 1}},
+	}); !errors.Is(err, usecase.ErrInvalidPayment) {
+		t.Errorf("ожидали ErrInvalidPayment, получили %v", err)
+	}
+}
+
+func TestPaymentUsecase_SplitEvenly(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockPaymentRepo := mocks.NewMockPaymentRepository(ctrl)
+	mockOrderRepo := mocks.NewMockOrderRepository(ctrl)
+	payments := usecase.NewPaymentUsecase(mockPaymentRepo, mockOrderRepo, repositorytest.InlineTx{}, provider.NewFakeProvider("secret"), &eventLog{})
+
+	ctx := context.Background()
+	order := tableOrder()
+	cash := &entity.Payment{OrderID: order.Id, Amount: common.NewMoney(10000), RefundedAmount: common.NewMoney(0), Status: entity.PaymentCaptured}
+	mockOrderRepo.EXPECT().GetByID(ctx, order.Id).Return(order, nil)
+	mockPaymentRepo.EXPECT().GetByOrder(ctx, order.Id).Return([]*entity.Payment{cash}, nil)
+
+	// остаток 600 ₽ делится на троих по 200 ₽
+	parts, err := payments.SplitEvenly(ctx, order.Id, 3)
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if len(parts) != 3 || parts[0].Amount != 20000 || parts[2].Amount != 20000 {
+		t.Errorf("неверное деление счета: %v", parts)
+	}
+}
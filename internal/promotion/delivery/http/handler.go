@@ -0,0 +1,182 @@
+package http
+
+import (
+	"coffe/internal/common"
+	"coffe/internal/promotion/entity"
+	"coffe/internal/promotion/usecase"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PromotionRequest содержит параметры акции.
+type PromotionRequest struct {
+	Name             string              `json:"name" binding:"required"`
+	Code             *string             `json:"code"` // пусто - автоматическая акция
+	Type             entity.DiscountType `json:"type" binding:"required"`
+	Percent          float64             `json:"percent"`
+	Amount           common.Money        `json:"amount"`
+	BuyQuantity      int                 `json:"buy_quantity"`
+	FreeQuantity     int                 `json:"free_quantity"`
+	MinTotal         common.Money        `json:"min_total"`
+	Conditions       []ConditionRequest  `json:"conditions" binding:"dive"`
+	ValidFrom        *time.Time          `json:"valid_from"`
+	ValidTo          *time.Time          `json:"valid_to"`
+	UsageLimit       int                 `json:"usage_limit"`
+	PerCustomerLimit int                 `json:"per_customer_limit"`
+	IsActive         *bool               `json:"is_active"` // по умолчанию true
+}
+
+// ConditionRequest ограничивает акцию продуктом или категорией.
+type ConditionRequest struct {
+	ProductID *uuid.UUID `json:"product_id"`
+	Category  string     `json:"category"`
+}
+
+type PromotionHandler struct {
+	promotionUsecase *usecase.PromotionUsecase
+}
+
+func NewPromotionHandler(promotionUsecase *usecase.PromotionUsecase) *PromotionHandler {
+	return &PromotionHandler{promotionUsecase: promotionUsecase}
+}
+
+// список акций
+func (h *PromotionHandler) GetPromotions(ctx *gin.Context) {
+	promotions, err := h.promotionUsecase.GetAll(ctx.Request.Context())
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения акций"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"promotions": promotions,
+		"total":      len(promotions),
+	})
+}
+
+// акция по id
+func (h *PromotionHandler) GetPromotion(ctx *gin.Context) {
+	id, ok := promotionIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	promotion, err := h.promotionUsecase.GetByID(ctx.Request.Context(), id)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Акция не найдена"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, promotion)
+}
+
+// создание акции или промокода
+func (h *PromotionHandler) CreatePromotion(ctx *gin.Context) {
+	var req PromotionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат данных", "details": err.Error()})
+		return
+	}
+
+	promotion := req.toPromotion()
+	if err := h.promotionUsecase.Create(ctx.Request.Context(), promotion); err != nil {
+		promotionError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, promotion)
+}
+
+// обновление акции
+func (h *PromotionHandler) UpdatePromotion(ctx *gin.Context) {
+	id, ok := promotionIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	var req PromotionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат данных", "details": err.Error()})
+		return
+	}
+
+	promotion := req.toPromotion()
+	promotion.ID = id
+	if err := h.promotionUsecase.Update(ctx.Request.Context(), promotion); err != nil {
+		promotionError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, promotion)
+}
+
+// выключение акции; история применений сохраняется
+func (h *PromotionHandler) DeactivatePromotion(ctx *gin.Context) {
+	id, ok := promotionIDParam(ctx)
+	if !ok {
+		return
+	}
+
+	if err := h.promotionUsecase.Deactivate(ctx.Request.Context(), id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Акция не найдена"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка выключения акции"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Акция выключена"})
+}
+
+func (r PromotionRequest) toPromotion() *entity.Promotion {
+	promotion := &entity.Promotion{
+		Name:             r.Name,
+		Type:             r.Type,
+		Percent:          r.Percent,
+		Amount:           r.Amount,
+		BuyQuantity:      r.BuyQuantity,
+		FreeQuantity:     r.FreeQuantity,
+		MinTotal:         r.MinTotal,
+		ValidFrom:        r.ValidFrom,
+		ValidTo:          r.ValidTo,
+		UsageLimit:       r.UsageLimit,
+		PerCustomerLimit: r.PerCustomerLimit,
+		IsActive:         true,
+	}
+	if r.Code != nil && *r.Code != "" {
+		promotion.Code = r.Code
+	}
+	if r.IsActive != nil {
+		promotion.IsActive = *r.IsActive
+	}
+	for _, condition := range r.Conditions {
+		promotion.Conditions = append(promotion.Conditions, &entity.Condition{
+			ProductID: condition.ProductID,
+			Category:  condition.Category,
+		})
+	}
+	return promotion
+}
+
+func promotionIDParam(ctx *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID акции"})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func promotionError(ctx *gin.Context, err error) {
+	if errors.Is(err, usecase.ErrInvalidPromotion) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
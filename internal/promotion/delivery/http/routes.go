@@ -0,0 +1,23 @@
+package http
+
+import (
+	"coffe/internal/middleware"
+	userentity "coffe/internal/user/entity"
+	"coffe/internal/user/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupPromotionRoutes настраивает маршруты управления акциями и промокодами
+func SetupPromotionRoutes(router *gin.RouterGroup, handler *PromotionHandler, jwtMiddleware *middleware.JWTMiddleware, permissionUC usecase.PermissionUsecase) {
+	promotions := router.Group("/admin/promotions")
+	promotions.Use(jwtMiddleware.Authenticate())
+	promotions.Use(jwtMiddleware.RequireRole(userentity.RoleAdmin, userentity.RoleManager))
+	{
+		promotions.GET("", middleware.PermissionMiddleware(permissionUC, "read_promotion"), handler.GetPromotions)
+		promotions.GET("/:id", middleware.PermissionMiddleware(permissionUC, "read_promotion"), handler.GetPromotion)
+		promotions.POST("", middleware.PermissionMiddleware(permissionUC, "update_promotion"), handler.CreatePromotion)
+		promotions.PUT("/:id", middleware.PermissionMiddleware(permissionUC, "update_promotion"), handler.UpdatePromotion)
+		promotions.DELETE("/:id", middleware.PermissionMiddleware(permissionUC, "update_promotion"), handler.DeactivatePromotion)
+	}
+}
@@ -0,0 +1,267 @@
+package entity
+
+import (
+	"coffe/internal/common"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrNotApplicable возвращается, если условия акции не выполнены для заказа.
+var ErrNotApplicable = errors.New("акция не применима к заказу")
+
+// DiscountType определяет способ расчета скидки.
+type DiscountType string
+
+const (
+	DiscountPercent  DiscountType = "процент" // процент от суммы подходящих позиций
+	DiscountFixed    DiscountType = "сумма"   // фиксированная сумма
+	DiscountBuyXGetY DiscountType = "подарок" // из каждых X+Y подходящих единиц Y самых дешевых бесплатно
+)
+
+// IsValid проверяет, что тип скидки входит в список известных значений.
+func (t DiscountType) IsValid() bool {
+	switch t {
+	case DiscountPercent, DiscountFixed, DiscountBuyXGetY:
+		return true
+	}
+	return false
+}
+
+// Promotion представляет правило скидки. Акция без кода применяется автоматически,
+// акция с кодом - только при вводе промокода.
+type Promotion struct {
+	ID               uuid.UUID    `json:"id" db:"id"`
+	Name             string       `json:"name" db:"name"`
+	Code             *string      `json:"code,omitempty" db:"code" gorm:"uniqueIndex"` // промокод в верхнем регистре, nil - автоматическая акция
+	Type             DiscountType `json:"type" db:"type"`
+	Percent          float64      `json:"percent,omitempty" db:"percent"`             // для типа "процент"
+	Amount           common.Money `json:"amount" db:"amount"`                         // для типа "сумма"
+	BuyQuantity      int          `json:"buy_quantity,omitempty" db:"buy_quantity"`   // X для типа "подарок"
+	FreeQuantity     int          `json:"free_quantity,omitempty" db:"free_quantity"` // Y для типа "подарок"
+	MinTotal         common.Money `json:"min_total" db:"min_total"`                   // минимальная сумма заказа, 0 - без ограничения
+	Conditions       []*Condition `json:"conditions,omitempty" db:"conditions" gorm:"foreignKey:PromotionID"`
+	ValidFrom        *time.Time   `json:"valid_from,omitempty" db:"valid_from"`
+	ValidTo          *time.Time   `json:"valid_to,omitempty" db:"valid_to"`
+	UsageLimit       int          `json:"usage_limit" db:"usage_limit"`               // всего применений, 0 - без ограничения
+	PerCustomerLimit int          `json:"per_customer_limit" db:"per_customer_limit"` // применений на клиента, 0 - без ограничения
+	IsActive         bool         `json:"is_active" db:"is_active"`
+	CreatedAt        time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time    `json:"updated_at" db:"updated_at"`
+}
+
+// Condition ограничивает акцию продуктом или категорией. Позиция подходит под акцию,
+// если она соответствует хотя бы одному условию; акция без условий действует на весь заказ.
+type Condition struct {
+	ID          uuid.UUID  `json:"id" db:"id"`
+	PromotionID uuid.UUID  `json:"promotion_id" db:"promotion_id" gorm:"index"`
+	ProductID   *uuid.UUID `json:"product_id,omitempty" db:"product_id"`
+	Category    string     `json:"category,omitempty" db:"category"`
+}
+
+// TableName задает имя таблицы условий акций.
+func (Condition) TableName() string {
+	return "promotion_conditions"
+}
+
+// Usage хранит факт применения акции в заказе для учета лимитов.
+type Usage struct {
+	ID          uuid.UUID    `json:"id" db:"id"`
+	PromotionID uuid.UUID    `json:"promotion_id" db:"promotion_id" gorm:"index"`
+	CustomerID  uuid.UUID    `json:"customer_id" db:"customer_id" gorm:"index"`
+	OrderID     uuid.UUID    `json:"order_id" db:"order_id" gorm:"index"`
+	Amount      common.Money `json:"amount" db:"amount"`
+	CreatedAt   time.Time    `json:"created_at" db:"created_at"`
+}
+
+// TableName задает имя таблицы применений акций.
+func (Usage) TableName() string {
+	return "promotion_usages"
+}
+
+// UsageCount содержит число применений акции всего и текущим клиентом.
+type UsageCount struct {
+	Total    int64
+	Customer int64
+}
+
+// Cart описывает заказ для расчета скидок.
+type Cart struct {
+	CustomerID uuid.UUID
+	Lines      []CartLine
+	At         time.Time
+}
+
+// CartLine описывает позицию заказа для расчета скидок.
+type CartLine struct {
+	ProductID uuid.UUID
+	Category  string
+	Quantity  int
+	UnitPrice common.Money // цена единицы с учетом варианта и модификаторов
+}
+
+// AppliedDiscount описывает скидку, примененную к заказу по акции.
+type AppliedDiscount struct {
+	PromotionID uuid.UUID
+	Code        string
+	Name        string
+	Amount      common.Money
+}
+
+// Total возвращает сумму заказа без скидок.
+func (c Cart) Total() common.Money {
+	total := common.NewMoney(0)
+	for _, line := range c.Lines {
+		total = total.Add(line.UnitPrice.Mul(int64(line.Quantity)))
+	}
+	return total
+}
+
+// NormalizeCode приводит промокод к виду, в котором он хранится.
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// CodeValue возвращает промокод акции или пустую строку для автоматической акции.
+func (p *Promotion) CodeValue() string {
+	if p.Code == nil {
+		return ""
+	}
+	return *p.Code
+}
+
+// Validate проверяет параметры акции.
+func (p *Promotion) Validate() error {
+	if p.Name == "" {
+		return errors.New("название акции не может быть пустым")
+	}
+	switch p.Type {
+	case DiscountPercent:
+		if p.Percent <= 0 || p.Percent > 100 {
+			return errors.New("процент скидки должен быть от 0 до 100")
+		}
+	case DiscountFixed:
+		if !p.Amount.IsPositive() {
+			return errors.New("сумма скидки должна быть больше нуля")
+		}
+	case DiscountBuyXGetY:
+		if p.BuyQuantity <= 0 || p.FreeQuantity <= 0 {
+			return errors.New("для акции \"подарок\" нужно указать количество покупаемых и бесплатных единиц")
+		}
+	default:
+		return fmt.Errorf("неизвестный тип скидки %q", p.Type)
+	}
+	if p.Code != nil && NormalizeCode(*p.Code) == "" {
+		return errors.New("промокод не может быть пустым")
+	}
+	if p.MinTotal.IsNegative() {
+		return errors.New("минимальная сумма заказа не может быть отрицательной")
+	}
+	if p.ValidFrom != nil && p.ValidTo != nil && !p.ValidTo.After(*p.ValidFrom) {
+		return errors.New("окончание действия акции должно быть позже начала")
+	}
+	if p.UsageLimit < 0 || p.PerCustomerLimit < 0 {
+		return errors.New("лимиты применений не могут быть отрицательными")
+	}
+	for _, condition := range p.Conditions {
+		if (condition.ProductID == nil) == (condition.Category == "") {
+			return errors.New("условие акции должно содержать либо продукт, либо категорию")
+		}
+	}
+	return nil
+}
+
+// ActiveAt проверяет, что акция включена и действует в момент t.
+func (p *Promotion) ActiveAt(t time.Time) bool {
+	if !p.IsActive {
+		return false
+	}
+	if p.ValidFrom != nil && t.Before(*p.ValidFrom) {
+		return false
+	}
+	if p.ValidTo != nil && !t.Before(*p.ValidTo) {
+		return false
+	}
+	return true
+}
+
+// Matches проверяет, подходит ли позиция под условия акции.
+func (p *Promotion) Matches(line CartLine) bool {
+	if len(p.Conditions) == 0 {
+		return true
+	}
+	for _, condition := range p.Conditions {
+		if condition.ProductID != nil && *condition.ProductID == line.ProductID {
+			return true
+		}
+		if condition.Category != "" && strings.EqualFold(condition.Category, line.Category) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckLimits проверяет лимиты применений акции.
+func (p *Promotion) CheckLimits(count UsageCount) error {
+	if p.UsageLimit > 0 && count.Total >= int64(p.UsageLimit) {
+		return fmt.Errorf("%w: лимит применений исчерпан", ErrNotApplicable)
+	}
+	if p.PerCustomerLimit > 0 && count.Customer >= int64(p.PerCustomerLimit) {
+		return fmt.Errorf("%w: вы уже использовали эту акцию", ErrNotApplicable)
+	}
+	return nil
+}
+
+// Discount рассчитывает скидку по акции для заказа без учета лимитов применений.
+// Скидка не превышает сумму подходящих позиций.
+func (p *Promotion) Discount(cart Cart) (common.Money, error) {
+	total := cart.Total()
+	if p.MinTotal.IsPositive() && total.Cmp(p.MinTotal) < 0 {
+		return common.Money{}, fmt.Errorf("%w: минимальная сумма заказа %s", ErrNotApplicable, p.MinTotal)
+	}
+
+	var eligible []CartLine
+	eligibleTotal := common.NewMoney(0)
+	for _, line := range cart.Lines {
+		if p.Matches(line) {
+			eligible = append(eligible, line)
+			eligibleTotal = eligibleTotal.Add(line.UnitPrice.Mul(int64(line.Quantity)))
+		}
+	}
+	if len(eligible) == 0 {
+		return common.Money{}, fmt.Errorf("%w: в заказе нет подходящих позиций", ErrNotApplicable)
+	}
+
+	switch p.Type {
+	case DiscountPercent:
+		return eligibleTotal.Percent(p.Percent), nil
+	case DiscountFixed:
+		return p.Amount.Min(eligibleTotal), nil
+	case DiscountBuyXGetY:
+		return p.freeItemsDiscount(eligible)
+	}
+	return common.Money{}, fmt.Errorf("неизвестный тип скидки %q", p.Type)
+}
+
+// freeItemsDiscount возвращает стоимость бесплатных единиц: из каждых BuyQuantity+FreeQuantity
+// подходящих единиц бесплатны FreeQuantity самых дешевых.
+func (p *Promotion) freeItemsDiscount(lines []CartLine) (common.Money, error) {
+	var prices []common.Money
+	for _, line := range lines {
+		for i := 0; i < line.Quantity; i++ {
+			prices = append(prices, line.UnitPrice)
+		}
+	}
+
+	free := len(prices) / (p.BuyQuantity + p.FreeQuantity) * p.FreeQuantity
+	if free == 0 {
+		return common.Money{}, fmt.Errorf("%w: нужно не меньше %d подходящих позиций", ErrNotApplicable, p.BuyQuantity+p.FreeQuantity)
+	}
+
+	sort.Slice(prices, func(i, j int) bool { return prices[i].Cmp(prices[j]) < 0 })
+	return common.SumMoney(prices[:free]...), nil
+}
@@ -0,0 +1,89 @@
+package entity_test
+
+import (
+	"coffe/internal/common"
+	"coffe/internal/promotion/entity"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func testCart() entity.Cart {
+	return entity.Cart{Lines: []entity.CartLine{
+		{ProductID: uuid.New(), Category: "coffee", Quantity: 2, UnitPrice: common.NewMoney(25000)},
+		{ProductID: uuid.New(), Category: "coffee", Quantity: 1, UnitPrice: common.NewMoney(20000)},
+		{ProductID: uuid.New(), Category: "dessert", Quantity: 1, UnitPrice: common.NewMoney(30000)},
+	}}
+}
+
+func TestPromotion_Discount(t *testing.T) {
+	cart := testCart()
+	coffee := []*entity.Condition{{Category: "coffee"}}
+
+	tests := []struct {
+		name      string
+		promotion entity.Promotion
+		want      int64
+	}{
+		{"процент от заказа", entity.Promotion{Type: entity.DiscountPercent, Percent: 10}, 10000},
+		{"процент от категории", entity.Promotion{Type: entity.DiscountPercent, Percent: 10, Conditions: coffee}, 7000},
+		{"сумма", entity.Promotion{Type: entity.DiscountFixed, Amount: common.NewMoney(15000)}, 15000},
+		{"сумма не больше подходящих позиций", entity.Promotion{Type: entity.DiscountFixed, Amount: common.NewMoney(50000),
+			Conditions: []*entity.Condition{{ProductID: &cart.Lines[1].ProductID}}}, 20000},
+		// три кофе: бесплатен самый дешевый
+		{"2+1", entity.Promotion{Type: entity.DiscountBuyXGetY, BuyQuantity: 2, FreeQuantity: 1, Conditions: coffee}, 20000},
+	}
+	for _, tt := range tests {
+		got, err := tt.promotion.Discount(cart)
+		if err != nil {
+			t.Errorf("%s: неожиданная ошибка: %v", tt.name, err)
+			continue
+		}
+		if got.Amount != tt.want {
+			t.Errorf("%s: ожидали %d, получили %d", tt.name, tt.want, got.Amount)
+		}
+	}
+}
+
+func TestPromotion_Discount_NotApplicable(t *testing.T) {
+	cart := testCart()
+
+	tests := map[string]entity.Promotion{
+		"минимальная сумма":   {Type: entity.DiscountPercent, Percent: 10, MinTotal: common.NewMoney(200000)},
+		"нет подходящих":      {Type: entity.DiscountPercent, Percent: 10, Conditions: []*entity.Condition{{Category: "tea"}}},
+		"мало единиц для 3+1": {Type: entity.DiscountBuyXGetY, BuyQuantity: 3, FreeQuantity: 1, Conditions: []*entity.Condition{{Category: "coffee"}}},
+	}
+	for name, promotion := range tests {
+		if _, err := promotion.Discount(cart); !errors.Is(err, entity.ErrNotApplicable) {
+			t.Errorf("%s: ожидали ErrNotApplicable, получили %v", name, err)
+		}
+	}
+}
+
+func TestPromotion_ActiveAtAndLimits(t *testing.T) {
+	now := time.Now()
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+
+	if (&entity.Promotion{IsActive: true, ValidFrom: &future}).ActiveAt(now) {
+		t.Error("акция еще не началась")
+	}
+	if (&entity.Promotion{IsActive: true, ValidTo: &past}).ActiveAt(now) {
+		t.Error("акция уже закончилась")
+	}
+	if !(&entity.Promotion{IsActive: true, ValidFrom: &past, ValidTo: &future}).ActiveAt(now) {
+		t.Error("акция должна действовать")
+	}
+
+	limited := &entity.Promotion{UsageLimit: 100, PerCustomerLimit: 1}
+	if err := limited.CheckLimits(entity.UsageCount{Total: 10, Customer: 1}); !errors.Is(err, entity.ErrNotApplicable) {
+		t.Errorf("клиент уже использовал промокод: ожидали ErrNotApplicable, получили %v", err)
+	}
+	if err := limited.CheckLimits(entity.UsageCount{Total: 100}); !errors.Is(err, entity.ErrNotApplicable) {
+		t.Errorf("общий лимит исчерпан: ожидали ErrNotApplicable, получили %v", err)
+	}
+	if err := limited.CheckLimits(entity.UsageCount{Total: 99}); err != nil {
+		t.Errorf("неожиданная ошибка: %v", err)
+	}
+}
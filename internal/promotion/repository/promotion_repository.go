@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"coffe/internal/promotion/entity"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PromotionRepository определяет методы для работы с акциями и промокодами.
+type PromotionRepository interface {
+	Create(ctx context.Context, promotion *entity.Promotion) error        // создать акцию вместе с условиями
+	Update(ctx context.Context, promotion *entity.Promotion) error        // обновить акцию и заменить условия
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Promotion, error) // акция по id
+	GetAll(ctx context.Context) ([]*entity.Promotion, error)              // все акции
+	Deactivate(ctx context.Context, id uuid.UUID) error                   // выключить акцию
+
+	// GetByCode возвращает акцию по нормализованному промокоду.
+	GetByCode(ctx context.Context, code string) (*entity.Promotion, error)
+
+	// GetAutomatic возвращает включенные акции без промокода, действующие в момент at.
+	GetAutomatic(ctx context.Context, at time.Time) ([]*entity.Promotion, error)
+
+	// LockUsage блокирует акции до конца транзакции и возвращает число их применений
+	// всего и клиентом customerID. Вызывается внутри транзакции оформления заказа.
+	LockUsage(ctx context.Context, promotionIDs []uuid.UUID, customerID uuid.UUID) (map[uuid.UUID]entity.UsageCount, error)
+
+	AddUsages(ctx context.Context, usages ...*entity.Usage) error     // записать применения акций
+	DeleteUsagesByOrder(ctx context.Context, orderID uuid.UUID) error // удалить применения акций в заказе
+}
@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"coffe/internal/promotion/entity"
+	"coffe/internal/promotion/repository"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidPromotion возвращается при некорректных параметрах акции.
+var ErrInvalidPromotion = errors.New("некорректные параметры акции")
+
+// ErrInvalidPromoCode возвращается, если промокод не найден или не может быть применен к заказу.
+var ErrInvalidPromoCode = errors.New("промокод недействителен")
+
+// PromotionUsecase управляет акциями и рассчитывает скидки заказов.
+type PromotionUsecase struct {
+	promotionRepo repository.PromotionRepository
+}
+
+// NewPromotionUsecase создает новый экземпляр PromotionUsecase.
+func NewPromotionUsecase(promotionRepo repository.PromotionRepository) *PromotionUsecase {
+	return &PromotionUsecase{promotionRepo: promotionRepo}
+}
+
+// Create добавляет акцию.
+func (u *PromotionUsecase) Create(ctx context.Context, promotion *entity.Promotion) error {
+	if err := u.preparePromotion(ctx, promotion); err != nil {
+		return err
+	}
+	return u.promotionRepo.Create(ctx, promotion)
+}
+
+// Update обновляет акцию. История применений сохраняется.
+func (u *PromotionUsecase) Update(ctx context.Context, promotion *entity.Promotion) error {
+	if promotion.ID == uuid.Nil {
+		return errors.New("ID акции не может быть пустым")
+	}
+	existing, err := u.promotionRepo.GetByID(ctx, promotion.ID)
+	if err != nil {
+		return errors.New("акция не найдена")
+	}
+	if err := u.preparePromotion(ctx, promotion); err != nil {
+		return err
+	}
+	promotion.CreatedAt = existing.CreatedAt
+	return u.promotionRepo.Update(ctx, promotion)
+}
+
+// GetByID возвращает акцию по идентификатору.
+func (u *PromotionUsecase) GetByID(ctx context.Context, id uuid.UUID) (*entity.Promotion, error) {
+	if id == uuid.Nil {
+		return nil, errors.New("ID акции не может быть пустым")
+	}
+	return u.promotionRepo.GetByID(ctx, id)
+}
+
+// GetAll возвращает все акции.
+func (u *PromotionUsecase) GetAll(ctx context.Context) ([]*entity.Promotion, error) {
+	return u.promotionRepo.GetAll(ctx)
+}
+
+// Deactivate выключает акцию.
+func (u *PromotionUsecase) Deactivate(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return errors.New("ID акции не может быть пустым")
+	}
+	return u.promotionRepo.Deactivate(ctx, id)
+}
+
+// Calculate подбирает автоматические акции и акцию по промокоду и рассчитывает скидки заказа.
+// Должен вызываться в транзакции оформления заказа: лимиты применений проверяются под блокировкой.
+// Автоматические акции, условия которых не выполнены, пропускаются; неприменимый промокод
+// возвращает ошибку, оборачивающую ErrInvalidPromoCode. Сумма скидок не превышает сумму заказа.
+func (u *PromotionUsecase) Calculate(ctx context.Context, cart entity.Cart, code string) ([]entity.AppliedDiscount, error) {
+	if cart.At.IsZero() {
+		cart.At = time.Now()
+	}
+
+	promotions, err := u.promotionRepo.GetAutomatic(ctx, cart.At)
+	if err != nil {
+		return nil, err
+	}
+
+	var coded *entity.Promotion
+	if code = entity.NormalizeCode(code); code != "" {
+		coded, err = u.promotionRepo.GetByCode(ctx, code)
+		if err != nil || !coded.ActiveAt(cart.At) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidPromoCode, code)
+		}
+		promotions = append(promotions, coded)
+	}
+
+	var limited []uuid.UUID
+	for _, promotion := range promotions {
+		if promotion.UsageLimit > 0 || promotion.PerCustomerLimit > 0 {
+			limited = append(limited, promotion.ID)
+		}
+	}
+	counts, err := u.promotionRepo.LockUsage(ctx, limited, cart.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := cart.Total()
+	var discounts []entity.AppliedDiscount
+	for _, promotion := range promotions {
+		amount, err := promotion.Discount(cart)
+		if err == nil {
+			err = promotion.CheckLimits(counts[promotion.ID])
+		}
+		if err != nil {
+			if promotion == coded {
+				return nil, fmt.Errorf("%w: %v", ErrInvalidPromoCode, err)
+			}
+			continue
+		}
+
+		amount = amount.Min(remaining)
+		if !amount.IsPositive() {
+			continue
+		}
+		remaining = remaining.Sub(amount)
+		discounts = append(discounts, entity.AppliedDiscount{
+			PromotionID: promotion.ID,
+			Code:        promotion.CodeValue(),
+			Name:        promotion.Name,
+			Amount:      amount,
+		})
+	}
+	return discounts, nil
+}
+
+// RecordUsage записывает применение акций в заказе. Вызывается в той же транзакции, что и Calculate.
+func (u *PromotionUsecase) RecordUsage(ctx context.Context, customerID, orderID uuid.UUID, discounts []entity.AppliedDiscount) error {
+	usages := make([]*entity.Usage, 0, len(discounts))
+	for _, discount := range discounts {
+		usages = append(usages, &entity.Usage{
+			PromotionID: discount.PromotionID,
+			CustomerID:  customerID,
+			OrderID:     orderID,
+			Amount:      discount.Amount,
+		})
+	}
+	return u.promotionRepo.AddUsages(ctx, usages...)
+}
+
+// ReleaseForOrder освобождает применения акций отмененного заказа, чтобы они
+// не учитывались в лимитах. Вызывается в транзакции отмены заказа.
+func (u *PromotionUsecase) ReleaseForOrder(ctx context.Context, orderID uuid.UUID) error {
+	return u.promotionRepo.DeleteUsagesByOrder(ctx, orderID)
+}
+
+// preparePromotion нормализует промокод, проверяет параметры акции и уникальность промокода.
+func (u *PromotionUsecase) preparePromotion(ctx context.Context, promotion *entity.Promotion) error {
+	if promotion.Code != nil {
+		code := entity.NormalizeCode(*promotion.Code)
+		promotion.Code = &code
+	}
+	if err := promotion.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPromotion, err)
+	}
+	if promotion.Code == nil {
+		return nil
+	}
+	if existing, err := u.promotionRepo.GetByCode(ctx, *promotion.Code); err == nil && existing.ID != promotion.ID {
+		return fmt.Errorf("%w: промокод %s уже существует", ErrInvalidPromotion, *promotion.Code)
+	}
+	return nil
+}
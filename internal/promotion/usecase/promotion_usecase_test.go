@@ -0,0 +1,84 @@
+package usecase_test
+
+import (
+	"coffe/internal/common"
+	"coffe/internal/promotion/entity"
+	"coffe/internal/promotion/usecase"
+	"coffe/internal/promotion/usecase/mocks"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestPromotionUsecase_Calculate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockPromotionRepository(ctrl)
+	promotions := usecase.NewPromotionUsecase(mockRepo)
+
+	ctx := context.Background()
+	customerID := uuid.New()
+	cart := entity.Cart{CustomerID: customerID, At: time.Now(), Lines: []entity.CartLine{
+		{ProductID: uuid.New(), Category: "coffee", Quantity: 2, UnitPrice: common.NewMoney(25000)},
+	}}
+
+	code := "WELCOME"
+	happyHour := &entity.Promotion{ID: uuid.New(), Name: "Счастливый час", Type: entity.DiscountPercent, Percent: 20, IsActive: true}
+	dessert := &entity.Promotion{ID: uuid.New(), Name: "Десерты", Type: entity.DiscountPercent, Percent: 50, IsActive: true,
+		Conditions: []*entity.Condition{{Category: "dessert"}}}
+	welcome := &entity.Promotion{ID: uuid.New(), Name: "Первый заказ", Code: &code, Type: entity.DiscountFixed,
+		Amount: common.NewMoney(50000), PerCustomerLimit: 1, IsActive: true}
+
+	mockRepo.EXPECT().GetAutomatic(ctx, cart.At).Return([]*entity.Promotion{happyHour, dessert}, nil)
+	mockRepo.EXPECT().GetByCode(ctx, "WELCOME").Return(welcome, nil)
+	mockRepo.EXPECT().LockUsage(ctx, []uuid.UUID{welcome.ID}, customerID).Return(map[uuid.UUID]entity.UsageCount{}, nil)
+
+	// промокод вводится в нижнем регистре и с пробелами
+	discounts, err := promotions.Calculate(ctx, cart, " welcome ")
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+
+	// десерта в заказе нет; 20% от 500 ₽ = 100 ₽, промокод на 500 ₽ ограничен остатком 400 ₽
+	if len(discounts) != 2 {
+		t.Fatalf("ожидали 2 скидки, получили %+v", discounts)
+	}
+	if discounts[0].PromotionID != happyHour.ID || discounts[0].Amount.Amount != 10000 {
+		t.Errorf("неверная автоматическая скидка: %+v", discounts[0])
+	}
+	if discounts[1].Code != "WELCOME" || discounts[1].Amount.Amount != 40000 {
+		t.Errorf("неверная скидка по промокоду: %+v", discounts[1])
+	}
+}
+
+func TestPromotionUsecase_Calculate_InvalidCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockPromotionRepository(ctrl)
+	promotions := usecase.NewPromotionUsecase(mockRepo)
+
+	ctx := context.Background()
+	cart := entity.Cart{CustomerID: uuid.New(), At: time.Now(), Lines: []entity.CartLine{
+		{ProductID: uuid.New(), Quantity: 1, UnitPrice: common.NewMoney(25000)},
+	}}
+	code := "ONCE"
+	once := &entity.Promotion{ID: uuid.New(), Name: "Один раз", Code: &code, Type: entity.DiscountPercent, Percent: 10,
+		PerCustomerLimit: 1, IsActive: true}
+
+	mockRepo.EXPECT().GetAutomatic(ctx, gomock.Any()).Return(nil, nil).Times(2)
+	mockRepo.EXPECT().GetByCode(ctx, "UNKNOWN").Return(nil, gorm.ErrRecordNotFound)
+	mockRepo.EXPECT().GetByCode(ctx, "ONCE").Return(once, nil)
+	mockRepo.EXPECT().LockUsage(ctx, gomock.Any(), gomock.Any()).Return(map[uuid.UUID]entity.UsageCount{
+		once.ID: {Total: 5, Customer: 1},
+	}, nil)
+
+	if _, err := promotions.Calculate(ctx, cart, "unknown"); !errors.Is(err, usecase.ErrInvalidPromoCode) {
+		t.Errorf("неизвестный промокод: ожидали ErrInvalidPromoCode, получили %v", err)
+	}
+	if _, err := promotions.Calculate(ctx, cart, "once"); !errors.Is(err, usecase.ErrInvalidPromoCode) {
+		t.Errorf("использованный промокод: ожидали ErrInvalidPromoCode, получили %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package http
+
+import (
+	"coffe/internal/common"
+	orderentity "coffe/internal/order/entity"
+	orderusecase "coffe/internal/order/usecase"
+	paymentusecase "coffe/internal/payment/usecase"
+	"coffe/internal/receipt"
+	userentity "coffe/internal/user/entity"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ReceiptHandler struct {
+	orderUsecase   *orderusecase.OrderUsecase
+	paymentUsecase *paymentusecase.PaymentUsecase
+	shop           receipt.Shop
+}
+
+func NewReceiptHandler(orderUsecase *orderusecase.OrderUsecase, paymentUsecase *paymentusecase.PaymentUsecase, shop receipt.Shop) *ReceiptHandler {
+	return &ReceiptHandler{
+		orderUsecase:   orderUsecase,
+		paymentUsecase: paymentUsecase,
+		shop:           shop,
+	}
+}
+
+// чек по заказу (?format=text58|text80|html|pdf, по умолчанию text80)
+func (h *ReceiptHandler) GetReceipt(ctx *gin.Context) {
+	userInterface, exists := ctx.Get("user")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Пользователь не авторизован"})
+		return
+	}
+	user, ok := userInterface.(*common.User)
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения пользователя"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный ID заказа"})
+		return
+	}
+
+	format, err := receipt.ParseFormat(ctx.Query("format"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	order, err := h.orderUsecase.GetByID(ctx.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, orderusecase.ErrOrderNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Заказ не найден"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения заказа"})
+		return
+	}
+	if order.CustomerID != user.ID && !isStaff(user) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Заказ не найден"})
+		return
+	}
+	if order.Status == orderentity.OrderStatusCancelled {
+		ctx.JSON(http.StatusConflict, gin.H{"error": "Заказ отменен, чек не формируется"})
+		return
+	}
+
+	payments, err := h.paymentUsecase.GetByOrder(ctx.Request.Context(), id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения платежей"})
+		return
+	}
+
+	body, err := receipt.Render(receipt.Build(order, payments, h.shop), format)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка формирования чека"})
+		return
+	}
+
+	if format == receipt.FormatPDF {
+		ctx.Header("Content-Disposition", fmt.Sprintf("inline; filename=\"receipt-%s.pdf\"", order.Id))
+	}
+	ctx.Data(http.StatusOK, format.ContentType(), body)
+}
+
+// isStaff сообщает, что пользователь может просматривать чеки любых заказов
+func isStaff(user *common.User) bool {
+	if user.Role == nil {
+		return false
+	}
+	return user.Role.Name == userentity.RoleAdmin || user.Role.Name == userentity.RoleManager
+}
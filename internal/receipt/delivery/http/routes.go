@@ -0,0 +1,17 @@
+package http
+
+import (
+	"coffe/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupReceiptRoutes настраивает маршруты чеков. Клиент получает чеки своих заказов,
+// персонал - любых.
+func SetupReceiptRoutes(router *gin.RouterGroup, handler *ReceiptHandler, jwtMiddleware *middleware.JWTMiddleware) {
+	orders := router.Group("/orders")
+	orders.Use(jwtMiddleware.Authenticate())
+	{
+		orders.GET("/:id/receipt", handler.GetReceipt)
+	}
+}
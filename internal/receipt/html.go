@@ -0,0 +1,70 @@
+package receipt
+
+import (
+	"bytes"
+	"html/template"
+)
+
+// htmlTemplate - чек для отправки по электронной почте. Стили встроены,
+// так как почтовые клиенты не загружают внешние таблицы стилей.
+var htmlTemplate = template.Must(template.New("receipt").Funcs(template.FuncMap{
+	"tax": taxName,
+}).Parse(`<!DOCTYPE html>
+<html lang="ru">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}} № {{.Number}}</title>
+</head>
+<body style="margin:0;padding:16px;background:#f4f4f4;font-family:Arial,sans-serif;color:#222">
+<table role="presentation" style="max-width:420px;margin:0 auto;background:#fff;border-collapse:collapse;width:100%">
+<tr><td style="padding:16px;text-align:center">
+{{with .Shop.Name}}<div style="font-size:18px;font-weight:bold">{{.}}</div>{{end}}
+{{with .Shop.Address}}<div style="font-size:12px">{{.}}</div>{{end}}
+{{with .Shop.INN}}<div style="font-size:12px">ИНН {{.}}</div>{{end}}
+{{with .Shop.Phone}}<div style="font-size:12px">Тел. {{.}}</div>{{end}}
+</td></tr>
+<tr><td style="padding:0 16px 8px">
+<div style="font-weight:bold">{{.Title}} № {{.Number}}</div>
+<div style="font-size:12px;color:#666">{{.IssuedAt.Format "02.01.2006 15:04"}}</div>
+</td></tr>
+<tr><td style="padding:0 16px">
+<table style="width:100%;border-collapse:collapse;font-size:14px">
+{{range .Lines}}<tr style="border-top:1px solid #eee">
+<td style="padding:6px 0">{{.Name}}{{range .Modifiers}}<div style="font-size:12px;color:#666">+ {{.}}</div>{{end}}</td>
+<td style="padding:6px 0;text-align:right;white-space:nowrap">{{.Quantity}} × {{.Price}}</td>
+<td style="padding:6px 0;text-align:right;white-space:nowrap">{{.Amount}}</td>
+</tr>{{end}}
+</table>
+</td></tr>
+<tr><td style="padding:8px 16px">
+<table style="width:100%;border-collapse:collapse;font-size:14px;border-top:1px solid #222">
+<tr><td style="padding:4px 0">Подытог</td><td style="text-align:right">{{.Subtotal}}</td></tr>
+{{range .Discounts}}<tr><td style="padding:4px 0">{{.Name}}</td><td style="text-align:right">-{{.Amount}}</td></tr>{{end}}
+{{if not .TaxInclusive}}{{range .Taxes}}<tr><td style="padding:4px 0">{{tax .Rate}}</td><td style="text-align:right">{{.Tax}}</td></tr>{{end}}{{end}}
+<tr style="font-weight:bold;font-size:16px"><td style="padding:4px 0">Итого</td><td style="text-align:right">{{.Total}}</td></tr>
+{{if .TaxInclusive}}{{range .Taxes}}<tr style="font-size:12px;color:#666"><td>в т.ч. {{tax .Rate}}</td><td style="text-align:right">{{.Tax}}</td></tr>{{end}}{{end}}
+{{with .Points}}<tr><td style="padding:4px 0">{{.Name}}</td><td style="text-align:right">-{{.Amount}}</td></tr>{{end}}
+</table>
+</td></tr>
+{{if or .Payments .Tip.IsPositive}}<tr><td style="padding:8px 16px">
+<table style="width:100%;border-collapse:collapse;font-size:14px;border-top:1px solid #eee">
+{{range .Payments}}<tr><td style="padding:4px 0">{{.Method}}</td><td style="text-align:right">{{.Amount}}</td></tr>
+{{if .Tendered.IsPositive}}<tr style="font-size:12px;color:#666"><td>Получено</td><td style="text-align:right">{{.Tendered}}</td></tr>
+<tr style="font-size:12px;color:#666"><td>Сдача</td><td style="text-align:right">{{.Change}}</td></tr>{{end}}{{end}}
+{{if .Tip.IsPositive}}<tr><td style="padding:4px 0">Чаевые</td><td style="text-align:right">{{.Tip}}</td></tr>{{end}}
+</table>
+</td></tr>{{end}}
+{{with .Shop.Footer}}<tr><td style="padding:16px;text-align:center;font-size:12px;color:#666">{{.}}</td></tr>{{end}}
+</table>
+</body>
+</html>
+`))
+
+// HTML формирует чек в виде HTML-страницы для отправки по электронной почте.
+func HTML(r *Receipt) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := htmlTemplate.Execute(&buf, r); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,191 @@
+package receipt
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gomono"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// Параметры страницы PDF-чека: лента 80 мм, моноширинный шрифт, в строку которого
+// помещается Width80 символов.
+const (
+	pdfPageWidth = 226.77 // 80 мм в пунктах
+	pdfMargin    = 5.0
+	pdfFontSize  = 7.5
+	pdfLeading   = 9.0
+)
+
+// pdfFont - встраиваемый в чек шрифт Go Mono. Стандартные шрифты PDF не содержат
+// кириллицы, поэтому шрифт встраивается в документ целиком.
+var pdfFont = loadPDFFont(gomono.TTF)
+
+// embeddedFont содержит шрифт TrueType и его метрики в единицах PDF (1/1000 кегля).
+type embeddedFont struct {
+	name      string
+	file      []byte // сжатый файл шрифта для потока FontFile2
+	length    int    // размер файла шрифта до сжатия
+	font      *sfnt.Font
+	width     int // ширина глифа моноширинного шрифта
+	ascent    int
+	descent   int
+	capHeight int
+	bbox      [4]int
+	fallback  sfnt.GlyphIndex // глиф для символов, которых нет в шрифте
+}
+
+// PDF формирует чек в виде одностраничного PDF-документа шириной с ленту 80 мм.
+// Документ повторяет текстовый чек, высота страницы подбирается по числу строк.
+func PDF(r *Receipt) []byte {
+	lines := textLines(r, Width80)
+	height := 2*pdfMargin + float64(len(lines))*pdfLeading
+
+	used := make(map[sfnt.GlyphIndex]rune)
+	var content bytes.Buffer
+	fmt.Fprintf(&content, "BT\n/F1 %g Tf\n%g TL\n%g %g Td\n", pdfFontSize, pdfLeading, pdfMargin, height-pdfMargin-pdfFontSize)
+	for _, line := range lines {
+		fmt.Fprintf(&content, "<%s> Tj T*\n", pdfFont.encode(line, used))
+	}
+	content.WriteString("ET\n")
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %g %g] /Resources << /Font << /F1 5 0 R >> >> /Contents 4 0 R >>", pdfPageWidth, height),
+		pdfStream("", content.Bytes()),
+		fmt.Sprintf("<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /Identity-H /DescendantFonts [6 0 R] /ToUnicode 9 0 R >>", pdfFont.name),
+		fmt.Sprintf("<< /Type /Font /Subtype /CIDFontType2 /BaseFont /%s /CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> "+
+			"/FontDescriptor 7 0 R /DW %d /CIDToGIDMap /Identity >>", pdfFont.name, pdfFont.width),
+		fmt.Sprintf("<< /Type /FontDescriptor /FontName /%s /Flags 33 /FontBBox [%d %d %d %d] /ItalicAngle 0 /Ascent %d /Descent %d /CapHeight %d /StemV 80 /FontFile2 8 0 R >>",
+			pdfFont.name, pdfFont.bbox[0], pdfFont.bbox[1], pdfFont.bbox[2], pdfFont.bbox[3], pdfFont.ascent, -pdfFont.descent, pdfFont.capHeight),
+		pdfStream(fmt.Sprintf("/Length1 %d /Filter /FlateDecode", pdfFont.length), pdfFont.file),
+		pdfStream("", toUnicode(used)),
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return buf.Bytes()
+}
+
+// loadPDFFont разбирает шрифт TrueType и считает метрики для словарей PDF.
+// Шрифт встроен в программу, поэтому ошибка разбора - ошибка сборки.
+func loadPDFFont(data []byte) *embeddedFont {
+	parsed, err := sfnt.Parse(data)
+	if err != nil {
+		panic(fmt.Sprintf("шрифт чека: %v", err))
+	}
+
+	var b sfnt.Buffer
+	upem := parsed.UnitsPerEm()
+	ppem := fixed.Int26_6(upem) << 6
+	scale := func(v fixed.Int26_6) int { return v.Round() * 1000 / int(upem) }
+
+	name, err := parsed.Name(&b, sfnt.NameIDPostScript)
+	if err != nil {
+		panic(fmt.Sprintf("шрифт чека: %v", err))
+	}
+	fallback, _ := parsed.GlyphIndex(&b, '?')
+	advance, err := parsed.GlyphAdvance(&b, fallback, ppem, font.HintingNone)
+	if err != nil {
+		panic(fmt.Sprintf("шрифт чека: %v", err))
+	}
+	metrics, err := parsed.Metrics(&b, ppem, font.HintingNone)
+	if err != nil {
+		panic(fmt.Sprintf("шрифт чека: %v", err))
+	}
+	bounds, err := parsed.Bounds(&b, ppem, font.HintingNone)
+	if err != nil {
+		panic(fmt.Sprintf("шрифт чека: %v", err))
+	}
+
+	// ось Y в sfnt направлена вниз, в PDF - вверх
+	return &embeddedFont{
+		name:      name,
+		file:      deflate(data),
+		length:    len(data),
+		font:      parsed,
+		width:     scale(advance),
+		ascent:    scale(metrics.Ascent),
+		descent:   scale(metrics.Descent),
+		capHeight: scale(metrics.CapHeight),
+		bbox:      [4]int{scale(bounds.Min.X), -scale(bounds.Max.Y), scale(bounds.Max.X), -scale(bounds.Min.Y)},
+		fallback:  fallback,
+	}
+}
+
+// encode переводит строку в шестнадцатеричные номера глифов для кодировки Identity-H
+// и запоминает использованные глифы для таблицы ToUnicode. Символы, которых нет
+// в шрифте, заменяются на "?".
+func (f *embeddedFont) encode(s string, used map[sfnt.GlyphIndex]rune) string {
+	var b sfnt.Buffer
+	var out strings.Builder
+	for _, r := range s {
+		glyph, err := f.font.GlyphIndex(&b, r)
+		if err != nil || glyph == 0 {
+			glyph, r = f.fallback, '?'
+		}
+		used[glyph] = r
+		fmt.Fprintf(&out, "%04X", uint16(glyph))
+	}
+	return out.String()
+}
+
+// toUnicode строит CMap, по которой программы просмотра копируют и ищут текст чека.
+func toUnicode(used map[sfnt.GlyphIndex]rune) []byte {
+	glyphs := make([]sfnt.GlyphIndex, 0, len(used))
+	for glyph := range used {
+		glyphs = append(glyphs, glyph)
+	}
+	sort.Slice(glyphs, func(i, j int) bool { return glyphs[i] < glyphs[j] })
+
+	var b bytes.Buffer
+	b.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n" +
+		"/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n" +
+		"/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n" +
+		"1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n")
+	// в одном блоке bfchar допускается не больше 100 записей
+	for start := 0; start < len(glyphs); start += 100 {
+		chunk := glyphs[start:min(start+100, len(glyphs))]
+		fmt.Fprintf(&b, "%d beginbfchar\n", len(chunk))
+		for _, glyph := range chunk {
+			fmt.Fprintf(&b, "<%04X> <%04X>\n", uint16(glyph), used[glyph])
+		}
+		b.WriteString("endbfchar\n")
+	}
+	b.WriteString("endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend\n")
+	return b.Bytes()
+}
+
+// pdfStream оформляет объект-поток с дополнительными ключами словаря.
+func pdfStream(keys string, data []byte) string {
+	if keys != "" {
+		keys = " " + keys
+	}
+	return fmt.Sprintf("<< /Length %d%s >>\nstream\n%s\nendstream", len(data), keys, data)
+}
+
+// deflate сжимает данные для потока с фильтром FlateDecode.
+func deflate(data []byte) []byte {
+	var buf bytes.Buffer
+	w := zlib.NewWriter(&buf)
+	w.Write(data)
+	w.Close()
+	return buf.Bytes()
+}
@@ -0,0 +1,226 @@
+// Package receipt формирует чеки по заказам: текст для чековых принтеров 58 и 80 мм,
+// HTML для отправки по почте и PDF.
+package receipt
+
+import (
+	"coffe/internal/common"
+	orderentity "coffe/internal/order/entity"
+	paymententity "coffe/internal/payment/entity"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrUnknownFormat возвращается при запросе чека в неизвестном формате.
+var ErrUnknownFormat = errors.New("неизвестный формат чека")
+
+// Format определяет формат чека.
+type Format string
+
+const (
+	FormatText58 Format = "text58" // текст для принтера с лентой 58 мм
+	FormatText80 Format = "text80" // текст для принтера с лентой 80 мм
+	FormatHTML   Format = "html"
+	FormatPDF    Format = "pdf"
+)
+
+// ParseFormat разбирает формат чека; пустая строка - текст для ленты 80 мм.
+func ParseFormat(s string) (Format, error) {
+	switch format := Format(strings.ToLower(s)); format {
+	case "":
+		return FormatText80, nil
+	case FormatText58, FormatText80, FormatHTML, FormatPDF:
+		return format, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnknownFormat, s)
+}
+
+// ContentType возвращает MIME-тип чека в формате f.
+func (f Format) ContentType() string {
+	switch f {
+	case FormatHTML:
+		return "text/html; charset=utf-8"
+	case FormatPDF:
+		return "application/pdf"
+	}
+	return "text/plain; charset=utf-8"
+}
+
+// Shop содержит реквизиты кофейни, печатаемые в чеке.
+type Shop struct {
+	Name    string
+	Address string
+	INN     string
+	Phone   string
+	Footer  string // строка в конце чека
+}
+
+// Line представляет позицию чека.
+type Line struct {
+	Name      string
+	Modifiers []string
+	Quantity  int
+	Price     common.Money // цена единицы
+	Amount    common.Money // сумма позиции до скидок
+	TaxRate   float64
+}
+
+// Adjustment представляет скидку или оплату баллами.
+type Adjustment struct {
+	Name   string
+	Amount common.Money
+}
+
+// TaxLine содержит налог по одной ставке.
+type TaxLine struct {
+	Rate float64
+	Net  common.Money
+	Tax  common.Money
+}
+
+// Payment представляет оплату в чеке.
+type Payment struct {
+	Method   string
+	Amount   common.Money
+	Tendered common.Money // получено наличными
+	Change   common.Money
+}
+
+// Receipt содержит данные чека, не зависящие от формата.
+type Receipt struct {
+	Shop         Shop
+	OrderID      uuid.UUID
+	Number       string // номер заказа за день, для старых заказов - начало ID
+	Title        string
+	IssuedAt     time.Time
+	Lines        []Line
+	Subtotal     common.Money
+	Discounts    []Adjustment
+	Total        common.Money // итог с налогом
+	Net          common.Money // итог без налога
+	Taxes        []TaxLine
+	TaxInclusive bool
+	Points       *Adjustment // оплата баллами
+	Payments     []Payment
+	Tip          common.Money
+}
+
+// Build собирает чек по заказу и его платежам. Учитываются только списанные платежи.
+func Build(order *orderentity.Order, payments []*paymententity.Payment, shop Shop) *Receipt {
+	r := &Receipt{
+		Shop:         shop,
+		OrderID:      order.Id,
+		Number:       strings.ToUpper(order.Id.String()[:8]),
+		Title:        "Счет",
+		IssuedAt:     order.UpdatedAt,
+		Subtotal:     order.Subtotal(),
+		Total:        order.TotalPrice,
+		Net:          order.NetTotal,
+		TaxInclusive: order.TaxInclusive,
+		Tip:          common.NewMoney(0),
+	}
+	if order.Number > 0 {
+		r.Number = strconv.Itoa(order.Number)
+	}
+	if order.Status == orderentity.OrderStatusCompleted {
+		r.Title = "Кассовый чек"
+	}
+
+	taxes := make(map[float64]*TaxLine)
+	for _, item := range order.Items {
+		line := Line{
+			Name:     item.DisplayName(),
+			Quantity: item.Quantity,
+			Price:    item.Price,
+			Amount:   item.Price.Mul(int64(item.Quantity)),
+			TaxRate:  item.TaxRate,
+		}
+		for _, modifier := range item.Modifiers {
+			line.Modifiers = append(line.Modifiers, modifier.Name)
+		}
+		r.Lines = append(r.Lines, line)
+
+		tax, ok := taxes[item.TaxRate]
+		if !ok {
+			tax = &TaxLine{Rate: item.TaxRate, Net: common.NewMoney(0), Tax: common.NewMoney(0)}
+			taxes[item.TaxRate] = tax
+		}
+		tax.Net = tax.Net.Add(item.NetAmount)
+		tax.Tax = tax.Tax.Add(item.TaxAmount)
+	}
+	for _, tax := range taxes {
+		r.Taxes = append(r.Taxes, *tax)
+	}
+	sort.Slice(r.Taxes, func(i, j int) bool { return r.Taxes[i].Rate > r.Taxes[j].Rate })
+
+	for _, discount := range order.Discounts {
+		r.Discounts = append(r.Discounts, Adjustment{Name: discount.Name, Amount: discount.Amount})
+	}
+	if order.PaidWithPoints.IsPositive() {
+		r.Points = &Adjustment{
+			Name:   fmt.Sprintf("Оплачено баллами (%d)", order.PointsRedeemed),
+			Amount: order.PaidWithPoints,
+		}
+	}
+
+	for _, payment := range payments {
+		if !payment.IsCaptured() {
+			continue
+		}
+		r.Payments = append(r.Payments, Payment{
+			Method:   methodName(payment.Method),
+			Amount:   payment.Charged(),
+			Tendered: payment.Tendered,
+			Change:   payment.Change,
+		})
+		r.Tip = r.Tip.Add(payment.Tip)
+		if payment.CapturedAt != nil && payment.CapturedAt.After(r.IssuedAt) {
+			r.IssuedAt = *payment.CapturedAt
+		}
+	}
+	if len(r.Payments) == 0 {
+		r.Tip = order.Tip
+	}
+	return r
+}
+
+// Render формирует чек в формате format.
+func Render(r *Receipt, format Format) ([]byte, error) {
+	switch format {
+	case FormatText58:
+		return []byte(Text(r, Width58)), nil
+	case FormatText80:
+		return []byte(Text(r, Width80)), nil
+	case FormatHTML:
+		return HTML(r)
+	case FormatPDF:
+		return PDF(r), nil
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+}
+
+// methodName возвращает название способа оплаты для чека.
+func methodName(method string) string {
+	switch orderentity.PaymentMethod(method) {
+	case orderentity.PaymentMethodCash:
+		return "Наличные"
+	case orderentity.PaymentMethodCard:
+		return "Карта"
+	case orderentity.PaymentMethodOnline:
+		return "Онлайн"
+	}
+	return method
+}
+
+// taxName возвращает название ставки налога.
+func taxName(rate float64) string {
+	if rate == 0 {
+		return "Без НДС"
+	}
+	return fmt.Sprintf("НДС %g%%", rate)
+}
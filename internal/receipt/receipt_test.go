@@ -0,0 +1,121 @@
+package receipt_test
+
+import (
+	"bytes"
+	"coffe/internal/common"
+	orderentity "coffe/internal/order/entity"
+	paymententity "coffe/internal/payment/entity"
+	"coffe/internal/receipt"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+func testReceipt() *receipt.Receipt {
+	order := &orderentity.Order{
+		Id:     uuid.New(),
+		Status: orderentity.OrderStatusCompleted,
+		Items: []orderentity.ItemsOrders{
+			{
+				Name:        "Капучино на овсяном молоке с сиропом",
+				VariantName: "Большой",
+				Modifiers:   []orderentity.ItemModifier{{Name: "Сироп <карамель>"}},
+				Quantity:    2,
+				Price:       common.NewMoney(25000),
+				TaxRate:     20,
+				NetAmount:   common.NewMoney(37500),
+				TaxAmount:   common.NewMoney(7500),
+			},
+			{
+				Name:      "Круассан",
+				Quantity:  1,
+				Price:     common.NewMoney(15000),
+				TaxRate:   10,
+				NetAmount: common.NewMoney(13637),
+				TaxAmount: common.NewMoney(1363),
+			},
+		},
+		Discounts:    []orderentity.OrderDiscount{{Name: "Счастливые часы", Amount: common.NewMoney(15000)}},
+		TotalPrice:   common.NewMoney(60000),
+		NetTotal:     common.NewMoney(51137),
+		TaxTotal:     common.NewMoney(8863),
+		TaxInclusive: true,
+		UpdatedAt:    time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC),
+	}
+	payments := []*paymententity.Payment{
+		{
+			Method:   string(orderentity.PaymentMethodCash),
+			Amount:   common.NewMoney(60000),
+			Tip:      common.NewMoney(5000),
+			Tendered: common.NewMoney(70000),
+			Change:   common.NewMoney(5000),
+			Status:   paymententity.PaymentCaptured,
+		},
+		{Method: string(orderentity.PaymentMethodOnline), Amount: common.NewMoney(60000), Status: paymententity.PaymentFailed},
+	}
+	shop := receipt.Shop{Name: "Кофейня", Address: "г. Москва, ул. Тверская, д. 1", INN: "7701234567", Footer: "Спасибо!"}
+	return receipt.Build(order, payments, shop)
+}
+
+func TestText_FitsPaperWidth(t *testing.T) {
+	r := testReceipt()
+	for _, width := range []int{receipt.Width58, receipt.Width80} {
+		text := receipt.Text(r, width)
+		for _, line := range strings.Split(strings.TrimSuffix(text, "\n"), "\n") {
+			if n := utf8.RuneCountInString(line); n > width {
+				t.Errorf("ширина %d: строка %q длиной %d", width, line, n)
+			}
+		}
+		for _, want := range []string{"Кассовый чек", "ИТОГО", "600.00", "в т.ч. НДС 20%", "75.00", "Счастливые часы", "-150.00", "Сдача", "Чаевые", "50.00"} {
+			if !strings.Contains(text, want) {
+				t.Errorf("ширина %d: в чеке нет %q:\n%s", width, want, text)
+			}
+		}
+	}
+}
+
+func TestBuild_SkipsUncapturedPayments(t *testing.T) {
+	r := testReceipt()
+	if len(r.Payments) != 1 {
+		t.Fatalf("ожидали только списанный платеж, получили %d", len(r.Payments))
+	}
+	if r.Payments[0].Amount.Amount != 65000 {
+		t.Errorf("сумма платежа должна включать чаевые, получили %s", r.Payments[0].Amount)
+	}
+}
+
+func TestHTML_EscapesContent(t *testing.T) {
+	html, err := receipt.HTML(testReceipt())
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if bytes.Contains(html, []byte("<карамель>")) || !bytes.Contains(html, []byte("&lt;карамель&gt;")) {
+		t.Error("названия позиций должны экранироваться")
+	}
+}
+
+func TestPDF_Structure(t *testing.T) {
+	pdf := receipt.PDF(testReceipt())
+	if !bytes.HasPrefix(pdf, []byte("%PDF-")) || !bytes.HasSuffix(pdf, []byte("%%EOF\n")) {
+		t.Fatal("документ должен начинаться с заголовка PDF и заканчиваться маркером конца")
+	}
+	if !bytes.Contains(pdf, []byte("xref")) || !bytes.Contains(pdf, []byte("/FontFile2")) {
+		t.Error("в документе нет таблицы ссылок или встроенного шрифта")
+	}
+	// кириллица выводится глифами встроенного шрифта и копируется как текст
+	if !bytes.Contains(pdf, []byte("<0432>")) {
+		t.Error("в таблице ToUnicode нет кириллицы")
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	if format, err := receipt.ParseFormat(""); err != nil || format != receipt.FormatText80 {
+		t.Errorf("по умолчанию ожидали text80, получили %q, %v", format, err)
+	}
+	if _, err := receipt.ParseFormat("docx"); err == nil {
+		t.Error("неизвестный формат должен возвращать ошибку")
+	}
+}
@@ -0,0 +1,162 @@
+package receipt
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Ширина строки чека в символах моноширинного шрифта принтера.
+const (
+	Width58 = 32 // лента 58 мм
+	Width80 = 48 // лента 80 мм
+)
+
+// Text формирует чек в виде текста шириной width символов.
+func Text(r *Receipt, width int) string {
+	return strings.Join(textLines(r, width), "\n") + "\n"
+}
+
+// textLines раскладывает чек по строкам шириной не более width символов.
+func textLines(r *Receipt, width int) []string {
+	var lines []string
+	add := func(s ...string) { lines = append(lines, s...) }
+	rule := strings.Repeat("-", width)
+
+	for _, s := range []string{r.Shop.Name, r.Shop.Address} {
+		if s == "" {
+			continue
+		}
+		for _, line := range wrap(s, width) {
+			add(center(line, width))
+		}
+	}
+	if r.Shop.INN != "" {
+		add(center("ИНН "+r.Shop.INN, width))
+	}
+	if r.Shop.Phone != "" {
+		add(center("Тел. "+r.Shop.Phone, width))
+	}
+	add(rule)
+	add(pair(r.Title, "№ "+r.Number, width)...)
+	add(r.IssuedAt.Format("02.01.2006 15:04"))
+	add(rule)
+
+	for _, line := range r.Lines {
+		add(wrap(line.Name, width)...)
+		for _, modifier := range line.Modifiers {
+			add(wrap("  + "+modifier, width)...)
+		}
+		add(pair(fmt.Sprintf("  %d x %s", line.Quantity, line.Price), line.Amount.String(), width)...)
+	}
+	add(rule)
+
+	add(pair("Подытог", r.Subtotal.String(), width)...)
+	for _, discount := range r.Discounts {
+		add(pair(discount.Name, discount.Amount.Neg().String(), width)...)
+	}
+	if !r.TaxInclusive {
+		for _, tax := range r.Taxes {
+			add(pair(taxName(tax.Rate), tax.Tax.String(), width)...)
+		}
+	}
+	add(pair("ИТОГО", r.Total.String(), width)...)
+	if r.TaxInclusive {
+		for _, tax := range r.Taxes {
+			add(pair("  в т.ч. "+taxName(tax.Rate), tax.Tax.String(), width)...)
+		}
+	}
+	if r.Points != nil {
+		add(pair(r.Points.Name, r.Points.Amount.Neg().String(), width)...)
+	}
+
+	if len(r.Payments) > 0 || r.Tip.IsPositive() {
+		add(rule)
+	}
+	for _, payment := range r.Payments {
+		add(pair(payment.Method, payment.Amount.String(), width)...)
+		if payment.Tendered.IsPositive() {
+			add(pair("  Получено", payment.Tendered.String(), width)...)
+			add(pair("  Сдача", payment.Change.String(), width)...)
+		}
+	}
+	if r.Tip.IsPositive() {
+		add(pair("Чаевые", r.Tip.String(), width)...)
+	}
+
+	if r.Shop.Footer != "" {
+		add(rule)
+		for _, line := range wrap(r.Shop.Footer, width) {
+			add(center(line, width))
+		}
+	}
+	return lines
+}
+
+// center выравнивает строку по центру.
+func center(s string, width int) string {
+	pad := (width - utf8.RuneCountInString(s)) / 2
+	if pad <= 0 {
+		return s
+	}
+	return strings.Repeat(" ", pad) + s
+}
+
+// pair выводит left слева и right справа. Если вместе они не помещаются,
+// left переносится, а right выравнивается по правому краю последней строки.
+func pair(left, right string, width int) []string {
+	rightLen := utf8.RuneCountInString(right)
+	lines := wrap(left, width)
+	last := lines[len(lines)-1]
+	gap := width - utf8.RuneCountInString(last) - rightLen
+	if gap < 1 {
+		return append(lines, strings.Repeat(" ", max(width-rightLen, 0))+right)
+	}
+	lines[len(lines)-1] = last + strings.Repeat(" ", gap) + right
+	return lines
+}
+
+// wrap разбивает строку по словам на строки не длиннее width символов.
+// Отступ в начале строки сохраняется на всех строках, слишком длинные слова режутся.
+func wrap(s string, width int) []string {
+	text := strings.TrimLeft(s, " ")
+	indent := s[:len(s)-len(text)]
+	if len(indent) >= width {
+		indent = ""
+	}
+	lines := wrapWords(text, width-len(indent))
+	for i := range lines {
+		lines[i] = indent + lines[i]
+	}
+	return lines
+}
+
+// wrapWords разбивает текст по словам на строки не длиннее width символов.
+func wrapWords(s string, width int) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(s) {
+		for utf8.RuneCountInString(word) > width {
+			if line != "" {
+				lines = append(lines, line)
+				line = ""
+			}
+			runes := []rune(word)
+			lines = append(lines, string(runes[:width]))
+			word = string(runes[width:])
+		}
+		switch {
+		case line == "":
+			line = word
+		case utf8.RuneCountInString(line)+1+utf8.RuneCountInString(word) <= width:
+			line += " " + word
+		default:
+			lines = append(lines, line)
+			line = word
+		}
+	}
+	if line != "" || len(lines) == 0 {
+		lines = append(lines, line)
+	}
+	return lines
+}
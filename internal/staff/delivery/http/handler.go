@@ -0,0 +1,123 @@
+package http
+
+import (
+	"coffe/internal/common"
+	"coffe/internal/staff/usecase"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StaffHandler struct {
+	shiftUsecase *usecase.ShiftUsecase
+}
+
+func NewStaffHandler(shiftUsecase *usecase.ShiftUsecase) *StaffHandler {
+	return &StaffHandler{shiftUsecase: shiftUsecase}
+}
+
+// открытие смены текущим сотрудником
+func (h *StaffHandler) StartShift(ctx *gin.Context) {
+	user, ok := currentUser(ctx)
+	if !ok {
+		return
+	}
+
+	shift, err := h.shiftUsecase.StartShift(ctx.Request.Context(), user.ID)
+	if err != nil {
+		if errors.Is(err, usecase.ErrShiftOpen) {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка открытия смены"})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, shift)
+}
+
+// закрытие смены текущим сотрудником
+func (h *StaffHandler) EndShift(ctx *gin.Context) {
+	user, ok := currentUser(ctx)
+	if !ok {
+		return
+	}
+
+	shift, err := h.shiftUsecase.EndShift(ctx.Request.Context(), user.ID)
+	if err != nil {
+		if errors.Is(err, usecase.ErrNoOpenShift) {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка закрытия смены"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, shift)
+}
+
+// смены сотрудников за день (?date=ГГГГ-ММ-ДД, по умолчанию сегодня)
+func (h *StaffHandler) GetShifts(ctx *gin.Context) {
+	day, ok := dateQuery(ctx)
+	if !ok {
+		return
+	}
+
+	shifts, err := h.shiftUsecase.GetShifts(ctx.Request.Context(), day)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения смен"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"shifts": shifts,
+		"total":  len(shifts),
+	})
+}
+
+// распределение чаевых за день по отработанным часам (?date=ГГГГ-ММ-ДД)
+func (h *StaffHandler) GetTipPool(ctx *gin.Context) {
+	day, ok := dateQuery(ctx)
+	if !ok {
+		return
+	}
+
+	pool, err := h.shiftUsecase.TipPool(ctx.Request.Context(), day)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка расчета чаевых"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, pool)
+}
+
+// currentUser получает текущего пользователя из контекста запроса
+func currentUser(ctx *gin.Context) (*common.User, bool) {
+	userInterface, exists := ctx.Get("user")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Пользователь не авторизован"})
+		return nil, false
+	}
+	user, ok := userInterface.(*common.User)
+	if !ok {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения пользователя"})
+		return nil, false
+	}
+	return user, true
+}
+
+// dateQuery разбирает день из параметра date; без параметра - сегодня
+func dateQuery(ctx *gin.Context) (time.Time, bool) {
+	date := ctx.Query("date")
+	if date == "" {
+		return time.Now(), true
+	}
+	day, err := time.ParseInLocation("2006-01-02", date, time.Local)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат даты, ожидается ГГГГ-ММ-ДД"})
+		return time.Time{}, false
+	}
+	return day, true
+}
@@ -0,0 +1,28 @@
+package http
+
+import (
+	"coffe/internal/middleware"
+	userentity "coffe/internal/user/entity"
+	"coffe/internal/user/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupStaffRoutes настраивает маршруты смен сотрудников и распределения чаевых
+func SetupStaffRoutes(router *gin.RouterGroup, handler *StaffHandler, jwtMiddleware *middleware.JWTMiddleware, permissionUC usecase.PermissionUsecase) {
+	shifts := router.Group("/staff/shifts")
+	shifts.Use(jwtMiddleware.Authenticate())
+	shifts.Use(jwtMiddleware.RequireRole(userentity.RoleAdmin, userentity.RoleManager))
+	{
+		shifts.POST("/start", handler.StartShift)
+		shifts.POST("/end", handler.EndShift)
+	}
+
+	admin := router.Group("/admin")
+	admin.Use(jwtMiddleware.Authenticate())
+	admin.Use(jwtMiddleware.RequireRole(userentity.RoleAdmin, userentity.RoleManager))
+	{
+		admin.GET("/shifts", middleware.PermissionMiddleware(permissionUC, "read_order"), handler.GetShifts)
+		admin.GET("/tips/pool", middleware.PermissionMiddleware(permissionUC, "read_order"), handler.GetTipPool)
+	}
+}
@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"coffe/internal/common"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Shift представляет рабочую смену сотрудника. Открытая смена не имеет времени окончания.
+type Shift struct {
+	ID        uuid.UUID    `json:"id" db:"id"`
+	UserID    uuid.UUID    `json:"user_id" db:"user_id" gorm:"index"`
+	User      *common.User `json:"user,omitempty" db:"user"`
+	StartedAt time.Time    `json:"started_at" db:"started_at" gorm:"index"`
+	EndedAt   *time.Time   `json:"ended_at,omitempty" db:"ended_at"`
+	CreatedAt time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at" db:"updated_at"`
+}
+
+// IsOpen сообщает, что смена еще не закрыта.
+func (s *Shift) IsOpen() bool {
+	return s.EndedAt == nil
+}
+
+// Worked возвращает время смены внутри периода [from, to). Открытая смена
+// считается до момента now.
+func (s *Shift) Worked(from, to, now time.Time) time.Duration {
+	end := now
+	if s.EndedAt != nil {
+		end = *s.EndedAt
+	}
+	start := s.StartedAt
+	if start.Before(from) {
+		start = from
+	}
+	if end.After(to) {
+		end = to
+	}
+	if !end.After(start) {
+		return 0
+	}
+	return end.Sub(start)
+}
+
+// TipShare описывает долю сотрудника в пуле чаевых.
+type TipShare struct {
+	UserID uuid.UUID    `json:"user_id"`
+	Name   string       `json:"name"`
+	Hours  float64      `json:"hours"` // отработано за день
+	Amount common.Money `json:"amount"`
+}
+
+// TipPool описывает распределение чаевых за день между сотрудниками по отработанным часам.
+type TipPool struct {
+	Date          string       `json:"date"`
+	Total         common.Money `json:"total"`         // чаевые за день
+	Hours         float64      `json:"hours"`         // всего отработано сотрудниками
+	Undistributed common.Money `json:"undistributed"` // чаевые без смен, которым их можно распределить
+	Shares        []*TipShare  `json:"shares"`
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"coffe/internal/staff/entity"
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ShiftRepository определяет методы для работы со сменами сотрудников.
+type ShiftRepository interface {
+	Create(ctx context.Context, shift *entity.Shift) error                        // открыть смену
+	Update(ctx context.Context, shift *entity.Shift) error                        // обновить смену
+	GetOpen(ctx context.Context, userID uuid.UUID) (*entity.Shift, error)         // открытая смена сотрудника
+	GetInPeriod(ctx context.Context, from, to time.Time) ([]*entity.Shift, error) // смены, пересекающие период, с сотрудниками
+}
@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"coffe/internal/common"
+	"coffe/internal/staff/entity"
+	"coffe/internal/staff/repository"
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrShiftOpen возвращается при открытии смены сотрудником, у которого смена уже открыта.
+var ErrShiftOpen = errors.New("смена уже открыта")
+
+// ErrNoOpenShift возвращается при закрытии смены, если открытой смены нет.
+var ErrNoOpenShift = errors.New("нет открытой смены")
+
+// TipSource возвращает сумму чаевых, полученных с оплатами заказов за период.
+type TipSource interface {
+	TipsTotal(ctx context.Context, from, to time.Time) (common.Money, error)
+}
+
+// ShiftUsecase ведет смены сотрудников и распределяет между ними чаевые.
+type ShiftUsecase struct {
+	shiftRepo repository.ShiftRepository
+	tips      TipSource
+}
+
+// NewShiftUsecase создает новый экземпляр ShiftUsecase.
+func NewShiftUsecase(shiftRepo repository.ShiftRepository, tips TipSource) *ShiftUsecase {
+	return &ShiftUsecase{shiftRepo: shiftRepo, tips: tips}
+}
+
+// StartShift открывает смену сотрудника.
+func (u *ShiftUsecase) StartShift(ctx context.Context, userID uuid.UUID) (*entity.Shift, error) {
+	if userID == uuid.Nil {
+		return nil, errors.New("user_id не может быть пустым")
+	}
+	if _, err := u.shiftRepo.GetOpen(ctx, userID); err == nil {
+		return nil, ErrShiftOpen
+	}
+
+	shift := &entity.Shift{
+		ID:        uuid.New(),
+		UserID:    userID,
+		StartedAt: time.Now(),
+	}
+	if err := u.shiftRepo.Create(ctx, shift); err != nil {
+		return nil, err
+	}
+	return shift, nil
+}
+
+// EndShift закрывает открытую смену сотрудника.
+func (u *ShiftUsecase) EndShift(ctx context.Context, userID uuid.UUID) (*entity.Shift, error) {
+	shift, err := u.shiftRepo.GetOpen(ctx, userID)
+	if err != nil {
+		return nil, ErrNoOpenShift
+	}
+
+	now := time.Now()
+	shift.EndedAt = &now
+	if err := u.shiftRepo.Update(ctx, shift); err != nil {
+		return nil, err
+	}
+	return shift, nil
+}
+
+// GetShifts возвращает смены, пересекающие календарный день day.
+func (u *ShiftUsecase) GetShifts(ctx context.Context, day time.Time) ([]*entity.Shift, error) {
+	from, to := dayBounds(day)
+	return u.shiftRepo.GetInPeriod(ctx, from, to)
+}
+
+// TipPool распределяет чаевые за календарный день day между сотрудниками
+// пропорционально времени, отработанному ими в этот день.
+func (u *ShiftUsecase) TipPool(ctx context.Context, day time.Time) (*entity.TipPool, error) {
+	from, to := dayBounds(day)
+	total, err := u.tips.TipsTotal(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	shifts, err := u.shiftRepo.GetInPeriod(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := &entity.TipPool{
+		Date:          from.Format("2006-01-02"),
+		Total:         total,
+		Undistributed: common.NewMoney(0),
+		Shares:        []*entity.TipShare{},
+	}
+	now := time.Now()
+	worked := make(map[uuid.UUID]time.Duration)
+	for _, shift := range shifts {
+		duration := shift.Worked(from, to, now)
+		if duration <= 0 {
+			continue
+		}
+		if _, ok := worked[shift.UserID]; !ok {
+			pool.Shares = append(pool.Shares, &entity.TipShare{UserID: shift.UserID, Name: userName(shift)})
+		}
+		worked[shift.UserID] += duration
+	}
+
+	weights := make([]int64, len(pool.Shares))
+	for i, share := range pool.Shares {
+		weights[i] = int64(worked[share.UserID] / time.Minute)
+		share.Hours = hours(worked[share.UserID])
+		pool.Hours += share.Hours
+	}
+	pool.Hours = math.Round(pool.Hours*100) / 100
+
+	amounts := total.AllocateBy(weights)
+	if amounts == nil {
+		pool.Undistributed = total
+		return pool, nil
+	}
+	for i, share := range pool.Shares {
+		share.Amount = amounts[i]
+	}
+	return pool, nil
+}
+
+// dayBounds возвращает начало календарного дня day и начало следующего.
+func dayBounds(day time.Time) (time.Time, time.Time) {
+	from := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	return from, from.AddDate(0, 0, 1)
+}
+
+// hours переводит длительность в часы с округлением до сотых.
+func hours(d time.Duration) float64 {
+	return math.Round(d.Hours()*100) / 100
+}
+
+// userName возвращает имя сотрудника смены для отчета.
+func userName(shift *entity.Shift) string {
+	if shift.User == nil {
+		return shift.UserID.String()
+	}
+	return strings.TrimSpace(shift.User.Name + " " + shift.User.Surname)
+}
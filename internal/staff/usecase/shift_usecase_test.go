@@ -0,0 +1,85 @@
+package usecase_test
+
+import (
+	"coffe/internal/common"
+	"coffe/internal/staff/entity"
+	"coffe/internal/staff/usecase"
+	"coffe/internal/staff/usecase/mocks"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+)
+
+func TestShiftUsecase_TipPool(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockShiftRepo := mocks.NewMockShiftRepository(ctrl)
+	mockTips := mocks.NewMockTipSource(ctrl)
+	shifts := usecase.NewShiftUsecase(mockShiftRepo, mockTips)
+
+	ctx := context.Background()
+	day := time.Date(2026, 3, 10, 0, 0, 0, 0, time.Local)
+	at := func(hour int) *time.Time {
+		moment := day.Add(time.Duration(hour) * time.Hour)
+		return &moment
+	}
+	anna := &common.User{ID: uuid.New(), Name: "Анна", Surname: "Иванова"}
+	oleg := &common.User{ID: uuid.New(), Name: "Олег"}
+
+	mockTips.EXPECT().TipsTotal(ctx, day, day.AddDate(0, 0, 1)).Return(common.NewMoney(100001), nil)
+	mockShiftRepo.EXPECT().GetInPeriod(ctx, day, day.AddDate(0, 0, 1)).Return([]*entity.Shift{
+		// ночная смена с предыдущего дня учитывается только с полуночи
+		{UserID: anna.ID, User: anna, StartedAt: *at(-2), EndedAt: at(4)},
+		{UserID: anna.ID, User: anna, StartedAt: *at(14), EndedAt: at(16)},
+		{UserID: oleg.ID, User: oleg, StartedAt: *at(8), EndedAt: at(12)},
+	}, nil)
+
+	pool, err := shifts.TipPool(ctx, day)
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if pool.Date != "2026-03-10" || pool.Hours != 10 || len(pool.Shares) != 2 {
+		t.Fatalf("неверный пул чаевых: %+v", pool)
+	}
+	// Анна отработала 6 часов из 10, Олег - 4; лишняя копейка достается Анне
+	if pool.Shares[0].Name != "Анна Иванова" || pool.Shares[0].Hours != 6 || pool.Shares[0].Amount.Amount != 60001 {
+		t.Errorf("неверная доля Анны: %+v", pool.Shares[0])
+	}
+	if pool.Shares[1].Hours != 4 || pool.Shares[1].Amount.Amount != 40000 {
+		t.Errorf("неверная доля Олега: %+v", pool.Shares[1])
+	}
+}
+
+func TestShiftUsecase_TipPool_NoShifts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockShiftRepo := mocks.NewMockShiftRepository(ctrl)
+	mockTips := mocks.NewMockTipSource(ctrl)
+	shifts := usecase.NewShiftUsecase(mockShiftRepo, mockTips)
+
+	mockTips.EXPECT().TipsTotal(gomock.Any(), gomock.Any(), gomock.Any()).Return(common.NewMoney(5000), nil)
+	mockShiftRepo.EXPECT().GetInPeriod(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	pool, err := shifts.TipPool(context.Background(), time.Now())
+	if err != nil {
+		t.Fatalf("неожиданная ошибка: %v", err)
+	}
+	if pool.Undistributed.Amount != 5000 || len(pool.Shares) != 0 {
+		t.Errorf("чаевые без смен должны остаться нераспределенными: %+v", pool)
+	}
+}
+
+func TestShiftUsecase_StartShift_AlreadyOpen(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockShiftRepo := mocks.NewMockShiftRepository(ctrl)
+	shifts := usecase.NewShiftUsecase(mockShiftRepo, mocks.NewMockTipSource(ctrl))
+
+	userID := uuid.New()
+	mockShiftRepo.EXPECT().GetOpen(gomock.Any(), userID).Return(&entity.Shift{UserID: userID, StartedAt: time.Now()}, nil)
+
+	if _, err := shifts.StartShift(context.Background(), userID); !errors.Is(err, usecase.ErrShiftOpen) {
+		t.Errorf("ожидали ErrShiftOpen, получили %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+// Package units описывает единицы измерения ингредиентов и пересчет между ними.
+package units
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnknownUnit возвращается для единицы, которой нет в справочнике.
+var ErrUnknownUnit = errors.New("неизвестная единица измерения")
+
+// ErrIncompatibleUnits возвращается, если единицы нельзя пересчитать друг в друга.
+var ErrIncompatibleUnits = errors.New("несовместимые единицы измерения")
+
+// Dimension определяет физическую величину, которую измеряет единица.
+type Dimension string
+
+const (
+	DimensionMass   Dimension = "масса"
+	DimensionVolume Dimension = "объем"
+	DimensionCount  Dimension = "количество"
+)
+
+// Unit описывает единицу измерения. Factor - сколько базовых единиц
+// величины (г, мл, шт) содержится в одной такой единице.
+type Unit struct {
+	Code      string
+	Dimension Dimension
+	Factor    float64
+}
+
+// Стандартные единицы. Code совпадает с тем, что хранится в базе.
+var (
+	Milligram  = Unit{Code: "mg", Dimension: DimensionMass, Factor: 0.001}
+	Gram       = Unit{Code: "g", Dimension: DimensionMass, Factor: 1}
+	Kilogram   = Unit{Code: "kg", Dimension: DimensionMass, Factor: 1000}
+	Milliliter = Unit{Code: "ml", Dimension: DimensionVolume, Factor: 1}
+	Liter      = Unit{Code: "l", Dimension: DimensionVolume, Factor: 1000}
+	Piece      = Unit{Code: "шт", Dimension: DimensionCount, Factor: 1}
+)
+
+// aliases сопоставляет написания единиц со стандартными единицами.
+var aliases = map[string]Unit{
+	"mg": Milligram, "мг": Milligram,
+	"g": Gram, "gr": Gram, "г": Gram, "гр": Gram,
+	"kg": Kilogram, "кг": Kilogram,
+	"ml": Milliliter, "мл": Milliliter,
+	"l": Liter, "л": Liter,
+	"шт": Piece, "pcs": Piece, "pc": Piece,
+}
+
+// Parse находит единицу по коду без учета регистра и точки в конце ("кг.", "ML").
+func Parse(code string) (Unit, error) {
+	normalized := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(code)), ".")
+	unit, ok := aliases[normalized]
+	if !ok {
+		return Unit{}, fmt.Errorf("%w: %q", ErrUnknownUnit, code)
+	}
+	return unit, nil
+}
+
+// Normalize возвращает стандартный код единицы.
+func Normalize(code string) (string, error) {
+	unit, err := Parse(code)
+	if err != nil {
+		return "", err
+	}
+	return unit.Code, nil
+}
+
+// Convert пересчитывает количество из единицы from в единицу to.
+// density - плотность ингредиента в г/мл, нужна только для пересчета
+// между массой и объемом; 0 означает, что плотность не задана.
+func Convert(quantity float64, from, to string, density float64) (float64, error) {
+	fromUnit, err := Parse(from)
+	if err != nil {
+		return 0, err
+	}
+	toUnit, err := Parse(to)
+	if err != nil {
+		return 0, err
+	}
+
+	base := quantity * fromUnit.Factor
+	if fromUnit.Dimension != toUnit.Dimension {
+		base, err = convertDimension(base, fromUnit.Dimension, toUnit.Dimension, density)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %s → %s", err, fromUnit.Code, toUnit.Code)
+		}
+	}
+	return base / toUnit.Factor, nil
+}
+
+// Compatible проверяет, что количество в единице from можно пересчитать в единицу to.
+func Compatible(from, to string, density float64) error {
+	_, err := Convert(1, from, to, density)
+	return err
+}
+
+// convertDimension переводит базовое количество между массой и объемом через плотность.
+func convertDimension(base float64, from, to Dimension, density float64) (float64, error) {
+	switch {
+	case from == DimensionMass && to == DimensionVolume && density > 0:
+		return base / density, nil
+	case from == DimensionVolume && to == DimensionMass && density > 0:
+		return base * density, nil
+	case (from == DimensionMass && to == DimensionVolume) || (from == DimensionVolume && to == DimensionMass):
+		return 0, fmt.Errorf("%w: для ингредиента не задана плотность", ErrIncompatibleUnits)
+	}
+	return 0, ErrIncompatibleUnits
+}
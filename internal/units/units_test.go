@@ -0,0 +1,48 @@
+package units_test
+
+import (
+	"coffe/internal/units"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		quantity float64
+		from, to string
+		density  float64
+		want     float64
+	}{
+		{1.5, "кг", "g", 0, 1500},
+		{250, "g", "kg", 0, 0.25},
+		{1, "L", "мл", 0, 1000},
+		{500, "mg", "г", 0, 0.5},
+		{3, "шт", "pcs", 0, 3},
+		{200, "ml", "g", 1.03, 206}, // молоко
+		{103, "g", "ml", 1.03, 100}, // и обратно
+		{1, "л", "кг", 0.92, 0.92},  // растительное масло
+	}
+	for _, tt := range tests {
+		got, err := units.Convert(tt.quantity, tt.from, tt.to, tt.density)
+		if err != nil {
+			t.Errorf("Convert(%v %s → %s): неожиданная ошибка %v", tt.quantity, tt.from, tt.to, err)
+			continue
+		}
+		if math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Convert(%v %s → %s) = %v, ожидали %v", tt.quantity, tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestConvertErrors(t *testing.T) {
+	if _, err := units.Convert(1, "ml", "g", 0); !errors.Is(err, units.ErrIncompatibleUnits) {
+		t.Errorf("объем → масса без плотности: ожидали ErrIncompatibleUnits, получили %v", err)
+	}
+	if _, err := units.Convert(1, "шт", "g", 1); !errors.Is(err, units.ErrIncompatibleUnits) {
+		t.Errorf("штуки → масса: ожидали ErrIncompatibleUnits, получили %v", err)
+	}
+	if _, err := units.Convert(1, "ложка", "g", 0); !errors.Is(err, units.ErrUnknownUnit) {
+		t.Errorf("неизвестная единица: ожидали ErrUnknownUnit, получили %v", err)
+	}
+}
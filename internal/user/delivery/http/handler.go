@@ -1,23 +1,38 @@
 package http
 
 import (
+	loyaltyentity "coffe/internal/loyalty/entity"
 	"coffe/internal/middleware"
 	"coffe/internal/user/usecase"
+	"context"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
+// StampCardReader возвращает штамп-карты клиента для профиля.
+type StampCardReader interface {
+	GetCards(ctx context.Context, customerID uuid.UUID) ([]*loyaltyentity.StampCard, error)
+}
+
+// ProfileResponse содержит профиль пользователя и его штамп-карты.
+type ProfileResponse struct {
+	*usecase.UserResponse
+	StampCards []*loyaltyentity.StampCard `json:"stamp_cards"`
+}
+
 type UserHandler struct {
 	userUseCase *usecase.UserUseCase
 	middleware  *middleware.JWTMiddleware
+	stampCards  StampCardReader
 }
 
-func NewUserHandler(userUseCase *usecase.UserUseCase, middleware *middleware.JWTMiddleware) *UserHandler {
+func NewUserHandler(userUseCase *usecase.UserUseCase, middleware *middleware.JWTMiddleware, stampCards StampCardReader) *UserHandler {
 	return &UserHandler{
 		userUseCase: userUseCase,
 		middleware:  middleware,
+		stampCards:  stampCards,
 	}
 }
 
@@ -147,7 +162,13 @@ func (h *UserHandler) GetProfile(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, userProfile)
+	stampCards, err := h.stampCards.GetCards(ctx.Request.Context(), id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка получения штамп-карт"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ProfileResponse{UserResponse: userProfile, StampCards: stampCards})
 }
 
 func (h *UserHandler) UpdateProfile(ctx *gin.Context) {
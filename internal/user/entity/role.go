@@ -14,4 +14,5 @@ var PredefinedPermissions = []Permission{
 	{Operation: "read", Resource: "product"},
 	{Operation: "update", Resource: "order"},
 	{Operation: "read", Resource: "order"},
+	{Operation: "refund", Resource: "order"},
 }
@@ -29,7 +29,7 @@ func (r *UserRepository) CreateUser(ctx context.Context, user *common.User) erro
 
 func (r *UserRepository) GetUserByID(ctx context.Context, id uuid.UUID) (*common.User, error) {
 	var user common.User
-	result := r.db.WithContext(ctx).First(&user, id)
+	result := r.db.WithContext(ctx).Preload("Role").First(&user, "id = ?", id)
 	if result.Error != nil {
 		return nil, result.Error
 	}
@@ -38,7 +38,7 @@ func (r *UserRepository) GetUserByID(ctx context.Context, id uuid.UUID) (*common
 
 func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (*common.User, error) {
 	var user common.User
-	result := r.db.WithContext(ctx).Where("email = ?", email).First(&user)
+	result := r.db.WithContext(ctx).Preload("Role").Where("email = ?", email).First(&user)
 	if result.Error != nil {
 		return nil, result.Error
 	}